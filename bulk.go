@@ -0,0 +1,165 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BulkInsertChunkSize is the max number of rows in one multi-row INSERT statement
+// issued by the bulk creating functions, e.g. CreateAppointments.
+var BulkInsertChunkSize = 500
+
+// bulkDefault is a row value of bulkInsert to be given the column default, i.e. DEFAULT.
+type bulkDefault struct{}
+
+// bulkMapRows turns a slice of named params into the columns and the row values for bulkInsert,
+// stamping the created_at and updated_at like the CreateX functions do. The columns missing
+// in a map get their default value.
+func bulkMapRows(ams []map[string]interface{}) (keys []string, rows [][]interface{}) {
+	t := time.Now()
+	keySet := map[string]bool{}
+	for _, am := range ams {
+		for _, v := range []string{"created_at", "updated_at"} {
+			if am[v] == nil {
+				am[v] = t
+			}
+		}
+		for k := range am {
+			keySet[k] = true
+		}
+	}
+	for k := range keySet {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows = make([][]interface{}, len(ams))
+	for i, am := range ams {
+		row := make([]interface{}, len(keys))
+		for j, k := range keys {
+			if v, ok := am[k]; ok {
+				row[j] = v
+			} else {
+				row[j] = bulkDefault{}
+			}
+		}
+		rows[i] = row
+	}
+	return keys, rows
+}
+
+// bulkInsert inserts the rows, each a list of values of the columns keys, into table of the model with
+// multi-row INSERT statements of at most BulkInsertChunkSize rows in a transaction,
+// and returns the ids of the inserted rows in order.
+//
+// PostgreSQL and SQLite return the ids by a RETURNING clause. For MySQL the ids are counted
+// from the LastInsertId() of each statement, as InnoDB allocates consecutive ids to the rows
+// of a multi-row INSERT, but only if they're allocated consecutively, see consecutiveIds.
+// Otherwise each row is inserted by a statement of its own.
+func bulkInsert(ctx context.Context, model, table string, keys []string, rows [][]interface{}) ([]int64, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	chunkSize := BulkInsertChunkSize
+	if chunkSize <= 0 {
+		chunkSize = len(rows)
+	}
+	driver := driverName()
+	if driver == "mysql" && !autoIncs.consecutiveIds(ctx, model) {
+		chunkSize = 1
+	}
+	ids := make([]int64, 0, len(rows))
+	err := WithTxContext(ctx, func(tx *sqlx.Tx) error {
+		// the transaction may be retried, see WithTxContext
+		ids = ids[:0]
+		for start := 0; start < len(rows); start += chunkSize {
+			end := start + chunkSize
+			if end > len(rows) {
+				end = len(rows)
+			}
+			holders := make([]string, 0, end-start)
+			args := []interface{}{}
+			for _, row := range rows[start:end] {
+				if len(row) != len(keys) {
+					return fmt.Errorf("Wrong number of values in a row of %s: %d for %d columns", table, len(row), len(keys))
+				}
+				marks := make([]string, len(row))
+				for i, v := range row {
+					if _, ok := v.(bulkDefault); ok {
+						marks[i] = "DEFAULT"
+						continue
+					}
+					marks[i] = "?"
+					args = append(args, v)
+				}
+				holders = append(holders, "("+strings.Join(marks, ",")+")")
+			}
+			sqlStr := fmt.Sprintf(`INSERT INTO %s (%s) VALUES %s`, table, strings.Join(keys, ","), strings.Join(holders, ","))
+			switch driver {
+			case "mysql":
+				result, err := dbExec(ctx, tx, model, tx.Rebind(sqlStr), args...)
+				if err != nil {
+					return err
+				}
+				firstId, err := result.LastInsertId()
+				if err != nil {
+					return err
+				}
+				for i := 0; i < end-start; i++ {
+					ids = append(ids, firstId+int64(i))
+				}
+			default:
+				chunkIds := []int64{}
+				err := dbSelect(ctx, tx, model, &chunkIds, tx.Rebind(sqlStr+" RETURNING id"), args...)
+				if err != nil {
+					return err
+				}
+				ids = append(ids, chunkIds...)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// autoIncSettings are the auto increment settings of the MySQL server of DB, safe for concurrent use.
+type autoIncSettings struct {
+	mu          sync.Mutex
+	db          *sqlx.DB
+	consecutive bool
+}
+
+// autoIncs are the auto increment settings of the package.
+var autoIncs = &autoIncSettings{}
+
+// consecutiveIds reports whether the MySQL server of DB allocates consecutive ids to the rows of a
+// multi-row INSERT of the model, i.e. its innodb_autoinc_lock_mode is 0 (traditional) or 1 (consecutive) and its
+// auto_increment_increment is 1. The interleaved lock mode 2, the default of MySQL 8, may interleave
+// the ids with the ones of the concurrent INSERTs. The settings are queried once for each DB, and
+// the ids are taken as not consecutive if they can't be queried.
+func (s *autoIncSettings) consecutiveIds(ctx context.Context, model string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == DB {
+		return s.consecutive
+	}
+	settings := struct {
+		LockMode  int64 `db:"lock_mode"`
+		Increment int64 `db:"increment"`
+	}{}
+	err := dbGet(withoutTx(ctx), DB, model, &settings, "SELECT @@innodb_autoinc_lock_mode AS lock_mode, @@auto_increment_increment AS increment")
+	if err != nil {
+		logger().Warn("Query auto increment settings error, the rows are inserted one by one", "error", err)
+		return false
+	}
+	s.db, s.consecutive = DB, settings.LockMode <= 1 && settings.Increment == 1
+	return s.consecutive
+}
@@ -0,0 +1,137 @@
+package models
+
+import "context"
+
+// The interfaces below are the optional lifecycle callbacks of the models.
+// A model gets a callback by implementing the method in a file of its own in
+// this package, e.g. a hooks.go next to the generated gor_*.go files, so that
+// the callbacks survive a regeneration of the models:
+//
+//	func (_patient *Patient) BeforeCreate() error { ... }
+//
+// The callbacks are run by the methods of a model object, i.e. Create(), Save(),
+// Update(), UpdateAttributes() and Destroy(), but not by UpdateColumns() which
+// writes the columns as they are. The functions working on ids or
+// attributes maps, e.g. CreatePatient() or DestroyPatients(), have no object to
+// call them on, so they run no callbacks.
+//
+// An error returned by a Before callback aborts the operation and is returned
+// to the caller, an error returned by an After callback is returned to the
+// caller after the record has been written.
+//
+// In a transaction of WithTxContext, see TxContext, the Before and After
+// callbacks are run with the statements, so they're run again if the transaction
+// is retried, while AfterCommit is only run once it's committed and its error
+// is returned by WithTxContext.
+
+// BeforeValidateHook is called before the struct validation of Create() and Save().
+type BeforeValidateHook interface {
+	BeforeValidate() error
+}
+
+// BeforeCreateHook is called after the validation and before a record is inserted.
+type BeforeCreateHook interface {
+	BeforeCreate() error
+}
+
+// AfterCreateHook is called after a record is inserted.
+type AfterCreateHook interface {
+	AfterCreate() error
+}
+
+// BeforeUpdateHook is called before a record is updated.
+type BeforeUpdateHook interface {
+	BeforeUpdate() error
+}
+
+// AfterUpdateHook is called after a record is updated.
+type AfterUpdateHook interface {
+	AfterUpdate() error
+}
+
+// BeforeDestroyHook is called before a record is destroyed.
+type BeforeDestroyHook interface {
+	BeforeDestroy() error
+}
+
+// AfterDestroyHook is called after a record is destroyed.
+type AfterDestroyHook interface {
+	AfterDestroy() error
+}
+
+// AfterCommitHook is called last, once the change of a create, update or destroy
+// is committed to the database.
+type AfterCommitHook interface {
+	AfterCommit() error
+}
+
+// runBeforeValidate calls BeforeValidate on m if it implements BeforeValidateHook.
+func runBeforeValidate(m interface{}) error {
+	if h, ok := m.(BeforeValidateHook); ok {
+		return h.BeforeValidate()
+	}
+	return nil
+}
+
+// runBeforeCreate calls BeforeCreate on m if it implements BeforeCreateHook.
+func runBeforeCreate(m interface{}) error {
+	if h, ok := m.(BeforeCreateHook); ok {
+		return h.BeforeCreate()
+	}
+	return nil
+}
+
+// runAfterCreate calls AfterCreate on m and then AfterCommit, see runAfterCommit, if it implements them.
+func runAfterCreate(ctx context.Context, m interface{}) error {
+	if h, ok := m.(AfterCreateHook); ok {
+		if err := h.AfterCreate(); err != nil {
+			return err
+		}
+	}
+	return runAfterCommit(ctx, m)
+}
+
+// runBeforeUpdate calls BeforeUpdate on m if it implements BeforeUpdateHook.
+func runBeforeUpdate(m interface{}) error {
+	if h, ok := m.(BeforeUpdateHook); ok {
+		return h.BeforeUpdate()
+	}
+	return nil
+}
+
+// runAfterUpdate calls AfterUpdate on m and then AfterCommit, see runAfterCommit, if it implements them.
+func runAfterUpdate(ctx context.Context, m interface{}) error {
+	if h, ok := m.(AfterUpdateHook); ok {
+		if err := h.AfterUpdate(); err != nil {
+			return err
+		}
+	}
+	return runAfterCommit(ctx, m)
+}
+
+// runBeforeDestroy calls BeforeDestroy on m if it implements BeforeDestroyHook.
+func runBeforeDestroy(m interface{}) error {
+	if h, ok := m.(BeforeDestroyHook); ok {
+		return h.BeforeDestroy()
+	}
+	return nil
+}
+
+// runAfterDestroy calls AfterDestroy on m and then AfterCommit, see runAfterCommit, if it implements them.
+func runAfterDestroy(ctx context.Context, m interface{}) error {
+	if h, ok := m.(AfterDestroyHook); ok {
+		if err := h.AfterDestroy(); err != nil {
+			return err
+		}
+	}
+	return runAfterCommit(ctx, m)
+}
+
+// runAfterCommit calls AfterCommit on m if it implements AfterCommitHook, after the transaction
+// ctx is bound to is committed or at once if there is none, see onCommit.
+func runAfterCommit(ctx context.Context, m interface{}) error {
+	if h, ok := m.(AfterCommitHook); ok {
+		return onCommit(ctx, h.AfterCommit)
+	}
+	return nil
+}
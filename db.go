@@ -1,26 +1,240 @@
 package models
 
 import (
-	"log"
+	"context"
+	"errors"
+	"sync"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 )
 
+// DB is the database of the models, it's connected by Open. The models return ErrNotOpen before that.
 var DB *sqlx.DB
 
-func init() {
-	var err error
-	driver_name := "mysql"
-	if driver_name == "" {
-		log.Fatal("Invalid driver name")
+// DefaultDSN is the DSN of the MySQL development database of go-on-rails, e.g. for Open("mysql", DefaultDSN).
+const DefaultDSN = "root:@tcp(localhost:3306)/go-on-rails_development?charset=utf8&parseTime=True"
+
+// StorageLocation is the location of the time values stored in the database,
+// Open pins both the driver and the MySQL session to it, so the stored times
+// don't depend on the time zone of the app or the database server. It must be
+// set before Open, and a location other than UTC must be a named one, e.g. of
+// time.LoadLocation, which is in the time zone tables of the MySQL server.
+// The times read back are in this location too, use In() to show them in
+// the local time of a clinic or physician, see ClinicLocation.
+var StorageLocation = time.UTC
+
+// Open connects DB to the database of the driver driverName, e.g. "mysql", by the dsn and pings it.
+// It must be called before the models are used, e.g. in main, and the error is logged and returned
+// rather than exiting the app. See CloseDB for closing it. The loc and time_zone parameters of a
+// MySQL dsn are set to StorageLocation.
+func Open(driverName, dsn string) error {
+	if driverName == "" {
+		err := errors.New("Invalid driver name")
+		logger().Error("Open database error", "error", err)
+		return err
 	}
-	dsn := "root:@tcp(localhost:3306)/go-on-rails_development?charset=utf8&parseTime=True&loc=Local"
 	if dsn == "" {
-		log.Fatal("Invalid DSN")
+		err := errors.New("Invalid DSN")
+		logger().Error("Open database error", "driver", driverName, "error", err)
+		return err
+	}
+	if driverName == "mysql" {
+		var err error
+		if dsn, err = storageDSN(dsn); err != nil {
+			logger().Error("Open database error", "driver", driverName, "error", err)
+			return err
+		}
+	}
+	db, err := sqlx.Connect(driverName, dsn)
+	if err != nil {
+		logger().Error("Open database error", "driver", driverName, "error", err)
+		return err
+	}
+	DB = db
+	return nil
+}
+
+// storageDSN returns the MySQL dsn with its loc and time_zone parameters set to StorageLocation, i.e. the
+// driver reads and writes the times in it and the session time zone of the server is it.
+func storageDSN(dsn string) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", err
+	}
+	loc := StorageLocation
+	if loc == nil {
+		loc = time.UTC
+	}
+	cfg.Loc = loc
+	timeZone := loc.String()
+	if loc == time.UTC {
+		// the offset works without the time zone tables of the server
+		timeZone = "+00:00"
+	}
+	if cfg.Params == nil {
+		cfg.Params = map[string]string{}
+	}
+	cfg.Params["time_zone"] = "'" + timeZone + "'"
+	return cfg.FormatDSN(), nil
+}
+
+// CloseDB closes the prepared statements of the models and DB, it's called on shutdown.
+func CloseDB() error {
+	if DB == nil {
+		return ErrNotOpen
+	}
+	stmts.close()
+	return DB.Close()
+}
+
+// rebind is same as DB.Rebind, the query is left as it is if DB isn't open, so its use fails with ErrNotOpen.
+func rebind(query string) string {
+	if DB == nil {
+		return query
+	}
+	return DB.Rebind(query)
+}
+
+// driverName returns the driver name of DB, or "" if it isn't open.
+func driverName() string {
+	if DB == nil {
+		return ""
+	}
+	return DB.DriverName()
+}
+
+// WithTx runs fn in a database transaction. The transaction is committed if fn
+// returns nil, otherwise it's rolled back and the error of fn is returned.
+func WithTx(fn func(tx *sqlx.Tx) error) error {
+	return WithTxContext(context.Background(), fn)
+}
+
+// WithTxContext is same as WithTx but the transaction is begun with the context ctx,
+// it's rolled back if ctx is done before it's committed. Use TxContext to run the model
+// functions in the transaction, their AfterCommit callbacks are run once it's committed.
+// If ctx is bound to a transaction already, fn runs in that one instead.
+//
+// The whole transaction is retried by QueryRetry if it's failed with a deadlock or a lock wait timeout,
+// so fn may be called more than once and should have no side effects but the queries on tx. E.g. it
+// should reset what it collects, and load the objects it saves rather than save the ones loaded before,
+// as a saved object keeps the lock_version and the other columns of the rolled back attempt.
+func WithTxContext(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	if tx := ctxTx(ctx); tx != nil {
+		return fn(tx)
+	}
+	var committed []func() error
+	err := QueryRetry.do(ctx, isLockError, func() (err error) {
+		committed, err = withTx(ctx, fn)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	errs := []error{}
+	for _, f := range committed {
+		if err := f(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// withTx runs fn in a transaction begun with ctx once, see WithTxContext, and returns the
+// functions queued by onCommit to be run after it's committed.
+func withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) (committed []func() error, err error) {
+	if DB == nil {
+		return nil, ErrNotOpen
 	}
-	DB, err = sqlx.Connect(driver_name, dsn)
+	tx, err := DB.BeginTxx(ctx, nil)
 	if err != nil {
-		log.Fatal(err)
+		logger().Error("Begin transaction error", "error", err)
+		return nil, err
+	}
+	state := &txState{}
+	txStates.Store(tx, state)
+	defer txStates.Delete(tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+	if err = fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			logger().Error("Rollback transaction error", "error", rbErr)
+		}
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	return state.committed, nil
+}
+
+// txKey is the context key of the transaction bound by TxContext.
+type txKey struct{}
+
+// txState is the state of a transaction begun by withTx.
+type txState struct {
+	mu sync.Mutex
+	// committed are the functions to be run after the transaction is committed.
+	committed []func() error
+}
+
+// txStates are the states of the transactions of withTx by their *sqlx.Tx.
+var txStates sync.Map
+
+// TxContext returns a copy of ctx bound to the transaction tx, the model functions called with it,
+// e.g. FindPatientContext or (*Patient).SaveContext, run their queries in tx rather than on DB:
+//
+//	err := models.WithTxContext(ctx, func(tx *sqlx.Tx) error {
+//		ctx := models.TxContext(ctx, tx)
+//		...
+//	})
+//
+// The AfterCommit callbacks of the changes made in a transaction of WithTxContext are run once it's
+// committed, and never if it's rolled back. The ones of another transaction are run at once.
+func TxContext(ctx context.Context, tx *sqlx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// ctxTx returns the transaction ctx is bound to by TxContext, or nil.
+func ctxTx(ctx context.Context) *sqlx.Tx {
+	tx, _ := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx
+}
+
+// withoutTx returns a copy of ctx which isn't bound to a transaction, e.g. for the queries run after
+// the transaction of ctx is committed.
+func withoutTx(ctx context.Context) context.Context {
+	return TxContext(ctx, nil)
+}
+
+// inTransaction runs fn in a transaction of WithTxContext with ctx bound to it, see TxContext.
+func inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return WithTxContext(ctx, func(tx *sqlx.Tx) error {
+		return fn(TxContext(ctx, tx))
+	})
+}
+
+// onCommit runs f after the transaction of WithTxContext ctx is bound to is committed, or at once
+// if there is none. The error of f is returned by WithTxContext then.
+func onCommit(ctx context.Context, f func() error) error {
+	tx := ctxTx(ctx)
+	if tx == nil {
+		return f()
+	}
+	v, ok := txStates.Load(tx)
+	if !ok {
+		return f()
 	}
+	state := v.(*txState)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.committed = append(state.committed, f)
+	return nil
 }
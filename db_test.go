@@ -0,0 +1,104 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestMain(m *testing.M) {
+	Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	os.Exit(m.Run())
+}
+
+// newMockDB points DB to a sqlmock database of the driver for the test, the unmet expectations fail the test.
+func newMockDB(t *testing.T, driver string) sqlmock.Sqlmock {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := DB
+	DB = sqlx.NewDb(db, driver)
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		db.Close()
+		DB = saved
+	})
+	return mock
+}
+
+// committedPatients counts the AfterCommit callbacks of the patients.
+var committedPatients int
+
+func (_patient *Patient) AfterCommit() error {
+	committedPatients++
+	return nil
+}
+
+func TestAfterCommitRunsAfterCommit(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO patients").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	committedPatients = 0
+	err := WithTx(func(tx *sqlx.Tx) error {
+		_patient := &Patient{Name: "John"}
+		if _, err := _patient.CreateContext(TxContext(context.Background(), tx)); err != nil {
+			return err
+		}
+		if committedPatients != 0 {
+			t.Errorf("AfterCommit is run before the commit")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if committedPatients != 1 {
+		t.Errorf("AfterCommit is run %d times, want 1", committedPatients)
+	}
+}
+
+func TestAfterCommitNotRunOnRollback(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO patients").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
+	committedPatients = 0
+	failed := errors.New("failed")
+	err := WithTx(func(tx *sqlx.Tx) error {
+		_patient := &Patient{Name: "John"}
+		if _, err := _patient.CreateContext(TxContext(context.Background(), tx)); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("WithTx error = %v, want %v", err, failed)
+	}
+	if committedPatients != 0 {
+		t.Errorf("AfterCommit is run %d times on the rollback, want 0", committedPatients)
+	}
+}
+
+func TestAfterCommitWithoutTransaction(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	mock.ExpectExec("INSERT INTO patients").WillReturnResult(sqlmock.NewResult(1, 1))
+	committedPatients = 0
+	_patient := &Patient{Name: "John"}
+	if _, err := _patient.Create(); err != nil {
+		t.Fatal(err)
+	}
+	if committedPatients != 1 {
+		t.Errorf("AfterCommit is run %d times, want 1", committedPatients)
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Change is the old and the new value of a changed attribute of a model object,
+// see the Changes() method of the models.
+type Change struct {
+	Old interface{}
+	New interface{}
+}
+
+// changedFields returns the sorted names of the attributes in current which differ from
+// the original ones. All of the attributes are changed if there're no original ones,
+// i.e. the object is not loaded from the database.
+func changedFields(original, current map[string]interface{}) []string {
+	fields := []string{}
+	for k, v := range current {
+		if original == nil || attrChanged(original[k], v) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// diffAttributes returns the changes from the original attributes to the current ones.
+func diffAttributes(original, current map[string]interface{}) map[string]Change {
+	changes := map[string]Change{}
+	for _, k := range changedFields(original, current) {
+		changes[k] = Change{Old: original[k], New: current[k]}
+	}
+	return changes
+}
+
+// attrChanged compares two attribute values, the time values are compared by
+// the instants they represent rather than by their locations.
+func attrChanged(a, b interface{}) bool {
+	switch va := a.(type) {
+	case time.Time:
+		if vb, ok := b.(time.Time); ok {
+			return !va.Equal(vb)
+		}
+	case *time.Time:
+		if vb, ok := b.(*time.Time); ok {
+			if va == nil || vb == nil {
+				return va != vb
+			}
+			return !va.Equal(*vb)
+		}
+	}
+	return !reflect.DeepEqual(a, b)
+}
+
+// namedSets builds the SET list of a named UPDATE statement for the columns cols.
+func namedSets(cols []string) string {
+	sets := make([]string, len(cols))
+	for i, v := range cols {
+		sets[i] = fmt.Sprintf("%s = :%s", v, v)
+	}
+	return strings.Join(sets, ", ")
+}
+
+// markSaved sets the original attributes of the columns keys to the current ones,
+// for the columns written without going through Save.
+func markSaved(original, current map[string]interface{}, keys []string) {
+	if original == nil {
+		return
+	}
+	for _, k := range keys {
+		if v, ok := current[k]; ok {
+			original[k] = v
+		}
+	}
+}
+
+// nullableValue returns the value pointed by the pointer typed field p of a nullable
+// column, or nil for a NULL. So the attributes of the nullable columns are compared
+// and reported by values rather than by pointers.
+func nullableValue(p interface{}) interface{} {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr {
+		return p
+	}
+	if v.IsNil() {
+		return nil
+	}
+	return v.Elem().Interface()
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// ErrStaleObject is returned when a record with a lock_version column is updated
+// but its lock_version no longer matches the one in the database, i.e. the record
+// has been changed by someone else since it was loaded.
+var ErrStaleObject = errors.New("Stale object: the record has been changed by someone else")
+
+// ErrNotOpen is returned when a model is used before DB is connected by Open.
+var ErrNotOpen = errors.New("The database is not open: call Open first")
+
+// ErrNoTransaction is returned when a row locking finder is called without a transaction,
+// a row lock only lasts until the end of the transaction it's taken in.
+var ErrNoTransaction = errors.New("Row locking needs a transaction")
+
+// ConflictError is returned when the appointments to be created overlap existing
+// appointments of the physician, Times are the start times of the overlapping ones.
+type ConflictError struct {
+	Times []time.Time
+}
+
+func (e *ConflictError) Error() string {
+	times := make([]string, len(e.Times))
+	for i, t := range e.Times {
+		times[i] = t.Format(time.RFC3339)
+	}
+	return "Appointment conflicts at " + strings.Join(times, ", ")
+}
+
+// ErrDuplicateKey is returned when a record is written with the value of a unique key
+// which another record has already.
+var ErrDuplicateKey = errors.New("Duplicate key: another record has the unique value")
+
+// isUniqueViolation reports whether err is a unique key violation of any supported driver: the MySQL
+// error 1062, the PostgreSQL SQLSTATE 23505 or a SQLite UNIQUE constraint failure.
+func isUniqueViolation(err error) bool {
+	if err == nil {
+		return false
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1062
+	}
+	// both lib/pq and pgx errors have the SQLSTATE method
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == "23505"
+	}
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// ErrInvalidTransition is returned when the status of an appointment is changed
+// in a way its workflow doesn't allow, e.g. a cancelled appointment is confirmed.
+var ErrInvalidTransition = errors.New("Invalid status transition")
+
+// The errors below are returned wrapped with the function they are returned by, e.g.
+// "FindPatient error: Invalid ID: it can't be zero", test them with errors.Is.
+var (
+	// ErrNotFound is returned when the record to be found doesn't exist. It wraps sql.ErrNoRows
+	// if the record is found by a single row query, e.g. FindPatient.
+	ErrNotFound = errors.New("Record not found")
+	// ErrInvalidID is returned when a record is looked up by a zero id, e.g. the Id of an unsaved object.
+	ErrInvalidID = errors.New("Invalid ID: it can't be zero")
+	// ErrNoIDs is returned when the records are looked up by no ids at all.
+	ErrNoIDs = errors.New("At least one or more ids needed")
+	// ErrEmptyAttributes is returned when a record is created or updated by an empty attributes map.
+	ErrEmptyAttributes = errors.New("Zero key in the attributes map")
+	// ErrNoWhere is returned when the records are destroyed by an empty where clause.
+	ErrNoWhere = errors.New("No WHERE conditions provided")
+	// ErrFirstPage is returned when the previous page of the first page is asked for.
+	ErrFirstPage = errors.New("This's the first page, no previous page yet")
+	// ErrLastPage is returned when the next page of the last page is asked for.
+	ErrLastPage = errors.New("This's the last page, no next page yet")
+	// ErrNoIdOrder is returned when a page is asked for by a page object without the id in its Order.
+	ErrNoIdOrder = errors.New("No id order specified in Order map")
+	// ErrWrongDirection is returned when a page is asked for in a direction other than previous, current or next.
+	ErrWrongDirection = errors.New("Wrong direction: none of previous, current or next")
+	// ErrBlankSQL is returned when the records are updated by a blank SQL clause.
+	ErrBlankSQL = errors.New("A blank SQL clause")
+	// ErrInvalidSlotLength is returned when the open slots are looked for with a slot length which isn't positive.
+	ErrInvalidSlotLength = errors.New("Invalid slot length: it must be positive")
+	// ErrNoOffer is returned when a waitlist without an offered slot is asked to accept or decline it.
+	ErrNoOffer = errors.New("No offer of the waitlist")
+	// ErrOfferExpired is returned when an offer of a waitlist is accepted after WaitlistOfferTTL.
+	ErrOfferExpired = errors.New("The offer of the waitlist is expired")
+	// ErrNoNotifier is returned when the reminders are sent by a ReminderScheduler without a Notifier.
+	ErrNoNotifier = errors.New("No notifier of the reminder scheduler")
+	// ErrNoRecipient is returned when a reminder is sent by an SMTPNotifier without a Recipient.
+	ErrNoRecipient = errors.New("No recipient of the SMTP notifier")
+)
+
+// notFound wraps err, an error of a query, with ErrNotFound if it's sql.ErrNoRows.
+func notFound(model string, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s: %w", ErrNotFound, model, err)
+	}
+	return err
+}
+
+// ValidationError is returned when a model struct fails its validation before it's written,
+// Err is the error of the validation, e.g. the govalidator.Errors of the invalid fields.
+type ValidationError struct {
+	Model string
+	// Index is the index of the struct in the slice it's created in bulk with, e.g. by CreatePatients, or -1.
+	Index int
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	msg := "Unknown error"
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if e.Index >= 0 {
+		return fmt.Sprintf("Validate %s struct error at index %d: %s", e.Model, e.Index, msg)
+	}
+	return fmt.Sprintf("Validate %s struct error: %s", e.Model, msg)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -2,30 +2,102 @@
 package models
 
 import (
-	"errors"
+	"context"
+	"database/sql"
 	"fmt"
-	"log"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
 )
 
-// set flags to output more detailed log
-func init() {
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
+// Appointment is the model of the table appointments. The nullable columns are
+// pointer typed fields, so a NULL value is a nil rather than a zero value.
+type Appointment struct {
+	Id                 int64      `json:"id,omitempty" db:"id" valid:"-"`
+	AppointmentDate    *time.Time `json:"appointment_date,omitempty" db:"appointment_date" valid:"-"`
+	PhysicianId        *int64     `json:"physician_id,omitempty" db:"physician_id" valid:"-"`
+	PatientId          *int64     `json:"patient_id,omitempty" db:"patient_id" valid:"-"`
+	SeriesId           *int64     `json:"series_id,omitempty" db:"series_id" valid:"-"`
+	Status             string     `json:"status,omitempty" db:"status" valid:"in(scheduled|confirmed|checked_in|completed|cancelled|no_show)"`
+	CancellationReason *string    `json:"cancellation_reason,omitempty" db:"cancellation_reason" valid:"-"`
+	ConfirmedAt        *time.Time `json:"confirmed_at,omitempty" db:"confirmed_at" valid:"-"`
+	CheckedInAt        *time.Time `json:"checked_in_at,omitempty" db:"checked_in_at" valid:"-"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty" db:"completed_at" valid:"-"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty" db:"cancelled_at" valid:"-"`
+	NoShowAt           *time.Time `json:"no_show_at,omitempty" db:"no_show_at" valid:"-"`
+	IcalUid            *string    `json:"ical_uid,omitempty" db:"ical_uid" valid:"-"`
+	CreatedAt          time.Time  `json:"created_at,omitempty" db:"created_at" valid:"-"`
+	UpdatedAt          time.Time  `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
+	DeletedAt          *time.Time `json:"deleted_at,omitempty" db:"deleted_at" valid:"-"`
+	LockVersion        int64      `json:"lock_version,omitempty" db:"lock_version" valid:"-"`
+	Physician          Physician  `json:"physician,omitempty" db:"physician" valid:"-"`
+	Patient            Patient    `json:"patient,omitempty" db:"patient" valid:"-"`
+	original           map[string]interface{}
+	savedChanges       map[string]Change
 }
 
-type Appointment struct {
-	Id              int64     `json:"id,omitempty" db:"id" valid:"-"`
-	AppointmentDate time.Time `json:"appointment_date,omitempty" db:"appointment_date" valid:"-"`
-	PhysicianId     int64     `json:"physician_id,omitempty" db:"physician_id" valid:"-"`
-	PatientId       int64     `json:"patient_id,omitempty" db:"patient_id" valid:"-"`
-	CreatedAt       time.Time `json:"created_at,omitempty" db:"created_at" valid:"-"`
-	UpdatedAt       time.Time `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
-	Physician       Physician `json:"physician,omitempty" db:"physician" valid:"-"`
-	Patient         Patient   `json:"patient,omitempty" db:"patient" valid:"-"`
+// Appointment records are soft deleted: Destroy only sets the deleted_at column.
+// The scopes below are used as the FROM target of the finders to filter
+// the soft deleted records out, or in.
+const (
+	appointmentScope            = "(SELECT * FROM appointments WHERE deleted_at IS NULL) AS appointments"
+	appointmentWithDeletedScope = "appointments"
+	appointmentOnlyDeletedScope = "(SELECT * FROM appointments WHERE deleted_at IS NOT NULL) AS appointments"
+)
+
+// attributes returns the values of the changeable columns of the Appointment object by column names.
+func (_appointment *Appointment) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"appointment_date":    nullableValue(_appointment.AppointmentDate),
+		"physician_id":        nullableValue(_appointment.PhysicianId),
+		"patient_id":          nullableValue(_appointment.PatientId),
+		"series_id":           nullableValue(_appointment.SeriesId),
+		"status":              _appointment.Status,
+		"cancellation_reason": nullableValue(_appointment.CancellationReason),
+		"confirmed_at":        nullableValue(_appointment.ConfirmedAt),
+		"checked_in_at":       nullableValue(_appointment.CheckedInAt),
+		"completed_at":        nullableValue(_appointment.CompletedAt),
+		"cancelled_at":        nullableValue(_appointment.CancelledAt),
+		"no_show_at":          nullableValue(_appointment.NoShowAt),
+		"ical_uid":            nullableValue(_appointment.IcalUid),
+	}
+}
+
+// snapshot keeps the current attributes of the Appointment object as the original ones for the dirty tracking.
+func (_appointment *Appointment) snapshot() {
+	_appointment.original = _appointment.attributes()
+}
+
+// snapshotAppointments keeps the current attributes of the loaded Appointment objects as the original ones.
+func snapshotAppointments(_appointments []Appointment) {
+	for i := range _appointments {
+		_appointments[i].snapshot()
+	}
+}
+
+// Changed reports whether any attribute of the Appointment object is changed since it was loaded
+// or saved. An object which is not loaded from the database is always changed.
+func (_appointment *Appointment) Changed() bool {
+	return len(_appointment.ChangedFields()) > 0
+}
+
+// ChangedFields returns the sorted column names of the changed attributes of the Appointment object.
+func (_appointment *Appointment) ChangedFields() []string {
+	return changedFields(_appointment.original, _appointment.attributes())
+}
+
+// Changes returns the old and new values of the changed attributes of the Appointment object by column names.
+func (_appointment *Appointment) Changes() map[string]Change {
+	return diffAttributes(_appointment.original, _appointment.attributes())
+}
+
+// SavedChanges returns the changes written by the last Create, Save, Update or UpdateAttributes
+// of the Appointment object, e.g. for an After callback to audit them.
+func (_appointment *Appointment) SavedChanges() map[string]Change {
+	return _appointment.savedChanges
 }
 
 // DataStruct for the pagination
@@ -44,12 +116,19 @@ type AppointmentPage struct {
 
 // Current get the current page of AppointmentPage object for pagination.
 func (_p *AppointmentPage) Current() ([]Appointment, error) {
+	return _p.CurrentContext(context.Background())
+}
+
+// CurrentContext is same as Current but with the context ctx.
+func (_p *AppointmentPage) CurrentContext(ctx context.Context) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "AppointmentPage.Current", "appointments")
+	defer span.End()
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("AppointmentPage.Current error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -58,7 +137,7 @@ func (_p *AppointmentPage) Current() ([]Appointment, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	appointments, err := FindAppointmentsWhere(whereStr, whereParams...)
+	appointments, err := FindAppointmentsWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -70,15 +149,22 @@ func (_p *AppointmentPage) Current() ([]Appointment, error) {
 
 // Previous get the previous page of AppointmentPage object for pagination.
 func (_p *AppointmentPage) Previous() ([]Appointment, error) {
+	return _p.PreviousContext(context.Background())
+}
+
+// PreviousContext is same as Previous but with the context ctx.
+func (_p *AppointmentPage) PreviousContext(ctx context.Context) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "AppointmentPage.Previous", "appointments")
+	defer span.End()
 	if _p.PageNum == 0 {
-		return nil, errors.New("This's the first page, no previous page yet")
+		return nil, fmt.Errorf("AppointmentPage.Previous error: %w", ErrFirstPage)
 	}
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("AppointmentPage.Previous error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -87,7 +173,7 @@ func (_p *AppointmentPage) Previous() ([]Appointment, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	appointments, err := FindAppointmentsWhere(whereStr, whereParams...)
+	appointments, err := FindAppointmentsWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,15 +186,22 @@ func (_p *AppointmentPage) Previous() ([]Appointment, error) {
 
 // Next get the next page of AppointmentPage object for pagination.
 func (_p *AppointmentPage) Next() ([]Appointment, error) {
+	return _p.NextContext(context.Background())
+}
+
+// NextContext is same as Next but with the context ctx.
+func (_p *AppointmentPage) NextContext(ctx context.Context) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "AppointmentPage.Next", "appointments")
+	defer span.End()
 	if _p.PageNum == _p.TotalPages-1 {
-		return nil, errors.New("This's the last page, no next page yet")
+		return nil, fmt.Errorf("AppointmentPage.Next error: %w", ErrLastPage)
 	}
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("AppointmentPage.Next error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -117,7 +210,7 @@ func (_p *AppointmentPage) Next() ([]Appointment, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	appointments, err := FindAppointmentsWhere(whereStr, whereParams...)
+	appointments, err := FindAppointmentsWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -131,15 +224,22 @@ func (_p *AppointmentPage) Next() ([]Appointment, error) {
 // GetPage is a helper function for the AppointmentPage object to return a corresponding page due to
 // the parameter passed in, i.e. one of "previous, current or next".
 func (_p *AppointmentPage) GetPage(direction string) (ps []Appointment, err error) {
+	return _p.GetPageContext(context.Background(), direction)
+}
+
+// GetPageContext is same as GetPage but with the context ctx.
+func (_p *AppointmentPage) GetPageContext(ctx context.Context, direction string) (ps []Appointment, err error) {
+	ctx, span := startSpan(ctx, "AppointmentPage.GetPage", "appointments")
+	defer span.End()
 	switch direction {
 	case "previous":
-		ps, _ = _p.Previous()
+		ps, err = _p.PreviousContext(ctx)
 	case "next":
-		ps, _ = _p.Next()
+		ps, err = _p.NextContext(ctx)
 	case "current":
-		ps, _ = _p.Current()
+		ps, err = _p.CurrentContext(ctx)
 	default:
-		return nil, errors.New("Error: wrong dircetion! None of previous, current or next!")
+		return nil, fmt.Errorf("AppointmentPage.GetPage error: %w: %q", ErrWrongDirection, direction)
 	}
 	return
 }
@@ -195,8 +295,8 @@ func (_p *AppointmentPage) buildIdRestrict(direction string) (idStr string, idPa
 }
 
 // buildPageCount calculate the TotalItems/TotalPages for the AppointmentPage object.
-func (_p *AppointmentPage) buildPageCount() error {
-	count, err := AppointmentCountWhere(_p.WhereString, _p.WhereParams...)
+func (_p *AppointmentPage) buildPageCount(ctx context.Context) error {
+	count, err := AppointmentCountWhereContext(ctx, _p.WhereString, _p.WhereParams...)
 	if err != nil {
 		return err
 	}
@@ -210,126 +310,195 @@ func (_p *AppointmentPage) buildPageCount() error {
 
 // FindAppointment find a single appointment by an ID.
 func FindAppointment(id int64) (*Appointment, error) {
+	return FindAppointmentContext(context.Background(), id)
+}
+
+// FindAppointmentContext is same as FindAppointment but with the context ctx.
+func FindAppointmentContext(ctx context.Context, id int64) (*Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointment", "appointments")
+	defer span.End()
 	if id == 0 {
-		return nil, errors.New("Invalid ID: it can't be zero")
+		return nil, fmt.Errorf("FindAppointment error: %w", ErrInvalidID)
 	}
 	_appointment := Appointment{}
-	err := DB.Get(&_appointment, DB.Rebind(`SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments WHERE appointments.id = ? LIMIT 1`), id)
+	err := dbGet(ctx, DB, "Appointment", &_appointment, rebind(`SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM `+appointmentScope+` WHERE appointments.id = ? LIMIT 1`), id)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_appointment.snapshot()
 	return &_appointment, nil
 }
 
 // FirstAppointment find the first one appointment by ID ASC order.
 func FirstAppointment() (*Appointment, error) {
+	return FirstAppointmentContext(context.Background())
+}
+
+// FirstAppointmentContext is same as FirstAppointment but with the context ctx.
+func FirstAppointmentContext(ctx context.Context) (*Appointment, error) {
+	ctx, span := startSpan(ctx, "FirstAppointment", "appointments")
+	defer span.End()
 	_appointment := Appointment{}
-	err := DB.Get(&_appointment, DB.Rebind(`SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments ORDER BY appointments.id ASC LIMIT 1`))
+	err := dbGet(ctx, DB, "Appointment", &_appointment, rebind(`SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM `+appointmentScope+` ORDER BY appointments.id ASC LIMIT 1`))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_appointment.snapshot()
 	return &_appointment, nil
 }
 
 // FirstAppointments find the first N appointments by ID ASC order.
 func FirstAppointments(n uint32) ([]Appointment, error) {
+	return FirstAppointmentsContext(context.Background(), n)
+}
+
+// FirstAppointmentsContext is same as FirstAppointments but with the context ctx.
+func FirstAppointmentsContext(ctx context.Context, n uint32) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "FirstAppointments", "appointments")
+	defer span.End()
 	_appointments := []Appointment{}
-	sql := fmt.Sprintf("SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments ORDER BY appointments.id ASC LIMIT %v", n)
-	err := DB.Select(&_appointments, DB.Rebind(sql))
+	sql := fmt.Sprintf("SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM "+appointmentScope+" ORDER BY appointments.id ASC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "Appointment", &_appointments, rebind(sql))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotAppointments(_appointments)
 	return _appointments, nil
 }
 
 // LastAppointment find the last one appointment by ID DESC order.
 func LastAppointment() (*Appointment, error) {
+	return LastAppointmentContext(context.Background())
+}
+
+// LastAppointmentContext is same as LastAppointment but with the context ctx.
+func LastAppointmentContext(ctx context.Context) (*Appointment, error) {
+	ctx, span := startSpan(ctx, "LastAppointment", "appointments")
+	defer span.End()
 	_appointment := Appointment{}
-	err := DB.Get(&_appointment, DB.Rebind(`SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments ORDER BY appointments.id DESC LIMIT 1`))
+	err := dbGet(ctx, DB, "Appointment", &_appointment, rebind(`SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM `+appointmentScope+` ORDER BY appointments.id DESC LIMIT 1`))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_appointment.snapshot()
 	return &_appointment, nil
 }
 
 // LastAppointments find the last N appointments by ID DESC order.
 func LastAppointments(n uint32) ([]Appointment, error) {
+	return LastAppointmentsContext(context.Background(), n)
+}
+
+// LastAppointmentsContext is same as LastAppointments but with the context ctx.
+func LastAppointmentsContext(ctx context.Context, n uint32) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "LastAppointments", "appointments")
+	defer span.End()
 	_appointments := []Appointment{}
-	sql := fmt.Sprintf("SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments ORDER BY appointments.id DESC LIMIT %v", n)
-	err := DB.Select(&_appointments, DB.Rebind(sql))
+	sql := fmt.Sprintf("SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM "+appointmentScope+" ORDER BY appointments.id DESC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "Appointment", &_appointments, rebind(sql))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotAppointments(_appointments)
 	return _appointments, nil
 }
 
 // FindAppointments find one or more appointments by the given ID(s).
 func FindAppointments(ids ...int64) ([]Appointment, error) {
+	return FindAppointmentsContext(context.Background(), ids...)
+}
+
+// FindAppointmentsContext is same as FindAppointments but with the context ctx.
+func FindAppointmentsContext(ctx context.Context, ids ...int64) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointments", "appointments")
+	defer span.End()
 	if len(ids) == 0 {
-		msg := "At least one or more ids needed"
-		log.Println(msg)
-		return nil, errors.New(msg)
+		err := fmt.Errorf("FindAppointments error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Appointment")
+		return nil, err
 	}
 	_appointments := []Appointment{}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
-	sql := DB.Rebind(fmt.Sprintf(`SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments WHERE appointments.id IN (?%s)`, idsHolder))
+	sql := rebind(fmt.Sprintf(`SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM `+appointmentScope+` WHERE appointments.id IN (?%s)`, idsHolder))
 	idsT := []interface{}{}
 	for _, id := range ids {
 		idsT = append(idsT, interface{}(id))
 	}
-	err := DB.Select(&_appointments, sql, idsT...)
+	err := dbSelect(ctx, DB, "Appointment", &_appointments, sql, idsT...)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotAppointments(_appointments)
 	return _appointments, nil
 }
 
 // FindAppointmentBy find a single appointment by a field name and a value.
 func FindAppointmentBy(field string, val interface{}) (*Appointment, error) {
+	return FindAppointmentByContext(context.Background(), field, val)
+}
+
+// FindAppointmentByContext is same as FindAppointmentBy but with the context ctx.
+func FindAppointmentByContext(ctx context.Context, field string, val interface{}) (*Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentBy", "appointments")
+	defer span.End()
 	_appointment := Appointment{}
-	sqlFmt := `SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments WHERE %s = ? LIMIT 1`
+	sqlFmt := `SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM ` + appointmentScope + ` WHERE %s = ? LIMIT 1`
 	sqlStr := fmt.Sprintf(sqlFmt, field)
-	err := DB.Get(&_appointment, DB.Rebind(sqlStr), val)
+	err := dbGet(ctx, DB, "Appointment", &_appointment, rebind(sqlStr), val)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_appointment.snapshot()
 	return &_appointment, nil
 }
 
 // FindAppointmentsBy find all appointments by a field name and a value.
 func FindAppointmentsBy(field string, val interface{}) (_appointments []Appointment, err error) {
-	sqlFmt := `SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments WHERE %s = ?`
+	return FindAppointmentsByContext(context.Background(), field, val)
+}
+
+// FindAppointmentsByContext is same as FindAppointmentsBy but with the context ctx.
+func FindAppointmentsByContext(ctx context.Context, field string, val interface{}) (_appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsBy", "appointments")
+	defer span.End()
+	sqlFmt := `SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM ` + appointmentScope + ` WHERE %s = ?`
 	sqlStr := fmt.Sprintf(sqlFmt, field)
-	err = DB.Select(&_appointments, DB.Rebind(sqlStr), val)
+	err = dbSelect(ctx, DB, "Appointment", &_appointments, rebind(sqlStr), val)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotAppointments(_appointments)
 	return _appointments, nil
 }
 
 // AllAppointments get all the Appointment records.
 func AllAppointments() (appointments []Appointment, err error) {
-	err = DB.Select(&appointments, "SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments")
+	return AllAppointmentsContext(context.Background())
+}
+
+// AllAppointmentsContext is same as AllAppointments but with the context ctx.
+func AllAppointmentsContext(ctx context.Context) (appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "AllAppointments", "appointments")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Appointment", &appointments, "SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM "+appointmentScope)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotAppointments(appointments)
 	return appointments, nil
 }
 
 // AppointmentCount get the count of all the Appointment records.
 func AppointmentCount() (c int64, err error) {
-	err = DB.Get(&c, "SELECT count(*) FROM appointments")
+	return AppointmentCountContext(context.Background())
+}
+
+// AppointmentCountContext is same as AppointmentCount but with the context ctx.
+func AppointmentCountContext(ctx context.Context) (c int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentCount", "appointments")
+	defer span.End()
+	err = dbGet(ctx, DB, "Appointment", &c, "SELECT count(*) FROM "+appointmentScope)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	return c, nil
@@ -337,18 +506,52 @@ func AppointmentCount() (c int64, err error) {
 
 // AppointmentCountWhere get the count of all the Appointment records with a where clause.
 func AppointmentCountWhere(where string, args ...interface{}) (c int64, err error) {
-	sql := "SELECT count(*) FROM appointments"
+	return AppointmentCountWhereContext(context.Background(), where, args...)
+}
+
+// AppointmentCountWhereContext is same as AppointmentCountWhere but with the context ctx.
+func AppointmentCountWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentCountWhere", "appointments")
+	defer span.End()
+	return appointmentCountWhere(ctx, appointmentScope, where, args...)
+}
+
+// AppointmentCountWithDeletedWhere get the count of the Appointment records with a where clause, soft deleted ones included.
+func AppointmentCountWithDeletedWhere(where string, args ...interface{}) (c int64, err error) {
+	return AppointmentCountWithDeletedWhereContext(context.Background(), where, args...)
+}
+
+// AppointmentCountWithDeletedWhereContext is same as AppointmentCountWithDeletedWhere but with the context ctx.
+func AppointmentCountWithDeletedWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentCountWithDeletedWhere", "appointments")
+	defer span.End()
+	return appointmentCountWhere(ctx, appointmentWithDeletedScope, where, args...)
+}
+
+// AppointmentCountOnlyDeletedWhere get the count of the soft deleted Appointment records with a where clause.
+func AppointmentCountOnlyDeletedWhere(where string, args ...interface{}) (c int64, err error) {
+	return AppointmentCountOnlyDeletedWhereContext(context.Background(), where, args...)
+}
+
+// AppointmentCountOnlyDeletedWhereContext is same as AppointmentCountOnlyDeletedWhere but with the context ctx.
+func AppointmentCountOnlyDeletedWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentCountOnlyDeletedWhere", "appointments")
+	defer span.End()
+	return appointmentCountWhere(ctx, appointmentOnlyDeletedScope, where, args...)
+}
+
+// appointmentCountWhere get the count of the Appointment records in a scope with a where clause.
+func appointmentCountWhere(ctx context.Context, scope, where string, args ...interface{}) (c int64, err error) {
+	sql := "SELECT count(*) FROM " + scope
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Appointment", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
-	err = stmt.Get(&c, args...)
+	err = stmt.Get(ctx, &c, args...)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	return c, nil
@@ -356,30 +559,117 @@ func AppointmentCountWhere(where string, args ...interface{}) (c int64, err erro
 
 // AppointmentIncludesWhere get the Appointment associated models records, currently it's not same as the corresponding "includes" function but "preload" instead in Ruby on Rails. It means that the "sql" should be restricted on Appointment model.
 func AppointmentIncludesWhere(assocs []string, sql string, args ...interface{}) (_appointments []Appointment, err error) {
-	_appointments, err = FindAppointmentsWhere(sql, args...)
+	return AppointmentIncludesWhereContext(context.Background(), assocs, sql, args...)
+}
+
+// AppointmentIncludesWhereContext is same as AppointmentIncludesWhere but with the context ctx.
+func AppointmentIncludesWhereContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "AppointmentIncludesWhere", "appointments")
+	defer span.End()
+	return appointmentIncludesWhere(ctx, false, assocs, sql, args...)
+}
+
+// AppointmentIncludesWherePartial is same as AppointmentIncludesWhere but it returns the records with the associations loaded so far together with the errors of the ones failed to be loaded, joined by errors.Join, rather than failing with the first of them. A record keeps an association empty if it's failed to be loaded.
+func AppointmentIncludesWherePartial(assocs []string, sql string, args ...interface{}) (_appointments []Appointment, err error) {
+	return AppointmentIncludesWherePartialContext(context.Background(), assocs, sql, args...)
+}
+
+// AppointmentIncludesWherePartialContext is same as AppointmentIncludesWherePartial but with the context ctx.
+func AppointmentIncludesWherePartialContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "AppointmentIncludesWherePartial", "appointments")
+	defer span.End()
+	return appointmentIncludesWhere(ctx, true, assocs, sql, args...)
+}
+
+// appointmentIncludesWhere get the Appointment associated models records, it fails with the first error of loading an association unless partial, see AppointmentIncludesWherePartial.
+func appointmentIncludesWhere(ctx context.Context, partial bool, assocs []string, sql string, args ...interface{}) (_appointments []Appointment, err error) {
+	_appointments, err = FindAppointmentsWhereContext(ctx, sql, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	if len(assocs) == 0 {
-		log.Println("No associated fields ard specified")
+		logger().Debug("No associated fields are specified", "model", "Appointment")
 		return _appointments, err
 	}
 	if len(_appointments) <= 0 {
-		return nil, errors.New("No results available")
+		return nil, fmt.Errorf("AppointmentIncludesWhere error: %w", ErrNotFound)
 	}
-	ids := make([]interface{}, len(_appointments))
-	for _, v := range _appointments {
-		ids = append(ids, interface{}(v.Id))
+	if err = includeAppointmentsAssocs(ctx, _appointments, assocs, partial); err != nil && !partial {
+		return nil, err
 	}
-	return _appointments, nil
+	return _appointments, err
+}
+
+// includeAppointmentsAssocs loads the belongs_to associations of the assocs, i.e. "physician" or "patient",
+// into the Appointment objects. It fails with the first error unless partial, see AppointmentIncludesWherePartial.
+func includeAppointmentsAssocs(ctx context.Context, _appointments []Appointment, assocs []string, partial bool) error {
+	errs := &includesErrors{model: "Appointment", partial: partial}
+	for _, assoc := range assocs {
+		switch assoc {
+		case "physician":
+			ids := []int64{}
+			for _, v := range _appointments {
+				if v.PhysicianId != nil {
+					ids = append(ids, *v.PhysicianId)
+				}
+			}
+			if len(ids) == 0 {
+				continue
+			}
+			_physicians, err := FindPhysiciansContext(ctx, ids...)
+			if err != nil {
+				if err = errs.add(assoc, err); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, vv := range _physicians {
+				for i, vvv := range _appointments {
+					if vvv.PhysicianId != nil && *vvv.PhysicianId == vv.Id {
+						_appointments[i].Physician = vv
+					}
+				}
+			}
+		case "patient":
+			ids := []int64{}
+			for _, v := range _appointments {
+				if v.PatientId != nil {
+					ids = append(ids, *v.PatientId)
+				}
+			}
+			if len(ids) == 0 {
+				continue
+			}
+			_patients, err := FindPatientsContext(ctx, ids...)
+			if err != nil {
+				if err = errs.add(assoc, err); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, vv := range _patients {
+				for i, vvv := range _appointments {
+					if vvv.PatientId != nil && *vvv.PatientId == vv.Id {
+						_appointments[i].Patient = vv
+					}
+				}
+			}
+		}
+	}
+	return errs.err()
 }
 
 // AppointmentIds get all the IDs of Appointment records.
 func AppointmentIds() (ids []int64, err error) {
-	err = DB.Select(&ids, "SELECT id FROM appointments")
+	return AppointmentIdsContext(context.Background())
+}
+
+// AppointmentIdsContext is same as AppointmentIds but with the context ctx.
+func AppointmentIdsContext(ctx context.Context) (ids []int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentIds", "appointments")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Appointment", &ids, "SELECT id FROM "+appointmentScope)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return ids, nil
@@ -387,24 +677,36 @@ func AppointmentIds() (ids []int64, err error) {
 
 // AppointmentIdsWhere get all the IDs of Appointment records by where restriction.
 func AppointmentIdsWhere(where string, args ...interface{}) ([]int64, error) {
-	ids, err := AppointmentIntCol("id", where, args...)
+	return AppointmentIdsWhereContext(context.Background(), where, args...)
+}
+
+// AppointmentIdsWhereContext is same as AppointmentIdsWhere but with the context ctx.
+func AppointmentIdsWhereContext(ctx context.Context, where string, args ...interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "AppointmentIdsWhere", "appointments")
+	defer span.End()
+	ids, err := AppointmentIntColContext(ctx, "id", where, args...)
 	return ids, err
 }
 
 // AppointmentIntCol get some int64 typed column of Appointment by where restriction.
 func AppointmentIntCol(col, where string, args ...interface{}) (intColRecs []int64, err error) {
-	sql := "SELECT " + col + " FROM appointments"
+	return AppointmentIntColContext(context.Background(), col, where, args...)
+}
+
+// AppointmentIntColContext is same as AppointmentIntCol but with the context ctx.
+func AppointmentIntColContext(ctx context.Context, col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentIntCol", "appointments")
+	defer span.End()
+	sql := "SELECT " + col + " FROM " + appointmentScope
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Appointment", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&intColRecs, args...)
+	err = stmt.Select(ctx, &intColRecs, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return intColRecs, nil
@@ -412,18 +714,23 @@ func AppointmentIntCol(col, where string, args ...interface{}) (intColRecs []int
 
 // AppointmentStrCol get some string typed column of Appointment by where restriction.
 func AppointmentStrCol(col, where string, args ...interface{}) (strColRecs []string, err error) {
-	sql := "SELECT " + col + " FROM appointments"
+	return AppointmentStrColContext(context.Background(), col, where, args...)
+}
+
+// AppointmentStrColContext is same as AppointmentStrCol but with the context ctx.
+func AppointmentStrColContext(ctx context.Context, col, where string, args ...interface{}) (strColRecs []string, err error) {
+	ctx, span := startSpan(ctx, "AppointmentStrCol", "appointments")
+	defer span.End()
+	sql := "SELECT " + col + " FROM " + appointmentScope
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Appointment", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&strColRecs, args...)
+	err = stmt.Select(ctx, &strColRecs, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return strColRecs, nil
@@ -433,63 +740,190 @@ func AppointmentStrCol(col, where string, args ...interface{}) (strColRecs []str
 // with placeholders, eg: FindUsersWhere("first_name = ? AND age > ?", "John", 18)
 // will return those records in the table "users" whose first_name is "John" and age elder than 18.
 func FindAppointmentsWhere(where string, args ...interface{}) (appointments []Appointment, err error) {
-	sql := "SELECT COALESCE(appointments.appointment_date, CONVERT_TZ('0001-01-01 00:00:00','+00:00','UTC')) AS appointment_date, COALESCE(appointments.physician_id, 0) AS physician_id, COALESCE(appointments.patient_id, 0) AS patient_id, appointments.id, appointments.created_at, appointments.updated_at FROM appointments"
+	return FindAppointmentsWhereContext(context.Background(), where, args...)
+}
+
+// FindAppointmentsWhereContext is same as FindAppointmentsWhere but with the context ctx.
+func FindAppointmentsWhereContext(ctx context.Context, where string, args ...interface{}) (appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsWhere", "appointments")
+	defer span.End()
+	return findAppointmentsWhere(ctx, appointmentScope, where, args...)
+}
+
+// FindAppointmentsWithDeletedWhere is same as FindAppointmentsWhere but the soft deleted records are included.
+func FindAppointmentsWithDeletedWhere(where string, args ...interface{}) (appointments []Appointment, err error) {
+	return FindAppointmentsWithDeletedWhereContext(context.Background(), where, args...)
+}
+
+// FindAppointmentsWithDeletedWhereContext is same as FindAppointmentsWithDeletedWhere but with the context ctx.
+func FindAppointmentsWithDeletedWhereContext(ctx context.Context, where string, args ...interface{}) (appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsWithDeletedWhere", "appointments")
+	defer span.End()
+	return findAppointmentsWhere(ctx, appointmentWithDeletedScope, where, args...)
+}
+
+// FindAppointmentsOnlyDeletedWhere is same as FindAppointmentsWhere but only the soft deleted records are queried.
+func FindAppointmentsOnlyDeletedWhere(where string, args ...interface{}) (appointments []Appointment, err error) {
+	return FindAppointmentsOnlyDeletedWhereContext(context.Background(), where, args...)
+}
+
+// FindAppointmentsOnlyDeletedWhereContext is same as FindAppointmentsOnlyDeletedWhere but with the context ctx.
+func FindAppointmentsOnlyDeletedWhereContext(ctx context.Context, where string, args ...interface{}) (appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsOnlyDeletedWhere", "appointments")
+	defer span.End()
+	return findAppointmentsWhere(ctx, appointmentOnlyDeletedScope, where, args...)
+}
+
+// findAppointmentsWhere query the Appointment records in a scope with a partial SQL clause.
+func findAppointmentsWhere(ctx context.Context, scope, where string, args ...interface{}) (appointments []Appointment, err error) {
+	sql := "SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM " + scope
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Appointment", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&appointments, args...)
+	err = stmt.Select(ctx, &appointments, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotAppointments(appointments)
 	return appointments, nil
 }
 
 // FindAppointmentBySql query use a complete SQL clause
 // with placeholders, eg: FindUserBySql("SELECT * FROM users WHERE first_name = ? AND age > ? ORDER BY DESC LIMIT 1", "John", 18)
 // will return only One record in the table "users" whose first_name is "John" and age elder than 18.
+// The soft deleted records are not filtered out of a complete SQL clause.
 func FindAppointmentBySql(sql string, args ...interface{}) (*Appointment, error) {
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	return FindAppointmentBySqlContext(context.Background(), sql, args...)
+}
+
+// FindAppointmentBySqlContext is same as FindAppointmentBySql but with the context ctx.
+func FindAppointmentBySqlContext(ctx context.Context, sql string, args ...interface{}) (*Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentBySql", "appointments")
+	defer span.End()
+	stmt, err := prepare(ctx, "Appointment", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	_appointment := &Appointment{}
-	err = stmt.Get(_appointment, args...)
+	err = stmt.Get(ctx, _appointment, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	_appointment.snapshot()
 	return _appointment, nil
 }
 
 // FindAppointmentsBySql query use a complete SQL clause
 // with placeholders, eg: FindUsersBySql("SELECT * FROM users WHERE first_name = ? AND age > ?", "John", 18)
 // will return those records in the table "users" whose first_name is "John" and age elder than 18.
+// The soft deleted records are not filtered out of a complete SQL clause.
 func FindAppointmentsBySql(sql string, args ...interface{}) (appointments []Appointment, err error) {
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	return FindAppointmentsBySqlContext(context.Background(), sql, args...)
+}
+
+// FindAppointmentsBySqlContext is same as FindAppointmentsBySql but with the context ctx.
+func FindAppointmentsBySqlContext(ctx context.Context, sql string, args ...interface{}) (appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsBySql", "appointments")
+	defer span.End()
+	stmt, err := prepare(ctx, "Appointment", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&appointments, args...)
+	err = stmt.Select(ctx, &appointments, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotAppointments(appointments)
+	return appointments, nil
+}
+
+// FindAppointmentForUpdate find a single appointment by an ID in the transaction tx and lock its row
+// FOR UPDATE until the transaction ends.
+func FindAppointmentForUpdate(tx *sqlx.Tx, id int64) (*Appointment, error) {
+	return FindAppointmentForUpdateContext(context.Background(), tx, id)
+}
+
+// FindAppointmentForUpdateContext is same as FindAppointmentForUpdate but with the context ctx.
+func FindAppointmentForUpdateContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentForUpdate", "appointments")
+	defer span.End()
+	return findAppointmentLock(ctx, tx, ForUpdate, id)
+}
+
+// FindAppointmentForShare find a single appointment by an ID in the transaction tx and lock its row
+// FOR SHARE until the transaction ends.
+func FindAppointmentForShare(tx *sqlx.Tx, id int64) (*Appointment, error) {
+	return FindAppointmentForShareContext(context.Background(), tx, id)
+}
+
+// FindAppointmentForShareContext is same as FindAppointmentForShare but with the context ctx.
+func FindAppointmentForShareContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentForShare", "appointments")
+	defer span.End()
+	return findAppointmentLock(ctx, tx, ForShare, id)
+}
+
+// findAppointmentLock find a single appointment by an ID in the transaction tx with a row lock mode.
+func findAppointmentLock(ctx context.Context, tx *sqlx.Tx, mode LockMode, id int64) (*Appointment, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("Lock Appointment error: %w", ErrInvalidID)
+	}
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	_appointment := Appointment{}
+	err = dbGet(ctx, tx, "Appointment", &_appointment, tx.Rebind(`SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM appointments WHERE appointments.id = ? AND appointments.deleted_at IS NULL LIMIT 1 `+lock), id)
+	if err != nil {
+		return nil, err
+	}
+	_appointment.snapshot()
+	return &_appointment, nil
+}
+
+// FindAppointmentsWhereLock is same as FindAppointmentsWhere but runs in the transaction tx and lock
+// the selected rows with the lock mode, e.g. ForUpdateSkipLocked, until the transaction ends.
+// The where clause should be a condition only, i.e. with no ORDER BY or LIMIT.
+func FindAppointmentsWhereLock(tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (appointments []Appointment, err error) {
+	return FindAppointmentsWhereLockContext(context.Background(), tx, mode, where, args...)
+}
+
+// FindAppointmentsWhereLockContext is same as FindAppointmentsWhereLock but with the context ctx.
+func FindAppointmentsWhereLockContext(ctx context.Context, tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (appointments []Appointment, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsWhereLock", "appointments")
+	defer span.End()
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	sql := "SELECT appointments.appointment_date, appointments.physician_id, appointments.patient_id, appointments.series_id, appointments.status, appointments.cancellation_reason, appointments.confirmed_at, appointments.checked_in_at, appointments.completed_at, appointments.cancelled_at, appointments.no_show_at, appointments.ical_uid, appointments.id, appointments.created_at, appointments.updated_at, appointments.deleted_at, appointments.lock_version FROM appointments WHERE appointments.deleted_at IS NULL"
+	if len(where) > 0 {
+		sql = sql + " AND (" + where + ")"
+	}
+	err = dbSelect(ctx, tx, "Appointment", &appointments, tx.Rebind(sql+" "+lock), args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointments(appointments)
 	return appointments, nil
 }
 
 // CreateAppointment use a named params to create a single Appointment record.
 // A named params is key-value map like map[string]interface{}{"first_name": "John", "age": 23} .
 func CreateAppointment(am map[string]interface{}) (int64, error) {
+	return CreateAppointmentContext(context.Background(), am)
+}
+
+// CreateAppointmentContext is same as CreateAppointment but with the context ctx.
+func CreateAppointmentContext(ctx context.Context, am map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointment", "appointments")
+	defer span.End()
 	if len(am) == 0 {
-		return 0, fmt.Errorf("Zero key in the attributes map!")
+		return 0, fmt.Errorf("CreateAppointment error: %w", ErrEmptyAttributes)
 	}
 	t := time.Now()
 	for _, v := range []string{"created_at", "updated_at"} {
@@ -500,95 +934,344 @@ func CreateAppointment(am map[string]interface{}) (int64, error) {
 	keys := allKeys(am)
 	sqlFmt := `INSERT INTO appointments (%s) VALUES (%s)`
 	sql := fmt.Sprintf(sqlFmt, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
-	result, err := DB.NamedExec(sql, am)
+	result, err := dbNamedExec(ctx, DB, "Appointment", sql, am)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		log.Println(err)
+		logger().Error("Get the last insert id error", "model", "Appointment", "error", err)
 		return 0, err
 	}
 	return lastId, nil
 }
 
-// Create is a method for Appointment to create a record.
+// CreateAppointments creates the Appointment records of the slice appointments with multi-row INSERT statements,
+// see BulkInsertChunkSize. The structs are validated and time stamped, and their Id fields
+// are set to the ids of the created records, which are returned too. No callbacks are run.
+func CreateAppointments(appointments []Appointment) ([]int64, error) {
+	return CreateAppointmentsContext(context.Background(), appointments)
+}
+
+// CreateAppointmentsContext is same as CreateAppointments but with the context ctx.
+func CreateAppointmentsContext(ctx context.Context, appointments []Appointment) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointments", "appointments")
+	defer span.End()
+	t := time.Now()
+	rows := make([][]interface{}, len(appointments))
+	for i := range appointments {
+		_appointment := &appointments[i]
+		if _appointment.Status == "" {
+			_appointment.Status = AppointmentScheduled
+		}
+		ok, err := govalidator.ValidateStruct(_appointment)
+		if !ok {
+			err = &ValidationError{Model: "Appointment", Index: i, Err: err}
+			logger().Warn(err.Error(), "model", "Appointment")
+			return nil, err
+		}
+		_appointment.CreatedAt = t
+		_appointment.UpdatedAt = t
+		rows[i] = []interface{}{_appointment.AppointmentDate, _appointment.PhysicianId, _appointment.PatientId, _appointment.SeriesId, _appointment.Status, _appointment.CancellationReason, _appointment.ConfirmedAt, _appointment.CheckedInAt, _appointment.CompletedAt, _appointment.CancelledAt, _appointment.NoShowAt, _appointment.IcalUid, _appointment.CreatedAt, _appointment.UpdatedAt, _appointment.LockVersion}
+	}
+	ids, err := bulkInsert(ctx, "Appointment", "appointments", []string{"appointment_date", "physician_id", "patient_id", "series_id", "status", "cancellation_reason", "confirmed_at", "checked_in_at", "completed_at", "cancelled_at", "no_show_at", "ical_uid", "created_at", "updated_at", "lock_version"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		appointments[i].Id = id
+		appointments[i].snapshot()
+	}
+	return ids, nil
+}
+
+// CreateAppointmentsMaps use a slice of named params to create Appointment records like CreateAppointment does,
+// but with multi-row INSERT statements, see BulkInsertChunkSize. A column missing in some
+// of the maps gets its default value. The ids of the created records are returned in order.
+func CreateAppointmentsMaps(ams []map[string]interface{}) ([]int64, error) {
+	return CreateAppointmentsMapsContext(context.Background(), ams)
+}
+
+// CreateAppointmentsMapsContext is same as CreateAppointmentsMaps but with the context ctx.
+func CreateAppointmentsMapsContext(ctx context.Context, ams []map[string]interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointmentsMaps", "appointments")
+	defer span.End()
+	for i, am := range ams {
+		if len(am) == 0 {
+			return nil, fmt.Errorf("CreateAppointmentsMaps error: %w at index %d", ErrEmptyAttributes, i)
+		}
+	}
+	keys, rows := bulkMapRows(ams)
+	return bulkInsert(ctx, "Appointment", "appointments", keys, rows)
+}
+
+// Create is a method for Appointment to create a record, the Id of the object is set to the new id.
+// It runs the BeforeValidate, BeforeCreate, AfterCreate and AfterCommit callbacks if implemented.
 func (_appointment *Appointment) Create() (int64, error) {
+	return _appointment.CreateContext(context.Background())
+}
+
+// CreateContext is same as Create but with the context ctx.
+func (_appointment *Appointment) CreateContext(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "Appointment.Create", "appointments")
+	defer span.End()
+	if _appointment.Status == "" {
+		_appointment.Status = AppointmentScheduled
+	}
+	if err := runBeforeValidate(_appointment); err != nil {
+		return 0, err
+	}
 	ok, err := govalidator.ValidateStruct(_appointment)
 	if !ok {
-		errMsg := "Validate Appointment struct error: Unknown error"
-		if err != nil {
-			errMsg = "Validate Appointment struct error: " + err.Error()
-		}
-		log.Println(errMsg)
-		return 0, errors.New(errMsg)
+		err = &ValidationError{Model: "Appointment", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Appointment")
+		return 0, err
+	}
+	if err = runBeforeCreate(_appointment); err != nil {
+		return 0, err
 	}
 	t := time.Now()
 	_appointment.CreatedAt = t
 	_appointment.UpdatedAt = t
-	sql := `INSERT INTO appointments (appointment_date,physician_id,patient_id,created_at,updated_at) VALUES (:appointment_date,:physician_id,:patient_id,:created_at,:updated_at)`
-	result, err := DB.NamedExec(sql, _appointment)
+	sql := `INSERT INTO appointments (appointment_date,physician_id,patient_id,series_id,status,cancellation_reason,confirmed_at,checked_in_at,completed_at,cancelled_at,no_show_at,ical_uid,created_at,updated_at,lock_version) VALUES (:appointment_date,:physician_id,:patient_id,:series_id,:status,:cancellation_reason,:confirmed_at,:checked_in_at,:completed_at,:cancelled_at,:no_show_at,:ical_uid,:created_at,:updated_at,:lock_version)`
+	result, err := dbNamedExec(ctx, DB, "Appointment", sql, _appointment)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		log.Println(err)
+		logger().Error("Get the last insert id error", "model", "Appointment", "error", err)
 		return 0, err
 	}
+	_appointment.Id = lastId
+	_appointment.savedChanges = diffAttributes(nil, _appointment.attributes())
+	_appointment.snapshot()
+	if err = runAfterCreate(ctx, _appointment); err != nil {
+		return lastId, err
+	}
 	return lastId, nil
 }
 
 // CreatePhysician is a method for a Appointment object to create an associated Physician record.
 func (_appointment *Appointment) CreatePhysician(am map[string]interface{}) error {
+	return _appointment.CreatePhysicianContext(context.Background(), am)
+}
+
+// CreatePhysicianContext is same as CreatePhysician but with the context ctx.
+func (_appointment *Appointment) CreatePhysicianContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Appointment.CreatePhysician", "appointments")
+	defer span.End()
 	am["appointment_id"] = _appointment.Id
-	_, err := CreatePhysician(am)
+	_, err := CreatePhysicianContext(ctx, am)
 	return err
 }
 
 // CreatePatient is a method for a Appointment object to create an associated Patient record.
 func (_appointment *Appointment) CreatePatient(am map[string]interface{}) error {
+	return _appointment.CreatePatientContext(context.Background(), am)
+}
+
+// CreatePatientContext is same as CreatePatient but with the context ctx.
+func (_appointment *Appointment) CreatePatientContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Appointment.CreatePatient", "appointments")
+	defer span.End()
 	am["appointment_id"] = _appointment.Id
-	_, err := CreatePatient(am)
+	_, err := CreatePatientContext(ctx, am)
 	return err
 }
 
+// Reload is a method for Appointment to reload the attributes of the object from the database,
+// soft deleted or not. The associations loaded into the object are reset.
+func (_appointment *Appointment) Reload() error {
+	return _appointment.ReloadContext(context.Background())
+}
+
+// ReloadContext is same as Reload but with the context ctx.
+func (_appointment *Appointment) ReloadContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.Reload", "appointments")
+	defer span.End()
+	if _appointment.Id == 0 {
+		return fmt.Errorf("Appointment.Reload error: %w", ErrInvalidID)
+	}
+	appointments, err := FindAppointmentsWithDeletedWhereContext(ctx, "id = ?", _appointment.Id)
+	if err != nil {
+		return err
+	}
+	if len(appointments) == 0 {
+		return notFound("Appointment", sql.ErrNoRows)
+	}
+	*_appointment = appointments[0]
+	return nil
+}
+
+// reloadColumns reloads the columns of the Appointment object like Reload, but keeps its loaded associations
+// which still belong to it.
+func (_appointment *Appointment) reloadColumns(ctx context.Context) error {
+	physician, patient := _appointment.Physician, _appointment.Patient
+	if err := _appointment.ReloadContext(ctx); err != nil {
+		return err
+	}
+	if _appointment.PhysicianId != nil && *_appointment.PhysicianId == physician.Id {
+		_appointment.Physician = physician
+	}
+	if _appointment.PatientId != nil && *_appointment.PatientId == patient.Id {
+		_appointment.Patient = patient
+	}
+	return nil
+}
+
 // Destroy is method used for a Appointment object to be destroyed.
+// The record is soft deleted, see HardDestroy for removing it physically. ErrNotFound is returned
+// without running the AfterDestroy callbacks if it doesn't exist or it's soft deleted already.
+// The freed slot is offered to the waitlisted patients, see OfferSlot.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
 func (_appointment *Appointment) Destroy() error {
+	return _appointment.DestroyContext(context.Background())
+}
+
+// DestroyContext is same as Destroy but with the context ctx.
+func (_appointment *Appointment) DestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.Destroy", "appointments")
+	defer span.End()
 	if _appointment.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Appointment.Destroy error: %w", ErrInvalidID)
 	}
-	err := DestroyAppointment(_appointment.Id)
-	return err
+	if err := runBeforeDestroy(_appointment); err != nil {
+		return err
+	}
+	t := time.Now()
+	cnt, err := softDestroyAppointments(ctx, t, "id = ?", _appointment.Id)
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return fmt.Errorf("Appointment.Destroy error: %w", ErrNotFound)
+	}
+	_appointment.DeletedAt = &t
+	return runAfterDestroy(ctx, _appointment)
+}
+
+// HardDestroy is method used for a Appointment object to be deleted from the database.
+// ErrNotFound is returned without running the AfterDestroy callbacks if the record doesn't exist.
+// The freed slot is offered to the waitlisted patients, see OfferSlot.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
+func (_appointment *Appointment) HardDestroy() error {
+	return _appointment.HardDestroyContext(context.Background())
+}
+
+// HardDestroyContext is same as HardDestroy but with the context ctx.
+func (_appointment *Appointment) HardDestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.HardDestroy", "appointments")
+	defer span.End()
+	if _appointment.Id == 0 {
+		return fmt.Errorf("Appointment.HardDestroy error: %w", ErrInvalidID)
+	}
+	if err := runBeforeDestroy(_appointment); err != nil {
+		return err
+	}
+	err := HardDestroyAppointmentContext(ctx, _appointment.Id)
+	if err != nil {
+		return err
+	}
+	return runAfterDestroy(ctx, _appointment)
+}
+
+// Restore is method used for a soft deleted Appointment object to be restored.
+// ErrNotFound is returned if there's no soft deleted record of its id.
+func (_appointment *Appointment) Restore() error {
+	return _appointment.RestoreContext(context.Background())
+}
+
+// RestoreContext is same as Restore but with the context ctx.
+func (_appointment *Appointment) RestoreContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.Restore", "appointments")
+	defer span.End()
+	if _appointment.Id == 0 {
+		return fmt.Errorf("Appointment.Restore error: %w", ErrInvalidID)
+	}
+	err := RestoreAppointmentContext(ctx, _appointment.Id)
+	if err != nil {
+		return err
+	}
+	_appointment.DeletedAt = nil
+	return nil
 }
 
-// DestroyAppointment will destroy a Appointment record specified by the id parameter.
+// DestroyAppointment will soft delete a Appointment record specified by the id parameter.
+// ErrNotFound is returned if the record doesn't exist or it's soft deleted already.
+// The freed slot is offered to the waitlisted patients, see OfferSlot.
 func DestroyAppointment(id int64) error {
-	stmt, err := DB.Preparex(DB.Rebind(`DELETE FROM appointments WHERE id = ?`))
-	_, err = stmt.Exec(id)
+	return DestroyAppointmentContext(context.Background(), id)
+}
+
+// DestroyAppointmentContext is same as DestroyAppointment but with the context ctx.
+func DestroyAppointmentContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DestroyAppointment", "appointments")
+	defer span.End()
+	cnt, err := softDestroyAppointments(ctx, time.Now(), "id = ?", id)
 	if err != nil {
 		return err
 	}
+	if cnt == 0 {
+		return fmt.Errorf("DestroyAppointment error: %w", ErrNotFound)
+	}
 	return nil
 }
 
-// DestroyAppointments will destroy Appointment records those specified by the ids parameters.
+// DestroyAppointments will soft delete Appointment records those specified by the ids parameters.
+// The freed slots are offered to the waitlisted patients, see OfferSlot.
 func DestroyAppointments(ids ...int64) (int64, error) {
+	return DestroyAppointmentsContext(context.Background(), ids...)
+}
+
+// DestroyAppointmentsContext is same as DestroyAppointments but with the context ctx.
+func DestroyAppointmentsContext(ctx context.Context, ids ...int64) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyAppointments", "appointments")
+	defer span.End()
 	if len(ids) == 0 {
-		msg := "At least one or more ids needed"
-		log.Println(msg)
-		return 0, errors.New(msg)
+		err := fmt.Errorf("DestroyAppointments error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Appointment")
+		return 0, err
 	}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
-	sql := fmt.Sprintf(`DELETE FROM appointments WHERE id IN (?%s)`, idsHolder)
 	idsT := []interface{}{}
 	for _, id := range ids {
 		idsT = append(idsT, interface{}(id))
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(idsT...)
+	return softDestroyAppointments(ctx, time.Now(), fmt.Sprintf("id IN (?%s)", idsHolder), idsT...)
+}
+
+// DestroyAppointmentsWhere soft delete records by a where clause restriction.
+// e.g. DestroyAppointmentsWhere("name = ?", "John")
+// And this func will not call the association dependent action
+// The freed slots are offered to the waitlisted patients, see OfferSlot.
+func DestroyAppointmentsWhere(where string, args ...interface{}) (int64, error) {
+	return DestroyAppointmentsWhereContext(context.Background(), where, args...)
+}
+
+// DestroyAppointmentsWhereContext is same as DestroyAppointmentsWhere but with the context ctx.
+func DestroyAppointmentsWhereContext(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyAppointmentsWhere", "appointments")
+	defer span.End()
+	if len(where) == 0 {
+		return 0, fmt.Errorf("DestroyAppointmentsWhere error: %w", ErrNoWhere)
+	}
+	return softDestroyAppointments(ctx, time.Now(), where, args...)
+}
+
+// softDestroyAppointments set the deleted_at column to t for the not yet deleted Appointment records
+// matching the where clause, and offers their freed slots to the waitlisted patients.
+func softDestroyAppointments(ctx context.Context, t time.Time, where string, args ...interface{}) (int64, error) {
+	freed, err := FindAppointmentsWhereContext(ctx, where, args...)
+	if err != nil {
+		return 0, err
+	}
+	sql := "UPDATE appointments SET deleted_at = ? WHERE deleted_at IS NULL AND (" + where + ")"
+	stmt, err := prepare(ctx, "Appointment", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, append([]interface{}{t}, args...)...)
 	if err != nil {
 		return 0, err
 	}
@@ -596,111 +1279,424 @@ func DestroyAppointments(ids ...int64) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	if cnt > 0 {
+		offerFreedSlots(ctx, freed)
+	}
 	return cnt, nil
 }
 
-// DestroyAppointmentsWhere delete records by a where clause restriction.
-// e.g. DestroyAppointmentsWhere("name = ?", "John")
-// And this func will not call the association dependent action
-func DestroyAppointmentsWhere(where string, args ...interface{}) (int64, error) {
-	sql := `DELETE FROM appointments WHERE `
-	if len(where) > 0 {
-		sql = sql + where
-	} else {
-		return 0, errors.New("No WHERE conditions provided")
+// RestoreAppointment will restore a soft deleted Appointment record specified by the id parameter.
+// ErrNotFound is returned if there's no soft deleted record of the id.
+func RestoreAppointment(id int64) error {
+	return RestoreAppointmentContext(context.Background(), id)
+}
+
+// RestoreAppointmentContext is same as RestoreAppointment but with the context ctx.
+func RestoreAppointmentContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "RestoreAppointment", "appointments")
+	defer span.End()
+	stmt, err := prepare(ctx, "Appointment", rebind(`UPDATE appointments SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`))
+	if err != nil {
+		return err
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(args...)
+	result, err := stmt.Exec(ctx, id)
 	if err != nil {
-		return 0, err
+		return err
 	}
 	cnt, err := result.RowsAffected()
 	if err != nil {
-		return 0, err
+		return err
 	}
-	return cnt, nil
+	if cnt == 0 {
+		return fmt.Errorf("RestoreAppointment error: %w", ErrNotFound)
+	}
+	return nil
+}
+
+// HardDestroyAppointment will delete a Appointment record specified by the id parameter from the database,
+// no matter it's soft deleted or not. The freed slot is offered to the waitlisted patients, see OfferSlot.
+// ErrNotFound is returned if the record doesn't exist.
+func HardDestroyAppointment(id int64) error {
+	return HardDestroyAppointmentContext(context.Background(), id)
+}
+
+// HardDestroyAppointmentContext is same as HardDestroyAppointment but with the context ctx.
+func HardDestroyAppointmentContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "HardDestroyAppointment", "appointments")
+	defer span.End()
+	// a soft deleted appointment has freed its slot already
+	freed, err := FindAppointmentsWhereContext(ctx, "id = ?", id)
+	if err != nil {
+		return err
+	}
+	stmt, err := prepare(ctx, "Appointment", rebind(`DELETE FROM appointments WHERE id = ?`))
+	if err != nil {
+		return err
+	}
+	result, err := stmt.Exec(ctx, id)
+	if err != nil {
+		return err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return fmt.Errorf("HardDestroyAppointment error: %w", ErrNotFound)
+	}
+	offerFreedSlots(ctx, freed)
+	return nil
 }
 
 // Save method is used for a Appointment object to update an existed record mainly.
-// If no id provided a new record will be created. FIXME: A UPSERT action will be implemented further.
+// If no id provided a new record will be created, and if the record of the id doesn't exist
+// it will be created with the id.
+// The record is optimistic locked by its lock_version column, ErrStaleObject is returned
+// if it has been changed by someone else since it was loaded.
+// A loaded object only updates its changed columns, see ChangedFields. The status can only be changed
+// from the stored one by an allowed transition, see CanTransition, and the time of the change is stamped,
+// e.g. on ConfirmedAt, unless it's stamped already. If it's changed to cancelled, the freed slot is
+// offered to the waitlisted patients, see OfferSlot.
+// The object is reloaded from the database after it's saved, its loaded associations are kept.
+// It runs the BeforeValidate, BeforeUpdate, AfterUpdate and AfterCommit callbacks if implemented.
 func (_appointment *Appointment) Save() error {
+	return _appointment.SaveContext(context.Background())
+}
+
+// SaveContext is same as Save but with the context ctx.
+func (_appointment *Appointment) SaveContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.Save", "appointments")
+	defer span.End()
+	if _appointment.Id == 0 {
+		_, err := _appointment.CreateContext(ctx)
+		return err
+	}
+	if _appointment.Status == "" {
+		_appointment.Status = AppointmentScheduled
+	}
+	if err := runBeforeValidate(_appointment); err != nil {
+		return err
+	}
 	ok, err := govalidator.ValidateStruct(_appointment)
 	if !ok {
-		errMsg := "Validate Appointment struct error: Unknown error"
+		err = &ValidationError{Model: "Appointment", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Appointment")
+		return err
+	}
+	if err = _appointment.checkStatusChange(ctx); err != nil {
+		return err
+	}
+	if err = runBeforeUpdate(_appointment); err != nil {
+		return err
+	}
+	old := _appointment.original
+	fields := _appointment.ChangedFields()
+	if old != nil && len(fields) == 0 {
+		// a loaded object without changes has nothing to write
+		_appointment.savedChanges = nil
+		return runAfterUpdate(ctx, _appointment)
+	}
+	_appointment.UpdatedAt = time.Now()
+	sqlFmt := `UPDATE appointments SET %s WHERE id = %v AND lock_version = :lock_version`
+	sqlStr := fmt.Sprintf(sqlFmt, namedSets(append(fields, "updated_at"))+", lock_version = lock_version + 1", _appointment.Id)
+	result, err := dbNamedExec(ctx, DB, "Appointment", sqlStr, _appointment)
+	if err != nil {
+		return err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		// the record is created with the id if it doesn't exist, or it's changed by someone else
+		if _appointment.CreatedAt.IsZero() {
+			_appointment.CreatedAt = _appointment.UpdatedAt
+		}
+		created, err := upsertWithId(ctx, "Appointment", "appointments", _appointment.Id, _appointment, []string{"id", "appointment_date", "physician_id", "patient_id", "series_id", "status", "cancellation_reason", "confirmed_at", "checked_in_at", "completed_at", "cancelled_at", "no_show_at", "ical_uid", "created_at", "updated_at", "lock_version"}, nil)
 		if err != nil {
-			errMsg = "Validate Appointment struct error: " + err.Error()
+			return err
+		}
+		if !created {
+			return ErrStaleObject
 		}
-		log.Println(errMsg)
-		return errors.New(errMsg)
+		if err = _appointment.reloadColumns(ctx); err != nil {
+			return err
+		}
+		_appointment.savedChanges = diffAttributes(old, _appointment.attributes())
+		return runAfterUpdate(ctx, _appointment)
 	}
-	if _appointment.Id == 0 {
-		_, err = _appointment.Create()
+	if err = _appointment.reloadColumns(ctx); err != nil {
 		return err
 	}
-	_appointment.UpdatedAt = time.Now()
-	sqlFmt := `UPDATE appointments SET %s WHERE id = %v`
-	sqlStr := fmt.Sprintf(sqlFmt, "appointment_date = :appointment_date, physician_id = :physician_id, patient_id = :patient_id, updated_at = :updated_at", _appointment.Id)
-	_, err = DB.NamedExec(sqlStr, _appointment)
-	return err
+	_appointment.savedChanges = diffAttributes(old, _appointment.attributes())
+	if from, _ := old["status"].(string); from != AppointmentCancelled && _appointment.Status == AppointmentCancelled {
+		offerFreedSlot(ctx, _appointment)
+	}
+	return runAfterUpdate(ctx, _appointment)
+}
+
+// UpsertAppointment use a named params to create a single Appointment record, or to update the updateColumns
+// of the existed record instead if it conflicts with the params on the conflictColumns.
+// The id of the created or updated record is returned. With MySQL the conflict is detected
+// on any unique key of the table, the conflictColumns is only used by PostgreSQL and SQLite, so the
+// record conflicting on another unique key than the conflictColumns is updated and its id is returned.
+func UpsertAppointment(am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	return UpsertAppointmentContext(context.Background(), am, conflictColumns, updateColumns)
+}
+
+// UpsertAppointmentContext is same as UpsertAppointment but with the context ctx.
+func UpsertAppointmentContext(ctx context.Context, am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	ctx, span := startSpan(ctx, "UpsertAppointment", "appointments")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("UpsertAppointment error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	return upsert(ctx, "Appointment", "appointments", am, allKeys(am), conflictColumns, updateColumns)
+}
+
+// Upsert is a method for Appointment to create a record, or to update the updateColumns of the existed
+// record instead if it conflicts with the object on the conflictColumns, see UpsertAppointment.
+// The Id of the object is set to the id of the created or updated record.
+// It runs the BeforeValidate callback if implemented.
+func (_appointment *Appointment) Upsert(conflictColumns, updateColumns []string) error {
+	return _appointment.UpsertContext(context.Background(), conflictColumns, updateColumns)
+}
+
+// UpsertContext is same as Upsert but with the context ctx.
+func (_appointment *Appointment) UpsertContext(ctx context.Context, conflictColumns, updateColumns []string) error {
+	ctx, span := startSpan(ctx, "Appointment.Upsert", "appointments")
+	defer span.End()
+	if _appointment.Status == "" {
+		_appointment.Status = AppointmentScheduled
+	}
+	if err := runBeforeValidate(_appointment); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_appointment)
+	if !ok {
+		err = &ValidationError{Model: "Appointment", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Appointment")
+		return err
+	}
+	t := time.Now()
+	if _appointment.CreatedAt.IsZero() {
+		_appointment.CreatedAt = t
+	}
+	_appointment.UpdatedAt = t
+	keys := []string{"appointment_date", "physician_id", "patient_id", "series_id", "status", "cancellation_reason", "confirmed_at", "checked_in_at", "completed_at", "cancelled_at", "no_show_at", "ical_uid", "created_at", "updated_at", "lock_version"}
+	if _appointment.Id != 0 {
+		keys = append([]string{"id"}, keys...)
+	}
+	id, err := upsert(ctx, "Appointment", "appointments", _appointment, keys, conflictColumns, updateColumns)
+	if err != nil {
+		return err
+	}
+	_appointment.Id = id
+	_appointment.snapshot()
+	return nil
 }
 
 // UpdateAppointment is used to update a record with a id and map[string]interface{} typed key-value parameters.
+// If a "lock_version" key is given, the record is only updated when its lock_version still
+// matches the value, and the lock_version is increased, otherwise ErrStaleObject is returned.
+// A "status" key can only change the status by an allowed transition, see CanTransition, and the
+// time of the change is stamped, e.g. on confirmed_at, unless it's given or stamped already. The record is
+// only updated if its status is still the checked one, otherwise ErrStaleObject is returned.
+// If the status is changed to cancelled, the freed slot is offered to the waitlisted patients, see OfferSlot.
 func UpdateAppointment(id int64, am map[string]interface{}) error {
+	return UpdateAppointmentContext(context.Background(), id, am)
+}
+
+// UpdateAppointmentContext is same as UpdateAppointment but with the context ctx.
+func UpdateAppointmentContext(ctx context.Context, id int64, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "UpdateAppointment", "appointments")
+	defer span.End()
+	if len(am) == 0 {
+		return fmt.Errorf("UpdateAppointment error: %w", ErrEmptyAttributes)
+	}
+	var stored *Appointment
+	if _, ok := am["status"]; ok {
+		appointments, err := FindAppointmentsWhereContext(ctx, "id = ?", id)
+		if err != nil {
+			return err
+		}
+		if len(appointments) > 0 {
+			stored = &appointments[0]
+		}
+	}
+	t := time.Now()
+	from := ""
+	if to, _ := am["status"].(string); stored != nil && to != stored.Status {
+		if !CanTransition(stored.Status, to) {
+			return fmt.Errorf("%w of appointment %d: %s to %s", ErrInvalidTransition, id, stored.Status, to)
+		}
+		if col, stamp := stored.statusStamp(to); stamp != nil && *stamp == nil && am[col] == nil {
+			am[col] = t
+		}
+		from = stored.Status
+	}
+	am["updated_at"] = t
+	if err := updateAppointmentColumns(ctx, id, am, from); err != nil {
+		return err
+	}
+	if stored != nil && stored.Status != AppointmentCancelled && am["status"] == AppointmentCancelled {
+		if err := stored.ReloadContext(ctx); err != nil {
+			return err
+		}
+		offerFreedSlot(ctx, stored)
+	}
+	return nil
+}
+
+// updateAppointmentColumns is used to update the columns of a record with a id as they are in the attributes map.
+// If from isn't empty, the record is only updated if its status is still from, otherwise ErrStaleObject is returned.
+func updateAppointmentColumns(ctx context.Context, id int64, am map[string]interface{}, from string) error {
 	if len(am) == 0 {
-		return errors.New("Zero key in the attributes map!")
+		return fmt.Errorf("Appointment.UpdateColumns error: %w", ErrEmptyAttributes)
 	}
-	am["updated_at"] = time.Now()
+	_, locked := am["lock_version"]
 	keys := allKeys(am)
 	sqlFmt := `UPDATE appointments SET %s WHERE id = %v`
 	setKeysArr := []string{}
 	for _, v := range keys {
+		if v == "lock_version" {
+			continue
+		}
 		s := fmt.Sprintf(" %s = :%s", v, v)
 		setKeysArr = append(setKeysArr, s)
 	}
+	if locked {
+		sqlFmt += " AND lock_version = :lock_version"
+		setKeysArr = append(setKeysArr, " lock_version = lock_version + 1")
+	}
+	arg := am
+	if from != "" {
+		// the status checked for the transition, the update fails if it's changed since
+		arg = map[string]interface{}{"from_status": from}
+		for k, v := range am {
+			arg[k] = v
+		}
+		sqlFmt += " AND status = :from_status"
+	}
 	sqlStr := fmt.Sprintf(sqlFmt, strings.Join(setKeysArr, ", "), id)
-	_, err := DB.NamedExec(sqlStr, am)
+	result, err := dbNamedExec(ctx, DB, "Appointment", sqlStr, arg)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
+	if locked || from != "" {
+		cnt, err := result.RowsAffected()
+		if err != nil {
+			logger().Error("Get the rows affected error", "model", "Appointment", "error", err)
+			return err
+		}
+		if cnt == 0 {
+			return ErrStaleObject
+		}
+	}
 	return nil
 }
 
 // Update is a method used to update a Appointment record with the map[string]interface{} typed key-value parameters.
+// The object is reloaded from the database after it's updated, its loaded associations are kept.
+// The update is optimistic locked by the lock_version of the object.
 func (_appointment *Appointment) Update(am map[string]interface{}) error {
+	return _appointment.UpdateContext(context.Background(), am)
+}
+
+// UpdateContext is same as Update but with the context ctx.
+func (_appointment *Appointment) UpdateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Appointment.Update", "appointments")
+	defer span.End()
 	if _appointment.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Appointment.Update error: %w", ErrInvalidID)
 	}
-	err := UpdateAppointment(_appointment.Id, am)
-	return err
+	if err := runBeforeUpdate(_appointment); err != nil {
+		return err
+	}
+	old := _appointment.attributes()
+	am["lock_version"] = _appointment.LockVersion
+	err := UpdateAppointmentContext(ctx, _appointment.Id, am)
+	if err != nil {
+		return err
+	}
+	if err = _appointment.reloadColumns(ctx); err != nil {
+		return err
+	}
+	_appointment.savedChanges = diffAttributes(old, _appointment.attributes())
+	return runAfterUpdate(ctx, _appointment)
 }
 
 // UpdateAttributes method is supposed to be used to update Appointment records as corresponding update_attributes in Ruby on Rails.
+// The attributes are assigned to the object which is then saved, i.e. it's validated,
+// the callbacks are run and the updated_at is stamped, see Save.
 func (_appointment *Appointment) UpdateAttributes(am map[string]interface{}) error {
+	return _appointment.UpdateAttributesContext(context.Background(), am)
+}
+
+// UpdateAttributesContext is same as UpdateAttributes but with the context ctx.
+func (_appointment *Appointment) UpdateAttributesContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Appointment.UpdateAttributes", "appointments")
+	defer span.End()
 	if _appointment.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Appointment.UpdateAttributes error: %w", ErrInvalidID)
 	}
-	err := UpdateAppointment(_appointment.Id, am)
-	return err
+	if err := assignColumns(_appointment, am); err != nil {
+		return err
+	}
+	return _appointment.SaveContext(ctx)
 }
 
 // UpdateColumns method is supposed to be used to update Appointment records as corresponding update_columns in Ruby on Rails.
+// The columns are written as they are, skipping the validation, the callbacks and the updated_at
+// stamp, and then assigned to the object. A value which can't be assigned fails before anything is written. Neither is the status transition checked nor the freed
+// slot of a cancelled appointment offered, see Save for them.
 func (_appointment *Appointment) UpdateColumns(am map[string]interface{}) error {
+	return _appointment.UpdateColumnsContext(context.Background(), am)
+}
+
+// UpdateColumnsContext is same as UpdateColumns but with the context ctx.
+func (_appointment *Appointment) UpdateColumnsContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Appointment.UpdateColumns", "appointments")
+	defer span.End()
 	if _appointment.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Appointment.UpdateColumns error: %w", ErrInvalidID)
 	}
-	err := UpdateAppointment(_appointment.Id, am)
-	return err
+	assigned := *_appointment
+	if err := assignColumns(&assigned, am); err != nil {
+		return err
+	}
+	if err := updateAppointmentColumns(ctx, _appointment.Id, am, ""); err != nil {
+		return err
+	}
+	*_appointment = assigned
+	markSaved(_appointment.original, _appointment.attributes(), allKeys(am))
+	return nil
 }
 
 // UpdateAppointmentsBySql is used to update Appointment records by a SQL clause
 // using the '?' binding syntax.
 func UpdateAppointmentsBySql(sql string, args ...interface{}) (int64, error) {
+	return UpdateAppointmentsBySqlContext(context.Background(), sql, args...)
+}
+
+// UpdateAppointmentsBySqlContext is same as UpdateAppointmentsBySql but with the context ctx.
+func UpdateAppointmentsBySqlContext(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "UpdateAppointmentsBySql", "appointments")
+	defer span.End()
 	if sql == "" {
-		return 0, errors.New("A blank SQL clause")
+		return 0, fmt.Errorf("UpdateAppointmentsBySql error: %w", ErrBlankSQL)
+	}
+	stmt, err := prepare(ctx, "Appointment", rebind(sql))
+	if err != nil {
+		return 0, err
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(args...)
+	result, err := stmt.Exec(ctx, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,1273 @@
+// Package models includes the functions on the model AppointmentReminder.
+package models
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
+)
+
+// AppointmentReminder is the model of the table appointment_reminders, the record of a reminder
+// sent LeadMinutes before an appointment, see ReminderScheduler. The table has a unique key on
+// (appointment_id, lead_minutes), so a reminder is sent only once, see schema.sql.
+type AppointmentReminder struct {
+	Id            int64     `json:"id,omitempty" db:"id" valid:"-"`
+	AppointmentId int64     `json:"appointment_id,omitempty" db:"appointment_id" valid:"required"`
+	LeadMinutes   int64     `json:"lead_minutes,omitempty" db:"lead_minutes" valid:"required"`
+	SentAt        time.Time `json:"sent_at,omitempty" db:"sent_at" valid:"-"`
+	CreatedAt     time.Time `json:"created_at,omitempty" db:"created_at" valid:"-"`
+	UpdatedAt     time.Time `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
+	original      map[string]interface{}
+	savedChanges  map[string]Change
+}
+
+// attributes returns the values of the changeable columns of the AppointmentReminder object by column names.
+func (_appointmentReminder *AppointmentReminder) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"appointment_id": _appointmentReminder.AppointmentId,
+		"lead_minutes":   _appointmentReminder.LeadMinutes,
+		"sent_at":        _appointmentReminder.SentAt,
+	}
+}
+
+// snapshot keeps the current attributes of the AppointmentReminder object as the original ones for the dirty tracking.
+func (_appointmentReminder *AppointmentReminder) snapshot() {
+	_appointmentReminder.original = _appointmentReminder.attributes()
+}
+
+// snapshotAppointmentReminders keeps the current attributes of the loaded AppointmentReminder objects as the original ones.
+func snapshotAppointmentReminders(_appointmentReminders []AppointmentReminder) {
+	for i := range _appointmentReminders {
+		_appointmentReminders[i].snapshot()
+	}
+}
+
+// Changed reports whether any attribute of the AppointmentReminder object is changed since it was loaded
+// or saved. An object which is not loaded from the database is always changed.
+func (_appointmentReminder *AppointmentReminder) Changed() bool {
+	return len(_appointmentReminder.ChangedFields()) > 0
+}
+
+// ChangedFields returns the sorted column names of the changed attributes of the AppointmentReminder object.
+func (_appointmentReminder *AppointmentReminder) ChangedFields() []string {
+	return changedFields(_appointmentReminder.original, _appointmentReminder.attributes())
+}
+
+// Changes returns the old and new values of the changed attributes of the AppointmentReminder object by column names.
+func (_appointmentReminder *AppointmentReminder) Changes() map[string]Change {
+	return diffAttributes(_appointmentReminder.original, _appointmentReminder.attributes())
+}
+
+// SavedChanges returns the changes written by the last Create, Save, Update or UpdateAttributes
+// of the AppointmentReminder object, e.g. for an After callback to audit them.
+func (_appointmentReminder *AppointmentReminder) SavedChanges() map[string]Change {
+	return _appointmentReminder.savedChanges
+}
+
+// DataStruct for the pagination
+type AppointmentReminderPage struct {
+	WhereString string
+	WhereParams []interface{}
+	Order       map[string]string
+	FirstId     int64
+	LastId      int64
+	PageNum     int
+	PerPage     int
+	TotalPages  int
+	TotalItems  int64
+	orderStr    string
+}
+
+// Current get the current page of AppointmentReminderPage object for pagination.
+func (_p *AppointmentReminderPage) Current() ([]AppointmentReminder, error) {
+	return _p.CurrentContext(context.Background())
+}
+
+// CurrentContext is same as Current but with the context ctx.
+func (_p *AppointmentReminderPage) CurrentContext(ctx context.Context) ([]AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderPage.Current", "appointment_reminders")
+	defer span.End()
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("AppointmentReminderPage.Current error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("current")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	appointmentReminders, err := FindAppointmentRemindersWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(appointmentReminders) != 0 {
+		_p.FirstId, _p.LastId = appointmentReminders[0].Id, appointmentReminders[len(appointmentReminders)-1].Id
+	}
+	return appointmentReminders, nil
+}
+
+// Previous get the previous page of AppointmentReminderPage object for pagination.
+func (_p *AppointmentReminderPage) Previous() ([]AppointmentReminder, error) {
+	return _p.PreviousContext(context.Background())
+}
+
+// PreviousContext is same as Previous but with the context ctx.
+func (_p *AppointmentReminderPage) PreviousContext(ctx context.Context) ([]AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderPage.Previous", "appointment_reminders")
+	defer span.End()
+	if _p.PageNum == 0 {
+		return nil, fmt.Errorf("AppointmentReminderPage.Previous error: %w", ErrFirstPage)
+	}
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("AppointmentReminderPage.Previous error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("previous")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	appointmentReminders, err := FindAppointmentRemindersWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(appointmentReminders) != 0 {
+		_p.FirstId, _p.LastId = appointmentReminders[0].Id, appointmentReminders[len(appointmentReminders)-1].Id
+	}
+	_p.PageNum -= 1
+	return appointmentReminders, nil
+}
+
+// Next get the next page of AppointmentReminderPage object for pagination.
+func (_p *AppointmentReminderPage) Next() ([]AppointmentReminder, error) {
+	return _p.NextContext(context.Background())
+}
+
+// NextContext is same as Next but with the context ctx.
+func (_p *AppointmentReminderPage) NextContext(ctx context.Context) ([]AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderPage.Next", "appointment_reminders")
+	defer span.End()
+	if _p.PageNum == _p.TotalPages-1 {
+		return nil, fmt.Errorf("AppointmentReminderPage.Next error: %w", ErrLastPage)
+	}
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("AppointmentReminderPage.Next error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("next")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	appointmentReminders, err := FindAppointmentRemindersWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(appointmentReminders) != 0 {
+		_p.FirstId, _p.LastId = appointmentReminders[0].Id, appointmentReminders[len(appointmentReminders)-1].Id
+	}
+	_p.PageNum += 1
+	return appointmentReminders, nil
+}
+
+// GetPage is a helper function for the AppointmentReminderPage object to return a corresponding page due to
+// the parameter passed in, i.e. one of "previous, current or next".
+func (_p *AppointmentReminderPage) GetPage(direction string) (ps []AppointmentReminder, err error) {
+	return _p.GetPageContext(context.Background(), direction)
+}
+
+// GetPageContext is same as GetPage but with the context ctx.
+func (_p *AppointmentReminderPage) GetPageContext(ctx context.Context, direction string) (ps []AppointmentReminder, err error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderPage.GetPage", "appointment_reminders")
+	defer span.End()
+	switch direction {
+	case "previous":
+		ps, err = _p.PreviousContext(ctx)
+	case "next":
+		ps, err = _p.NextContext(ctx)
+	case "current":
+		ps, err = _p.CurrentContext(ctx)
+	default:
+		return nil, fmt.Errorf("AppointmentReminderPage.GetPage error: %w: %q", ErrWrongDirection, direction)
+	}
+	return
+}
+
+// buildOrder is for AppointmentReminderPage object to build a SQL ORDER BY clause.
+func (_p *AppointmentReminderPage) buildOrder() {
+	tempList := []string{}
+	for k, v := range _p.Order {
+		tempList = append(tempList, fmt.Sprintf("%v %v", k, v))
+	}
+	_p.orderStr = " ORDER BY " + strings.Join(tempList, ", ")
+}
+
+// buildIdRestrict is for AppointmentReminderPage object to build a SQL clause for ID restriction,
+// implementing a simple keyset style pagination.
+func (_p *AppointmentReminderPage) buildIdRestrict(direction string) (idStr string, idParams []interface{}) {
+	switch direction {
+	case "previous":
+		if strings.ToLower(_p.Order["id"]) == "desc" {
+			idStr += "id > ? "
+			idParams = append(idParams, _p.FirstId)
+		} else {
+			idStr += "id < ? "
+			idParams = append(idParams, _p.FirstId)
+		}
+	case "current":
+		// trick to make Where function work
+		if _p.PageNum == 0 && _p.FirstId == 0 && _p.LastId == 0 {
+			idStr += "id > ? "
+			idParams = append(idParams, 0)
+		} else {
+			if strings.ToLower(_p.Order["id"]) == "desc" {
+				idStr += "id <= ? AND id >= ? "
+				idParams = append(idParams, _p.FirstId, _p.LastId)
+			} else {
+				idStr += "id >= ? AND id <= ? "
+				idParams = append(idParams, _p.FirstId, _p.LastId)
+			}
+		}
+	case "next":
+		if strings.ToLower(_p.Order["id"]) == "desc" {
+			idStr += "id < ? "
+			idParams = append(idParams, _p.LastId)
+		} else {
+			idStr += "id > ? "
+			idParams = append(idParams, _p.LastId)
+		}
+	}
+	if _p.WhereString != "" {
+		idStr = " AND " + idStr
+	}
+	return
+}
+
+// buildPageCount calculate the TotalItems/TotalPages for the AppointmentReminderPage object.
+func (_p *AppointmentReminderPage) buildPageCount(ctx context.Context) error {
+	count, err := AppointmentReminderCountWhereContext(ctx, _p.WhereString, _p.WhereParams...)
+	if err != nil {
+		return err
+	}
+	_p.TotalItems = count
+	if _p.PerPage == 0 {
+		_p.PerPage = 10
+	}
+	_p.TotalPages = int(math.Ceil(float64(_p.TotalItems) / float64(_p.PerPage)))
+	return nil
+}
+
+// FindAppointmentReminder find a single appointment reminder by an ID.
+func FindAppointmentReminder(id int64) (*AppointmentReminder, error) {
+	return FindAppointmentReminderContext(context.Background(), id)
+}
+
+// FindAppointmentReminderContext is same as FindAppointmentReminder but with the context ctx.
+func FindAppointmentReminderContext(ctx context.Context, id int64) (*AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentReminder", "appointment_reminders")
+	defer span.End()
+	if id == 0 {
+		return nil, fmt.Errorf("FindAppointmentReminder error: %w", ErrInvalidID)
+	}
+	_appointmentReminder := AppointmentReminder{}
+	err := dbGet(ctx, DB, "AppointmentReminder", &_appointmentReminder, rebind(`SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders WHERE appointment_reminders.id = ? LIMIT 1`), id)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentReminder.snapshot()
+	return &_appointmentReminder, nil
+}
+
+// FirstAppointmentReminder find the first one appointment reminder by ID ASC order.
+func FirstAppointmentReminder() (*AppointmentReminder, error) {
+	return FirstAppointmentReminderContext(context.Background())
+}
+
+// FirstAppointmentReminderContext is same as FirstAppointmentReminder but with the context ctx.
+func FirstAppointmentReminderContext(ctx context.Context) (*AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "FirstAppointmentReminder", "appointment_reminders")
+	defer span.End()
+	_appointmentReminder := AppointmentReminder{}
+	err := dbGet(ctx, DB, "AppointmentReminder", &_appointmentReminder, rebind(`SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders ORDER BY appointment_reminders.id ASC LIMIT 1`))
+	if err != nil {
+		return nil, err
+	}
+	_appointmentReminder.snapshot()
+	return &_appointmentReminder, nil
+}
+
+// FirstAppointmentReminders find the first N appointment reminders by ID ASC order.
+func FirstAppointmentReminders(n uint32) ([]AppointmentReminder, error) {
+	return FirstAppointmentRemindersContext(context.Background(), n)
+}
+
+// FirstAppointmentRemindersContext is same as FirstAppointmentReminders but with the context ctx.
+func FirstAppointmentRemindersContext(ctx context.Context, n uint32) ([]AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "FirstAppointmentReminders", "appointment_reminders")
+	defer span.End()
+	_appointmentReminders := []AppointmentReminder{}
+	sql := fmt.Sprintf("SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders ORDER BY appointment_reminders.id ASC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "AppointmentReminder", &_appointmentReminders, rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentReminders(_appointmentReminders)
+	return _appointmentReminders, nil
+}
+
+// LastAppointmentReminder find the last one appointment reminder by ID DESC order.
+func LastAppointmentReminder() (*AppointmentReminder, error) {
+	return LastAppointmentReminderContext(context.Background())
+}
+
+// LastAppointmentReminderContext is same as LastAppointmentReminder but with the context ctx.
+func LastAppointmentReminderContext(ctx context.Context) (*AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "LastAppointmentReminder", "appointment_reminders")
+	defer span.End()
+	_appointmentReminder := AppointmentReminder{}
+	err := dbGet(ctx, DB, "AppointmentReminder", &_appointmentReminder, rebind(`SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders ORDER BY appointment_reminders.id DESC LIMIT 1`))
+	if err != nil {
+		return nil, err
+	}
+	_appointmentReminder.snapshot()
+	return &_appointmentReminder, nil
+}
+
+// LastAppointmentReminders find the last N appointment reminders by ID DESC order.
+func LastAppointmentReminders(n uint32) ([]AppointmentReminder, error) {
+	return LastAppointmentRemindersContext(context.Background(), n)
+}
+
+// LastAppointmentRemindersContext is same as LastAppointmentReminders but with the context ctx.
+func LastAppointmentRemindersContext(ctx context.Context, n uint32) ([]AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "LastAppointmentReminders", "appointment_reminders")
+	defer span.End()
+	_appointmentReminders := []AppointmentReminder{}
+	sql := fmt.Sprintf("SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders ORDER BY appointment_reminders.id DESC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "AppointmentReminder", &_appointmentReminders, rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentReminders(_appointmentReminders)
+	return _appointmentReminders, nil
+}
+
+// FindAppointmentReminders find one or more appointment reminders by the given ID(s).
+func FindAppointmentReminders(ids ...int64) ([]AppointmentReminder, error) {
+	return FindAppointmentRemindersContext(context.Background(), ids...)
+}
+
+// FindAppointmentRemindersContext is same as FindAppointmentReminders but with the context ctx.
+func FindAppointmentRemindersContext(ctx context.Context, ids ...int64) ([]AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentReminders", "appointment_reminders")
+	defer span.End()
+	if len(ids) == 0 {
+		err := fmt.Errorf("FindAppointmentReminders error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "AppointmentReminder")
+		return nil, err
+	}
+	_appointmentReminders := []AppointmentReminder{}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	sql := rebind(fmt.Sprintf(`SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders WHERE appointment_reminders.id IN (?%s)`, idsHolder))
+	idsT := []interface{}{}
+	for _, id := range ids {
+		idsT = append(idsT, interface{}(id))
+	}
+	err := dbSelect(ctx, DB, "AppointmentReminder", &_appointmentReminders, sql, idsT...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentReminders(_appointmentReminders)
+	return _appointmentReminders, nil
+}
+
+// FindAppointmentReminderBy find a single appointment reminder by a field name and a value.
+func FindAppointmentReminderBy(field string, val interface{}) (*AppointmentReminder, error) {
+	return FindAppointmentReminderByContext(context.Background(), field, val)
+}
+
+// FindAppointmentReminderByContext is same as FindAppointmentReminderBy but with the context ctx.
+func FindAppointmentReminderByContext(ctx context.Context, field string, val interface{}) (*AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentReminderBy", "appointment_reminders")
+	defer span.End()
+	_appointmentReminder := AppointmentReminder{}
+	sqlFmt := `SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders WHERE %s = ? LIMIT 1`
+	sqlStr := fmt.Sprintf(sqlFmt, field)
+	err := dbGet(ctx, DB, "AppointmentReminder", &_appointmentReminder, rebind(sqlStr), val)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentReminder.snapshot()
+	return &_appointmentReminder, nil
+}
+
+// FindAppointmentRemindersBy find all appointment reminders by a field name and a value.
+func FindAppointmentRemindersBy(field string, val interface{}) (_appointmentReminders []AppointmentReminder, err error) {
+	return FindAppointmentRemindersByContext(context.Background(), field, val)
+}
+
+// FindAppointmentRemindersByContext is same as FindAppointmentRemindersBy but with the context ctx.
+func FindAppointmentRemindersByContext(ctx context.Context, field string, val interface{}) (_appointmentReminders []AppointmentReminder, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentRemindersBy", "appointment_reminders")
+	defer span.End()
+	sqlFmt := `SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders WHERE %s = ?`
+	sqlStr := fmt.Sprintf(sqlFmt, field)
+	err = dbSelect(ctx, DB, "AppointmentReminder", &_appointmentReminders, rebind(sqlStr), val)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentReminders(_appointmentReminders)
+	return _appointmentReminders, nil
+}
+
+// AllAppointmentReminders get all the AppointmentReminder records.
+func AllAppointmentReminders() (appointmentReminders []AppointmentReminder, err error) {
+	return AllAppointmentRemindersContext(context.Background())
+}
+
+// AllAppointmentRemindersContext is same as AllAppointmentReminders but with the context ctx.
+func AllAppointmentRemindersContext(ctx context.Context) (appointmentReminders []AppointmentReminder, err error) {
+	ctx, span := startSpan(ctx, "AllAppointmentReminders", "appointment_reminders")
+	defer span.End()
+	err = dbSelect(ctx, DB, "AppointmentReminder", &appointmentReminders, "SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders")
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentReminders(appointmentReminders)
+	return appointmentReminders, nil
+}
+
+// AppointmentReminderCount get the count of all the AppointmentReminder records.
+func AppointmentReminderCount() (c int64, err error) {
+	return AppointmentReminderCountContext(context.Background())
+}
+
+// AppointmentReminderCountContext is same as AppointmentReminderCount but with the context ctx.
+func AppointmentReminderCountContext(ctx context.Context) (c int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderCount", "appointment_reminders")
+	defer span.End()
+	err = dbGet(ctx, DB, "AppointmentReminder", &c, "SELECT count(*) FROM appointment_reminders")
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+// AppointmentReminderCountWhere get the count of all the AppointmentReminder records with a where clause.
+func AppointmentReminderCountWhere(where string, args ...interface{}) (c int64, err error) {
+	return AppointmentReminderCountWhereContext(context.Background(), where, args...)
+}
+
+// AppointmentReminderCountWhereContext is same as AppointmentReminderCountWhere but with the context ctx.
+func AppointmentReminderCountWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderCountWhere", "appointment_reminders")
+	defer span.End()
+	sql := "SELECT count(*) FROM appointment_reminders"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	err = stmt.Get(ctx, &c, args...)
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+// AppointmentReminderIncludesWhere get the AppointmentReminder associated models records, currently it's not same as the corresponding "includes" function but "preload" instead in Ruby on Rails. It means that the "sql" should be restricted on AppointmentReminder model.
+func AppointmentReminderIncludesWhere(assocs []string, sql string, args ...interface{}) (_appointmentReminders []AppointmentReminder, err error) {
+	return AppointmentReminderIncludesWhereContext(context.Background(), assocs, sql, args...)
+}
+
+// AppointmentReminderIncludesWhereContext is same as AppointmentReminderIncludesWhere but with the context ctx.
+func AppointmentReminderIncludesWhereContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_appointmentReminders []AppointmentReminder, err error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderIncludesWhere", "appointment_reminders")
+	defer span.End()
+	_appointmentReminders, err = FindAppointmentRemindersWhereContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(assocs) == 0 {
+		logger().Debug("No associated fields are specified", "model", "AppointmentReminder")
+		return _appointmentReminders, err
+	}
+	if len(_appointmentReminders) <= 0 {
+		return nil, fmt.Errorf("AppointmentReminderIncludesWhere error: %w", ErrNotFound)
+	}
+	ids := make([]interface{}, len(_appointmentReminders))
+	for _, v := range _appointmentReminders {
+		ids = append(ids, interface{}(v.Id))
+	}
+	return _appointmentReminders, nil
+}
+
+// AppointmentReminderIds get all the IDs of AppointmentReminder records.
+func AppointmentReminderIds() (ids []int64, err error) {
+	return AppointmentReminderIdsContext(context.Background())
+}
+
+// AppointmentReminderIdsContext is same as AppointmentReminderIds but with the context ctx.
+func AppointmentReminderIdsContext(ctx context.Context) (ids []int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderIds", "appointment_reminders")
+	defer span.End()
+	err = dbSelect(ctx, DB, "AppointmentReminder", &ids, "SELECT id FROM appointment_reminders")
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// AppointmentReminderIdsWhere get all the IDs of AppointmentReminder records by where restriction.
+func AppointmentReminderIdsWhere(where string, args ...interface{}) ([]int64, error) {
+	return AppointmentReminderIdsWhereContext(context.Background(), where, args...)
+}
+
+// AppointmentReminderIdsWhereContext is same as AppointmentReminderIdsWhere but with the context ctx.
+func AppointmentReminderIdsWhereContext(ctx context.Context, where string, args ...interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderIdsWhere", "appointment_reminders")
+	defer span.End()
+	ids, err := AppointmentReminderIntColContext(ctx, "id", where, args...)
+	return ids, err
+}
+
+// AppointmentReminderIntCol get some int64 typed column of AppointmentReminder by where restriction.
+func AppointmentReminderIntCol(col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	return AppointmentReminderIntColContext(context.Background(), col, where, args...)
+}
+
+// AppointmentReminderIntColContext is same as AppointmentReminderIntCol but with the context ctx.
+func AppointmentReminderIntColContext(ctx context.Context, col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderIntCol", "appointment_reminders")
+	defer span.End()
+	sql := "SELECT " + col + " FROM appointment_reminders"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &intColRecs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return intColRecs, nil
+}
+
+// AppointmentReminderStrCol get some string typed column of AppointmentReminder by where restriction.
+func AppointmentReminderStrCol(col, where string, args ...interface{}) (strColRecs []string, err error) {
+	return AppointmentReminderStrColContext(context.Background(), col, where, args...)
+}
+
+// AppointmentReminderStrColContext is same as AppointmentReminderStrCol but with the context ctx.
+func AppointmentReminderStrColContext(ctx context.Context, col, where string, args ...interface{}) (strColRecs []string, err error) {
+	ctx, span := startSpan(ctx, "AppointmentReminderStrCol", "appointment_reminders")
+	defer span.End()
+	sql := "SELECT " + col + " FROM appointment_reminders"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &strColRecs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return strColRecs, nil
+}
+
+// FindAppointmentRemindersWhere query use a partial SQL clause that usually following after WHERE
+// with placeholders, eg: FindUsersWhere("first_name = ? AND age > ?", "John", 18)
+// will return those records in the table "users" whose first_name is "John" and age elder than 18.
+func FindAppointmentRemindersWhere(where string, args ...interface{}) (appointmentReminders []AppointmentReminder, err error) {
+	return FindAppointmentRemindersWhereContext(context.Background(), where, args...)
+}
+
+// FindAppointmentRemindersWhereContext is same as FindAppointmentRemindersWhere but with the context ctx.
+func FindAppointmentRemindersWhereContext(ctx context.Context, where string, args ...interface{}) (appointmentReminders []AppointmentReminder, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentRemindersWhere", "appointment_reminders")
+	defer span.End()
+	sql := "SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &appointmentReminders, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentReminders(appointmentReminders)
+	return appointmentReminders, nil
+}
+
+// FindAppointmentReminderBySql query use a complete SQL clause
+// with placeholders, eg: FindUserBySql("SELECT * FROM users WHERE first_name = ? AND age > ? ORDER BY DESC LIMIT 1", "John", 18)
+// will return only One record in the table "users" whose first_name is "John" and age elder than 18.
+func FindAppointmentReminderBySql(sql string, args ...interface{}) (*AppointmentReminder, error) {
+	return FindAppointmentReminderBySqlContext(context.Background(), sql, args...)
+}
+
+// FindAppointmentReminderBySqlContext is same as FindAppointmentReminderBySql but with the context ctx.
+func FindAppointmentReminderBySqlContext(ctx context.Context, sql string, args ...interface{}) (*AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentReminderBySql", "appointment_reminders")
+	defer span.End()
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	_appointmentReminder := &AppointmentReminder{}
+	err = stmt.Get(ctx, _appointmentReminder, args...)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentReminder.snapshot()
+	return _appointmentReminder, nil
+}
+
+// FindAppointmentRemindersBySql query use a complete SQL clause
+// with placeholders, eg: FindUsersBySql("SELECT * FROM users WHERE first_name = ? AND age > ?", "John", 18)
+// will return those records in the table "users" whose first_name is "John" and age elder than 18.
+func FindAppointmentRemindersBySql(sql string, args ...interface{}) (appointmentReminders []AppointmentReminder, err error) {
+	return FindAppointmentRemindersBySqlContext(context.Background(), sql, args...)
+}
+
+// FindAppointmentRemindersBySqlContext is same as FindAppointmentRemindersBySql but with the context ctx.
+func FindAppointmentRemindersBySqlContext(ctx context.Context, sql string, args ...interface{}) (appointmentReminders []AppointmentReminder, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentRemindersBySql", "appointment_reminders")
+	defer span.End()
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &appointmentReminders, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentReminders(appointmentReminders)
+	return appointmentReminders, nil
+}
+
+// FindAppointmentReminderForUpdate find a single appointment reminder by an ID in the transaction tx and lock its row
+// FOR UPDATE until the transaction ends.
+func FindAppointmentReminderForUpdate(tx *sqlx.Tx, id int64) (*AppointmentReminder, error) {
+	return FindAppointmentReminderForUpdateContext(context.Background(), tx, id)
+}
+
+// FindAppointmentReminderForUpdateContext is same as FindAppointmentReminderForUpdate but with the context ctx.
+func FindAppointmentReminderForUpdateContext(ctx context.Context, tx *sqlx.Tx, id int64) (*AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentReminderForUpdate", "appointment_reminders")
+	defer span.End()
+	return findAppointmentReminderLock(ctx, tx, ForUpdate, id)
+}
+
+// FindAppointmentReminderForShare find a single appointment reminder by an ID in the transaction tx and lock its row
+// FOR SHARE until the transaction ends.
+func FindAppointmentReminderForShare(tx *sqlx.Tx, id int64) (*AppointmentReminder, error) {
+	return FindAppointmentReminderForShareContext(context.Background(), tx, id)
+}
+
+// FindAppointmentReminderForShareContext is same as FindAppointmentReminderForShare but with the context ctx.
+func FindAppointmentReminderForShareContext(ctx context.Context, tx *sqlx.Tx, id int64) (*AppointmentReminder, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentReminderForShare", "appointment_reminders")
+	defer span.End()
+	return findAppointmentReminderLock(ctx, tx, ForShare, id)
+}
+
+// findAppointmentReminderLock find a single appointment reminder by an ID in the transaction tx with a row lock mode.
+func findAppointmentReminderLock(ctx context.Context, tx *sqlx.Tx, mode LockMode, id int64) (*AppointmentReminder, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("Lock AppointmentReminder error: %w", ErrInvalidID)
+	}
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentReminder := AppointmentReminder{}
+	err = dbGet(ctx, tx, "AppointmentReminder", &_appointmentReminder, tx.Rebind(`SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders WHERE appointment_reminders.id = ? LIMIT 1 `+lock), id)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentReminder.snapshot()
+	return &_appointmentReminder, nil
+}
+
+// FindAppointmentRemindersWhereLock is same as FindAppointmentRemindersWhere but runs in the transaction tx and lock
+// the selected rows with the lock mode, e.g. ForUpdateSkipLocked, until the transaction ends.
+// The where clause should be a condition only, i.e. with no ORDER BY or LIMIT.
+func FindAppointmentRemindersWhereLock(tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (appointmentReminders []AppointmentReminder, err error) {
+	return FindAppointmentRemindersWhereLockContext(context.Background(), tx, mode, where, args...)
+}
+
+// FindAppointmentRemindersWhereLockContext is same as FindAppointmentRemindersWhereLock but with the context ctx.
+func FindAppointmentRemindersWhereLockContext(ctx context.Context, tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (appointmentReminders []AppointmentReminder, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentRemindersWhereLock", "appointment_reminders")
+	defer span.End()
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	sql := "SELECT COALESCE(appointment_reminders.appointment_id, 0) AS appointment_id, COALESCE(appointment_reminders.lead_minutes, 0) AS lead_minutes, appointment_reminders.sent_at, appointment_reminders.id, appointment_reminders.created_at, appointment_reminders.updated_at FROM appointment_reminders"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	err = dbSelect(ctx, tx, "AppointmentReminder", &appointmentReminders, tx.Rebind(sql+" "+lock), args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentReminders(appointmentReminders)
+	return appointmentReminders, nil
+}
+
+// CreateAppointmentReminder use a named params to create a single AppointmentReminder record.
+// A named params is key-value map like map[string]interface{}{"first_name": "John", "age": 23} .
+func CreateAppointmentReminder(am map[string]interface{}) (int64, error) {
+	return CreateAppointmentReminderContext(context.Background(), am)
+}
+
+// CreateAppointmentReminderContext is same as CreateAppointmentReminder but with the context ctx.
+func CreateAppointmentReminderContext(ctx context.Context, am map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointmentReminder", "appointment_reminders")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("CreateAppointmentReminder error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	keys := allKeys(am)
+	sqlFmt := `INSERT INTO appointment_reminders (%s) VALUES (%s)`
+	sql := fmt.Sprintf(sqlFmt, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
+	result, err := dbNamedExec(ctx, DB, "AppointmentReminder", sql, am)
+	if err != nil {
+		return 0, err
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		logger().Error("Get the last insert id error", "model", "AppointmentReminder", "error", err)
+		return 0, err
+	}
+	return lastId, nil
+}
+
+// CreateAppointmentReminders creates the AppointmentReminder records of the slice appointment reminders with multi-row INSERT statements,
+// see BulkInsertChunkSize. The structs are validated and time stamped, and their Id fields
+// are set to the ids of the created records, which are returned too. No callbacks are run.
+func CreateAppointmentReminders(appointmentReminders []AppointmentReminder) ([]int64, error) {
+	return CreateAppointmentRemindersContext(context.Background(), appointmentReminders)
+}
+
+// CreateAppointmentRemindersContext is same as CreateAppointmentReminders but with the context ctx.
+func CreateAppointmentRemindersContext(ctx context.Context, appointmentReminders []AppointmentReminder) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointmentReminders", "appointment_reminders")
+	defer span.End()
+	t := time.Now()
+	rows := make([][]interface{}, len(appointmentReminders))
+	for i := range appointmentReminders {
+		_appointmentReminder := &appointmentReminders[i]
+		ok, err := govalidator.ValidateStruct(_appointmentReminder)
+		if !ok {
+			err = &ValidationError{Model: "AppointmentReminder", Index: i, Err: err}
+			logger().Warn(err.Error(), "model", "AppointmentReminder")
+			return nil, err
+		}
+		_appointmentReminder.CreatedAt = t
+		_appointmentReminder.UpdatedAt = t
+		rows[i] = []interface{}{_appointmentReminder.AppointmentId, _appointmentReminder.LeadMinutes, _appointmentReminder.SentAt, _appointmentReminder.CreatedAt, _appointmentReminder.UpdatedAt}
+	}
+	ids, err := bulkInsert(ctx, "AppointmentReminder", "appointment_reminders", []string{"appointment_id", "lead_minutes", "sent_at", "created_at", "updated_at"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		appointmentReminders[i].Id = id
+		appointmentReminders[i].snapshot()
+	}
+	return ids, nil
+}
+
+// CreateAppointmentRemindersMaps use a slice of named params to create AppointmentReminder records like CreateAppointmentReminder does,
+// but with multi-row INSERT statements, see BulkInsertChunkSize. A column missing in some
+// of the maps gets its default value. The ids of the created records are returned in order.
+func CreateAppointmentRemindersMaps(ams []map[string]interface{}) ([]int64, error) {
+	return CreateAppointmentRemindersMapsContext(context.Background(), ams)
+}
+
+// CreateAppointmentRemindersMapsContext is same as CreateAppointmentRemindersMaps but with the context ctx.
+func CreateAppointmentRemindersMapsContext(ctx context.Context, ams []map[string]interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointmentRemindersMaps", "appointment_reminders")
+	defer span.End()
+	for i, am := range ams {
+		if len(am) == 0 {
+			return nil, fmt.Errorf("CreateAppointmentRemindersMaps error: %w at index %d", ErrEmptyAttributes, i)
+		}
+	}
+	keys, rows := bulkMapRows(ams)
+	return bulkInsert(ctx, "AppointmentReminder", "appointment_reminders", keys, rows)
+}
+
+// Create is a method for AppointmentReminder to create a record, the Id of the object is set to the new id.
+// It runs the BeforeValidate, BeforeCreate, AfterCreate and AfterCommit callbacks if implemented.
+func (_appointmentReminder *AppointmentReminder) Create() (int64, error) {
+	return _appointmentReminder.CreateContext(context.Background())
+}
+
+// CreateContext is same as Create but with the context ctx.
+func (_appointmentReminder *AppointmentReminder) CreateContext(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "AppointmentReminder.Create", "appointment_reminders")
+	defer span.End()
+	if err := runBeforeValidate(_appointmentReminder); err != nil {
+		return 0, err
+	}
+	ok, err := govalidator.ValidateStruct(_appointmentReminder)
+	if !ok {
+		err = &ValidationError{Model: "AppointmentReminder", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "AppointmentReminder")
+		return 0, err
+	}
+	if err = runBeforeCreate(_appointmentReminder); err != nil {
+		return 0, err
+	}
+	t := time.Now()
+	_appointmentReminder.CreatedAt = t
+	_appointmentReminder.UpdatedAt = t
+	sql := `INSERT INTO appointment_reminders (appointment_id,lead_minutes,sent_at,created_at,updated_at) VALUES (:appointment_id,:lead_minutes,:sent_at,:created_at,:updated_at)`
+	result, err := dbNamedExec(ctx, DB, "AppointmentReminder", sql, _appointmentReminder)
+	if err != nil {
+		return 0, err
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		logger().Error("Get the last insert id error", "model", "AppointmentReminder", "error", err)
+		return 0, err
+	}
+	_appointmentReminder.Id = lastId
+	_appointmentReminder.savedChanges = diffAttributes(nil, _appointmentReminder.attributes())
+	_appointmentReminder.snapshot()
+	if err = runAfterCreate(ctx, _appointmentReminder); err != nil {
+		return lastId, err
+	}
+	return lastId, nil
+}
+
+// Reload is a method for AppointmentReminder to reload the attributes of the object from the database.
+// The associations loaded into the object are reset.
+func (_appointmentReminder *AppointmentReminder) Reload() error {
+	return _appointmentReminder.ReloadContext(context.Background())
+}
+
+// ReloadContext is same as Reload but with the context ctx.
+func (_appointmentReminder *AppointmentReminder) ReloadContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "AppointmentReminder.Reload", "appointment_reminders")
+	defer span.End()
+	if _appointmentReminder.Id == 0 {
+		return fmt.Errorf("AppointmentReminder.Reload error: %w", ErrInvalidID)
+	}
+	appointmentReminder, err := FindAppointmentReminderContext(ctx, _appointmentReminder.Id)
+	if err != nil {
+		return err
+	}
+	*_appointmentReminder = *appointmentReminder
+	return nil
+}
+
+// Destroy is method used for a AppointmentReminder object to be destroyed.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
+func (_appointmentReminder *AppointmentReminder) Destroy() error {
+	return _appointmentReminder.DestroyContext(context.Background())
+}
+
+// DestroyContext is same as Destroy but with the context ctx.
+func (_appointmentReminder *AppointmentReminder) DestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "AppointmentReminder.Destroy", "appointment_reminders")
+	defer span.End()
+	if _appointmentReminder.Id == 0 {
+		return fmt.Errorf("AppointmentReminder.Destroy error: %w", ErrInvalidID)
+	}
+	if err := runBeforeDestroy(_appointmentReminder); err != nil {
+		return err
+	}
+	err := DestroyAppointmentReminderContext(ctx, _appointmentReminder.Id)
+	if err != nil {
+		return err
+	}
+	return runAfterDestroy(ctx, _appointmentReminder)
+}
+
+// DestroyAppointmentReminder will destroy a AppointmentReminder record specified by the id parameter.
+func DestroyAppointmentReminder(id int64) error {
+	return DestroyAppointmentReminderContext(context.Background(), id)
+}
+
+// DestroyAppointmentReminderContext is same as DestroyAppointmentReminder but with the context ctx.
+func DestroyAppointmentReminderContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DestroyAppointmentReminder", "appointment_reminders")
+	defer span.End()
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(`DELETE FROM appointment_reminders WHERE id = ?`))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(ctx, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DestroyAppointmentReminders will destroy AppointmentReminder records those specified by the ids parameters.
+func DestroyAppointmentReminders(ids ...int64) (int64, error) {
+	return DestroyAppointmentRemindersContext(context.Background(), ids...)
+}
+
+// DestroyAppointmentRemindersContext is same as DestroyAppointmentReminders but with the context ctx.
+func DestroyAppointmentRemindersContext(ctx context.Context, ids ...int64) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyAppointmentReminders", "appointment_reminders")
+	defer span.End()
+	if len(ids) == 0 {
+		err := fmt.Errorf("DestroyAppointmentReminders error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "AppointmentReminder")
+		return 0, err
+	}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	sql := fmt.Sprintf(`DELETE FROM appointment_reminders WHERE id IN (?%s)`, idsHolder)
+	idsT := []interface{}{}
+	for _, id := range ids {
+		idsT = append(idsT, interface{}(id))
+	}
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, idsT...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// DestroyAppointmentRemindersWhere delete records by a where clause restriction.
+// e.g. DestroyAppointmentRemindersWhere("name = ?", "John")
+// And this func will not call the association dependent action
+func DestroyAppointmentRemindersWhere(where string, args ...interface{}) (int64, error) {
+	return DestroyAppointmentRemindersWhereContext(context.Background(), where, args...)
+}
+
+// DestroyAppointmentRemindersWhereContext is same as DestroyAppointmentRemindersWhere but with the context ctx.
+func DestroyAppointmentRemindersWhereContext(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyAppointmentRemindersWhere", "appointment_reminders")
+	defer span.End()
+	sql := `DELETE FROM appointment_reminders WHERE `
+	if len(where) > 0 {
+		sql = sql + where
+	} else {
+		return 0, fmt.Errorf("DestroyAppointmentRemindersWhere error: %w", ErrNoWhere)
+	}
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// Save method is used for a AppointmentReminder object to update an existed record mainly.
+// If no id provided a new record will be created, and if the record of the id doesn't exist
+// it will be created with the id.
+// A loaded object only updates its changed columns, see ChangedFields.
+// The object is reloaded from the database after it's saved.
+// It runs the BeforeValidate, BeforeUpdate, AfterUpdate and AfterCommit callbacks if implemented.
+func (_appointmentReminder *AppointmentReminder) Save() error {
+	return _appointmentReminder.SaveContext(context.Background())
+}
+
+// SaveContext is same as Save but with the context ctx.
+func (_appointmentReminder *AppointmentReminder) SaveContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "AppointmentReminder.Save", "appointment_reminders")
+	defer span.End()
+	if _appointmentReminder.Id == 0 {
+		_, err := _appointmentReminder.CreateContext(ctx)
+		return err
+	}
+	if err := runBeforeValidate(_appointmentReminder); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_appointmentReminder)
+	if !ok {
+		err = &ValidationError{Model: "AppointmentReminder", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "AppointmentReminder")
+		return err
+	}
+	if err = runBeforeUpdate(_appointmentReminder); err != nil {
+		return err
+	}
+	old := _appointmentReminder.original
+	if old != nil && !_appointmentReminder.Changed() {
+		// a loaded object without changes has nothing to write
+		_appointmentReminder.savedChanges = nil
+		return runAfterUpdate(ctx, _appointmentReminder)
+	}
+	_appointmentReminder.UpdatedAt = time.Now()
+	if old != nil {
+		// a loaded object only updates its changed columns
+		sqlFmt := `UPDATE appointment_reminders SET %s WHERE id = %v`
+		sqlStr := fmt.Sprintf(sqlFmt, namedSets(append(_appointmentReminder.ChangedFields(), "updated_at")), _appointmentReminder.Id)
+		_, err = dbNamedExec(ctx, DB, "AppointmentReminder", sqlStr, _appointmentReminder)
+	} else {
+		// an object not loaded updates all its columns, or it's created with the id if the record doesn't exist
+		if _appointmentReminder.CreatedAt.IsZero() {
+			_appointmentReminder.CreatedAt = _appointmentReminder.UpdatedAt
+		}
+		_, err = upsertWithId(ctx, "AppointmentReminder", "appointment_reminders", _appointmentReminder.Id, _appointmentReminder, []string{"id", "appointment_id", "lead_minutes", "sent_at", "created_at", "updated_at"}, []string{"appointment_id", "lead_minutes", "sent_at", "updated_at"})
+	}
+	if err != nil {
+		return err
+	}
+	if err = _appointmentReminder.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_appointmentReminder.savedChanges = diffAttributes(old, _appointmentReminder.attributes())
+	return runAfterUpdate(ctx, _appointmentReminder)
+}
+
+// UpsertAppointmentReminder use a named params to create a single AppointmentReminder record, or to update the updateColumns
+// of the existed record instead if it conflicts with the params on the conflictColumns.
+// The id of the created or updated record is returned. With MySQL the conflict is detected
+// on any unique key of the table, the conflictColumns is only used by PostgreSQL and SQLite, so the
+// record conflicting on another unique key than the conflictColumns is updated and its id is returned.
+func UpsertAppointmentReminder(am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	return UpsertAppointmentReminderContext(context.Background(), am, conflictColumns, updateColumns)
+}
+
+// UpsertAppointmentReminderContext is same as UpsertAppointmentReminder but with the context ctx.
+func UpsertAppointmentReminderContext(ctx context.Context, am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	ctx, span := startSpan(ctx, "UpsertAppointmentReminder", "appointment_reminders")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("UpsertAppointmentReminder error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	return upsert(ctx, "AppointmentReminder", "appointment_reminders", am, allKeys(am), conflictColumns, updateColumns)
+}
+
+// Upsert is a method for AppointmentReminder to create a record, or to update the updateColumns of the existed
+// record instead if it conflicts with the object on the conflictColumns, see UpsertAppointmentReminder.
+// The Id of the object is set to the id of the created or updated record.
+// It runs the BeforeValidate callback if implemented.
+func (_appointmentReminder *AppointmentReminder) Upsert(conflictColumns, updateColumns []string) error {
+	return _appointmentReminder.UpsertContext(context.Background(), conflictColumns, updateColumns)
+}
+
+// UpsertContext is same as Upsert but with the context ctx.
+func (_appointmentReminder *AppointmentReminder) UpsertContext(ctx context.Context, conflictColumns, updateColumns []string) error {
+	ctx, span := startSpan(ctx, "AppointmentReminder.Upsert", "appointment_reminders")
+	defer span.End()
+	if err := runBeforeValidate(_appointmentReminder); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_appointmentReminder)
+	if !ok {
+		err = &ValidationError{Model: "AppointmentReminder", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "AppointmentReminder")
+		return err
+	}
+	t := time.Now()
+	if _appointmentReminder.CreatedAt.IsZero() {
+		_appointmentReminder.CreatedAt = t
+	}
+	_appointmentReminder.UpdatedAt = t
+	keys := []string{"appointment_id", "lead_minutes", "sent_at", "created_at", "updated_at"}
+	if _appointmentReminder.Id != 0 {
+		keys = append([]string{"id"}, keys...)
+	}
+	id, err := upsert(ctx, "AppointmentReminder", "appointment_reminders", _appointmentReminder, keys, conflictColumns, updateColumns)
+	if err != nil {
+		return err
+	}
+	_appointmentReminder.Id = id
+	_appointmentReminder.snapshot()
+	return nil
+}
+
+// UpdateAppointmentReminder is used to update a record with a id and map[string]interface{} typed key-value parameters.
+func UpdateAppointmentReminder(id int64, am map[string]interface{}) error {
+	return UpdateAppointmentReminderContext(context.Background(), id, am)
+}
+
+// UpdateAppointmentReminderContext is same as UpdateAppointmentReminder but with the context ctx.
+func UpdateAppointmentReminderContext(ctx context.Context, id int64, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "UpdateAppointmentReminder", "appointment_reminders")
+	defer span.End()
+	if len(am) == 0 {
+		return fmt.Errorf("UpdateAppointmentReminder error: %w", ErrEmptyAttributes)
+	}
+	am["updated_at"] = time.Now()
+	return updateAppointmentReminderColumns(ctx, id, am)
+}
+
+// updateAppointmentReminderColumns is used to update the columns of a record with a id as they are in the attributes map.
+func updateAppointmentReminderColumns(ctx context.Context, id int64, am map[string]interface{}) error {
+	if len(am) == 0 {
+		return fmt.Errorf("AppointmentReminder.UpdateColumns error: %w", ErrEmptyAttributes)
+	}
+	keys := allKeys(am)
+	sqlFmt := `UPDATE appointment_reminders SET %s WHERE id = %v`
+	setKeysArr := []string{}
+	for _, v := range keys {
+		s := fmt.Sprintf(" %s = :%s", v, v)
+		setKeysArr = append(setKeysArr, s)
+	}
+	sqlStr := fmt.Sprintf(sqlFmt, strings.Join(setKeysArr, ", "), id)
+	_, err := dbNamedExec(ctx, DB, "AppointmentReminder", sqlStr, am)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update is a method used to update a AppointmentReminder record with the map[string]interface{} typed key-value parameters.
+// The object is reloaded from the database after it's updated.
+func (_appointmentReminder *AppointmentReminder) Update(am map[string]interface{}) error {
+	return _appointmentReminder.UpdateContext(context.Background(), am)
+}
+
+// UpdateContext is same as Update but with the context ctx.
+func (_appointmentReminder *AppointmentReminder) UpdateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "AppointmentReminder.Update", "appointment_reminders")
+	defer span.End()
+	if _appointmentReminder.Id == 0 {
+		return fmt.Errorf("AppointmentReminder.Update error: %w", ErrInvalidID)
+	}
+	if err := runBeforeUpdate(_appointmentReminder); err != nil {
+		return err
+	}
+	old := _appointmentReminder.attributes()
+	err := UpdateAppointmentReminderContext(ctx, _appointmentReminder.Id, am)
+	if err != nil {
+		return err
+	}
+	if err = _appointmentReminder.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_appointmentReminder.savedChanges = diffAttributes(old, _appointmentReminder.attributes())
+	return runAfterUpdate(ctx, _appointmentReminder)
+}
+
+// UpdateAttributes method is supposed to be used to update AppointmentReminder records as corresponding update_attributes in Ruby on Rails.
+// The attributes are assigned to the object which is then saved, i.e. it's validated,
+// the callbacks are run and the updated_at is stamped, see Save.
+func (_appointmentReminder *AppointmentReminder) UpdateAttributes(am map[string]interface{}) error {
+	return _appointmentReminder.UpdateAttributesContext(context.Background(), am)
+}
+
+// UpdateAttributesContext is same as UpdateAttributes but with the context ctx.
+func (_appointmentReminder *AppointmentReminder) UpdateAttributesContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "AppointmentReminder.UpdateAttributes", "appointment_reminders")
+	defer span.End()
+	if _appointmentReminder.Id == 0 {
+		return fmt.Errorf("AppointmentReminder.UpdateAttributes error: %w", ErrInvalidID)
+	}
+	if err := assignColumns(_appointmentReminder, am); err != nil {
+		return err
+	}
+	return _appointmentReminder.SaveContext(ctx)
+}
+
+// UpdateColumns method is supposed to be used to update AppointmentReminder records as corresponding update_columns in Ruby on Rails.
+// The columns are written as they are, skipping the validation, the callbacks and the updated_at
+// stamp, and then assigned to the object. A value which can't be assigned fails before anything is written.
+func (_appointmentReminder *AppointmentReminder) UpdateColumns(am map[string]interface{}) error {
+	return _appointmentReminder.UpdateColumnsContext(context.Background(), am)
+}
+
+// UpdateColumnsContext is same as UpdateColumns but with the context ctx.
+func (_appointmentReminder *AppointmentReminder) UpdateColumnsContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "AppointmentReminder.UpdateColumns", "appointment_reminders")
+	defer span.End()
+	if _appointmentReminder.Id == 0 {
+		return fmt.Errorf("AppointmentReminder.UpdateColumns error: %w", ErrInvalidID)
+	}
+	assigned := *_appointmentReminder
+	if err := assignColumns(&assigned, am); err != nil {
+		return err
+	}
+	if err := updateAppointmentReminderColumns(ctx, _appointmentReminder.Id, am); err != nil {
+		return err
+	}
+	*_appointmentReminder = assigned
+	markSaved(_appointmentReminder.original, _appointmentReminder.attributes(), allKeys(am))
+	return nil
+}
+
+// UpdateAppointmentRemindersBySql is used to update AppointmentReminder records by a SQL clause
+// using the '?' binding syntax.
+func UpdateAppointmentRemindersBySql(sql string, args ...interface{}) (int64, error) {
+	return UpdateAppointmentRemindersBySqlContext(context.Background(), sql, args...)
+}
+
+// UpdateAppointmentRemindersBySqlContext is same as UpdateAppointmentRemindersBySql but with the context ctx.
+func UpdateAppointmentRemindersBySqlContext(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "UpdateAppointmentRemindersBySql", "appointment_reminders")
+	defer span.End()
+	if sql == "" {
+		return 0, fmt.Errorf("UpdateAppointmentRemindersBySql error: %w", ErrBlankSQL)
+	}
+	stmt, err := prepare(ctx, "AppointmentReminder", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
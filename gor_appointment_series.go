@@ -0,0 +1,1383 @@
+// Package models includes the functions on the model AppointmentSeries.
+package models
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
+)
+
+// AppointmentSeries is the model of the table appointment_series, the recurrence rule of the
+// Appointments of a patient with a physician, in the manner of an iCalendar RRULE: they recur by the
+// Frequency, i.e. daily, weekly or monthly, every Interval periods from StartsAt on, and end after
+// Count occurrences or at Until. A weekly one may recur on the ByDay weekdays like "MO,TH", and the
+// Exdates are the skipped occurrences, see Occurrences and Materialize.
+type AppointmentSeries struct {
+	Id           int64         `json:"id,omitempty" db:"id" valid:"-"`
+	PhysicianId  int64         `json:"physician_id,omitempty" db:"physician_id" valid:"required"`
+	PatientId    int64         `json:"patient_id,omitempty" db:"patient_id" valid:"required"`
+	StartsAt     time.Time     `json:"starts_at,omitempty" db:"starts_at" valid:"-"`
+	Frequency    string        `json:"frequency,omitempty" db:"frequency" valid:"required,in(daily|weekly|monthly)"`
+	Interval     int64         `json:"interval_count,omitempty" db:"interval_count" valid:"-"`
+	Count        int64         `json:"count,omitempty" db:"count" valid:"-"`
+	Until        *time.Time    `json:"repeat_until,omitempty" db:"repeat_until" valid:"-"`
+	ByDay        string        `json:"by_day,omitempty" db:"by_day" valid:"-"`
+	Exdates      string        `json:"exdates,omitempty" db:"exdates" valid:"-"`
+	CreatedAt    time.Time     `json:"created_at,omitempty" db:"created_at" valid:"-"`
+	UpdatedAt    time.Time     `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
+	Appointments []Appointment `json:"appointments,omitempty" db:"appointments" valid:"-"`
+	original     map[string]interface{}
+	savedChanges map[string]Change
+}
+
+// attributes returns the values of the changeable columns of the AppointmentSeries object by column names.
+func (_appointmentSeries *AppointmentSeries) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"physician_id":   _appointmentSeries.PhysicianId,
+		"patient_id":     _appointmentSeries.PatientId,
+		"starts_at":      _appointmentSeries.StartsAt,
+		"frequency":      _appointmentSeries.Frequency,
+		"interval_count": _appointmentSeries.Interval,
+		"count":          _appointmentSeries.Count,
+		"repeat_until":   nullableValue(_appointmentSeries.Until),
+		"by_day":         _appointmentSeries.ByDay,
+		"exdates":        _appointmentSeries.Exdates,
+	}
+}
+
+// snapshot keeps the current attributes of the AppointmentSeries object as the original ones for the dirty tracking.
+func (_appointmentSeries *AppointmentSeries) snapshot() {
+	_appointmentSeries.original = _appointmentSeries.attributes()
+}
+
+// snapshotAppointmentSeriesList keeps the current attributes of the loaded AppointmentSeries objects as the original ones.
+func snapshotAppointmentSeriesList(_appointmentSeriesList []AppointmentSeries) {
+	for i := range _appointmentSeriesList {
+		_appointmentSeriesList[i].snapshot()
+	}
+}
+
+// Changed reports whether any attribute of the AppointmentSeries object is changed since it was loaded
+// or saved. An object which is not loaded from the database is always changed.
+func (_appointmentSeries *AppointmentSeries) Changed() bool {
+	return len(_appointmentSeries.ChangedFields()) > 0
+}
+
+// ChangedFields returns the sorted column names of the changed attributes of the AppointmentSeries object.
+func (_appointmentSeries *AppointmentSeries) ChangedFields() []string {
+	return changedFields(_appointmentSeries.original, _appointmentSeries.attributes())
+}
+
+// Changes returns the old and new values of the changed attributes of the AppointmentSeries object by column names.
+func (_appointmentSeries *AppointmentSeries) Changes() map[string]Change {
+	return diffAttributes(_appointmentSeries.original, _appointmentSeries.attributes())
+}
+
+// SavedChanges returns the changes written by the last Create, Save, Update or UpdateAttributes
+// of the AppointmentSeries object, e.g. for an After callback to audit them.
+func (_appointmentSeries *AppointmentSeries) SavedChanges() map[string]Change {
+	return _appointmentSeries.savedChanges
+}
+
+// DataStruct for the pagination
+type AppointmentSeriesPage struct {
+	WhereString string
+	WhereParams []interface{}
+	Order       map[string]string
+	FirstId     int64
+	LastId      int64
+	PageNum     int
+	PerPage     int
+	TotalPages  int
+	TotalItems  int64
+	orderStr    string
+}
+
+// Current get the current page of AppointmentSeriesPage object for pagination.
+func (_p *AppointmentSeriesPage) Current() ([]AppointmentSeries, error) {
+	return _p.CurrentContext(context.Background())
+}
+
+// CurrentContext is same as Current but with the context ctx.
+func (_p *AppointmentSeriesPage) CurrentContext(ctx context.Context) ([]AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesPage.Current", "appointment_series")
+	defer span.End()
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("AppointmentSeriesPage.Current error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("current")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	appointmentSeriesList, err := FindAppointmentSeriesListWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(appointmentSeriesList) != 0 {
+		_p.FirstId, _p.LastId = appointmentSeriesList[0].Id, appointmentSeriesList[len(appointmentSeriesList)-1].Id
+	}
+	return appointmentSeriesList, nil
+}
+
+// Previous get the previous page of AppointmentSeriesPage object for pagination.
+func (_p *AppointmentSeriesPage) Previous() ([]AppointmentSeries, error) {
+	return _p.PreviousContext(context.Background())
+}
+
+// PreviousContext is same as Previous but with the context ctx.
+func (_p *AppointmentSeriesPage) PreviousContext(ctx context.Context) ([]AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesPage.Previous", "appointment_series")
+	defer span.End()
+	if _p.PageNum == 0 {
+		return nil, fmt.Errorf("AppointmentSeriesPage.Previous error: %w", ErrFirstPage)
+	}
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("AppointmentSeriesPage.Previous error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("previous")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	appointmentSeriesList, err := FindAppointmentSeriesListWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(appointmentSeriesList) != 0 {
+		_p.FirstId, _p.LastId = appointmentSeriesList[0].Id, appointmentSeriesList[len(appointmentSeriesList)-1].Id
+	}
+	_p.PageNum -= 1
+	return appointmentSeriesList, nil
+}
+
+// Next get the next page of AppointmentSeriesPage object for pagination.
+func (_p *AppointmentSeriesPage) Next() ([]AppointmentSeries, error) {
+	return _p.NextContext(context.Background())
+}
+
+// NextContext is same as Next but with the context ctx.
+func (_p *AppointmentSeriesPage) NextContext(ctx context.Context) ([]AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesPage.Next", "appointment_series")
+	defer span.End()
+	if _p.PageNum == _p.TotalPages-1 {
+		return nil, fmt.Errorf("AppointmentSeriesPage.Next error: %w", ErrLastPage)
+	}
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("AppointmentSeriesPage.Next error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("next")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	appointmentSeriesList, err := FindAppointmentSeriesListWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(appointmentSeriesList) != 0 {
+		_p.FirstId, _p.LastId = appointmentSeriesList[0].Id, appointmentSeriesList[len(appointmentSeriesList)-1].Id
+	}
+	_p.PageNum += 1
+	return appointmentSeriesList, nil
+}
+
+// GetPage is a helper function for the AppointmentSeriesPage object to return a corresponding page due to
+// the parameter passed in, i.e. one of "previous, current or next".
+func (_p *AppointmentSeriesPage) GetPage(direction string) (ps []AppointmentSeries, err error) {
+	return _p.GetPageContext(context.Background(), direction)
+}
+
+// GetPageContext is same as GetPage but with the context ctx.
+func (_p *AppointmentSeriesPage) GetPageContext(ctx context.Context, direction string) (ps []AppointmentSeries, err error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesPage.GetPage", "appointment_series")
+	defer span.End()
+	switch direction {
+	case "previous":
+		ps, err = _p.PreviousContext(ctx)
+	case "next":
+		ps, err = _p.NextContext(ctx)
+	case "current":
+		ps, err = _p.CurrentContext(ctx)
+	default:
+		return nil, fmt.Errorf("AppointmentSeriesPage.GetPage error: %w: %q", ErrWrongDirection, direction)
+	}
+	return
+}
+
+// buildOrder is for AppointmentSeriesPage object to build a SQL ORDER BY clause.
+func (_p *AppointmentSeriesPage) buildOrder() {
+	tempList := []string{}
+	for k, v := range _p.Order {
+		tempList = append(tempList, fmt.Sprintf("%v %v", k, v))
+	}
+	_p.orderStr = " ORDER BY " + strings.Join(tempList, ", ")
+}
+
+// buildIdRestrict is for AppointmentSeriesPage object to build a SQL clause for ID restriction,
+// implementing a simple keyset style pagination.
+func (_p *AppointmentSeriesPage) buildIdRestrict(direction string) (idStr string, idParams []interface{}) {
+	switch direction {
+	case "previous":
+		if strings.ToLower(_p.Order["id"]) == "desc" {
+			idStr += "id > ? "
+			idParams = append(idParams, _p.FirstId)
+		} else {
+			idStr += "id < ? "
+			idParams = append(idParams, _p.FirstId)
+		}
+	case "current":
+		// trick to make Where function work
+		if _p.PageNum == 0 && _p.FirstId == 0 && _p.LastId == 0 {
+			idStr += "id > ? "
+			idParams = append(idParams, 0)
+		} else {
+			if strings.ToLower(_p.Order["id"]) == "desc" {
+				idStr += "id <= ? AND id >= ? "
+				idParams = append(idParams, _p.FirstId, _p.LastId)
+			} else {
+				idStr += "id >= ? AND id <= ? "
+				idParams = append(idParams, _p.FirstId, _p.LastId)
+			}
+		}
+	case "next":
+		if strings.ToLower(_p.Order["id"]) == "desc" {
+			idStr += "id < ? "
+			idParams = append(idParams, _p.LastId)
+		} else {
+			idStr += "id > ? "
+			idParams = append(idParams, _p.LastId)
+		}
+	}
+	if _p.WhereString != "" {
+		idStr = " AND " + idStr
+	}
+	return
+}
+
+// buildPageCount calculate the TotalItems/TotalPages for the AppointmentSeriesPage object.
+func (_p *AppointmentSeriesPage) buildPageCount(ctx context.Context) error {
+	count, err := AppointmentSeriesCountWhereContext(ctx, _p.WhereString, _p.WhereParams...)
+	if err != nil {
+		return err
+	}
+	_p.TotalItems = count
+	if _p.PerPage == 0 {
+		_p.PerPage = 10
+	}
+	_p.TotalPages = int(math.Ceil(float64(_p.TotalItems) / float64(_p.PerPage)))
+	return nil
+}
+
+// FindAppointmentSeries find a single appointment series by an ID.
+func FindAppointmentSeries(id int64) (*AppointmentSeries, error) {
+	return FindAppointmentSeriesContext(context.Background(), id)
+}
+
+// FindAppointmentSeriesContext is same as FindAppointmentSeries but with the context ctx.
+func FindAppointmentSeriesContext(ctx context.Context, id int64) (*AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeries", "appointment_series")
+	defer span.End()
+	if id == 0 {
+		return nil, fmt.Errorf("FindAppointmentSeries error: %w", ErrInvalidID)
+	}
+	_appointmentSeries := AppointmentSeries{}
+	err := dbGet(ctx, DB, "AppointmentSeries", &_appointmentSeries, rebind(`SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series WHERE appointment_series.id = ? LIMIT 1`), id)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentSeries.snapshot()
+	return &_appointmentSeries, nil
+}
+
+// FirstAppointmentSeries find the first one appointment series by ID ASC order.
+func FirstAppointmentSeries() (*AppointmentSeries, error) {
+	return FirstAppointmentSeriesContext(context.Background())
+}
+
+// FirstAppointmentSeriesContext is same as FirstAppointmentSeries but with the context ctx.
+func FirstAppointmentSeriesContext(ctx context.Context) (*AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "FirstAppointmentSeries", "appointment_series")
+	defer span.End()
+	_appointmentSeries := AppointmentSeries{}
+	err := dbGet(ctx, DB, "AppointmentSeries", &_appointmentSeries, rebind(`SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series ORDER BY appointment_series.id ASC LIMIT 1`))
+	if err != nil {
+		return nil, err
+	}
+	_appointmentSeries.snapshot()
+	return &_appointmentSeries, nil
+}
+
+// FirstAppointmentSeriesList find the first N appointment series by ID ASC order.
+func FirstAppointmentSeriesList(n uint32) ([]AppointmentSeries, error) {
+	return FirstAppointmentSeriesListContext(context.Background(), n)
+}
+
+// FirstAppointmentSeriesListContext is same as FirstAppointmentSeriesList but with the context ctx.
+func FirstAppointmentSeriesListContext(ctx context.Context, n uint32) ([]AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "FirstAppointmentSeriesList", "appointment_series")
+	defer span.End()
+	_appointmentSeriesList := []AppointmentSeries{}
+	sql := fmt.Sprintf("SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series ORDER BY appointment_series.id ASC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "AppointmentSeries", &_appointmentSeriesList, rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentSeriesList(_appointmentSeriesList)
+	return _appointmentSeriesList, nil
+}
+
+// LastAppointmentSeries find the last one appointment series by ID DESC order.
+func LastAppointmentSeries() (*AppointmentSeries, error) {
+	return LastAppointmentSeriesContext(context.Background())
+}
+
+// LastAppointmentSeriesContext is same as LastAppointmentSeries but with the context ctx.
+func LastAppointmentSeriesContext(ctx context.Context) (*AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "LastAppointmentSeries", "appointment_series")
+	defer span.End()
+	_appointmentSeries := AppointmentSeries{}
+	err := dbGet(ctx, DB, "AppointmentSeries", &_appointmentSeries, rebind(`SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series ORDER BY appointment_series.id DESC LIMIT 1`))
+	if err != nil {
+		return nil, err
+	}
+	_appointmentSeries.snapshot()
+	return &_appointmentSeries, nil
+}
+
+// LastAppointmentSeriesList find the last N appointment series by ID DESC order.
+func LastAppointmentSeriesList(n uint32) ([]AppointmentSeries, error) {
+	return LastAppointmentSeriesListContext(context.Background(), n)
+}
+
+// LastAppointmentSeriesListContext is same as LastAppointmentSeriesList but with the context ctx.
+func LastAppointmentSeriesListContext(ctx context.Context, n uint32) ([]AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "LastAppointmentSeriesList", "appointment_series")
+	defer span.End()
+	_appointmentSeriesList := []AppointmentSeries{}
+	sql := fmt.Sprintf("SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series ORDER BY appointment_series.id DESC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "AppointmentSeries", &_appointmentSeriesList, rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentSeriesList(_appointmentSeriesList)
+	return _appointmentSeriesList, nil
+}
+
+// FindAppointmentSeriesList find one or more appointment series by the given ID(s).
+func FindAppointmentSeriesList(ids ...int64) ([]AppointmentSeries, error) {
+	return FindAppointmentSeriesListContext(context.Background(), ids...)
+}
+
+// FindAppointmentSeriesListContext is same as FindAppointmentSeriesList but with the context ctx.
+func FindAppointmentSeriesListContext(ctx context.Context, ids ...int64) ([]AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesList", "appointment_series")
+	defer span.End()
+	if len(ids) == 0 {
+		err := fmt.Errorf("FindAppointmentSeriesList error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "AppointmentSeries")
+		return nil, err
+	}
+	_appointmentSeriesList := []AppointmentSeries{}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	sql := rebind(fmt.Sprintf(`SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series WHERE appointment_series.id IN (?%s)`, idsHolder))
+	idsT := []interface{}{}
+	for _, id := range ids {
+		idsT = append(idsT, interface{}(id))
+	}
+	err := dbSelect(ctx, DB, "AppointmentSeries", &_appointmentSeriesList, sql, idsT...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentSeriesList(_appointmentSeriesList)
+	return _appointmentSeriesList, nil
+}
+
+// FindAppointmentSeriesBy find a single appointment series by a field name and a value.
+func FindAppointmentSeriesBy(field string, val interface{}) (*AppointmentSeries, error) {
+	return FindAppointmentSeriesByContext(context.Background(), field, val)
+}
+
+// FindAppointmentSeriesByContext is same as FindAppointmentSeriesBy but with the context ctx.
+func FindAppointmentSeriesByContext(ctx context.Context, field string, val interface{}) (*AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesBy", "appointment_series")
+	defer span.End()
+	_appointmentSeries := AppointmentSeries{}
+	sqlFmt := `SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series WHERE %s = ? LIMIT 1`
+	sqlStr := fmt.Sprintf(sqlFmt, field)
+	err := dbGet(ctx, DB, "AppointmentSeries", &_appointmentSeries, rebind(sqlStr), val)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentSeries.snapshot()
+	return &_appointmentSeries, nil
+}
+
+// FindAppointmentSeriesListBy find all appointment series by a field name and a value.
+func FindAppointmentSeriesListBy(field string, val interface{}) (_appointmentSeriesList []AppointmentSeries, err error) {
+	return FindAppointmentSeriesListByContext(context.Background(), field, val)
+}
+
+// FindAppointmentSeriesListByContext is same as FindAppointmentSeriesListBy but with the context ctx.
+func FindAppointmentSeriesListByContext(ctx context.Context, field string, val interface{}) (_appointmentSeriesList []AppointmentSeries, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesListBy", "appointment_series")
+	defer span.End()
+	sqlFmt := `SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series WHERE %s = ?`
+	sqlStr := fmt.Sprintf(sqlFmt, field)
+	err = dbSelect(ctx, DB, "AppointmentSeries", &_appointmentSeriesList, rebind(sqlStr), val)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentSeriesList(_appointmentSeriesList)
+	return _appointmentSeriesList, nil
+}
+
+// AllAppointmentSeriesList get all the AppointmentSeries records.
+func AllAppointmentSeriesList() (appointmentSeriesList []AppointmentSeries, err error) {
+	return AllAppointmentSeriesListContext(context.Background())
+}
+
+// AllAppointmentSeriesListContext is same as AllAppointmentSeriesList but with the context ctx.
+func AllAppointmentSeriesListContext(ctx context.Context) (appointmentSeriesList []AppointmentSeries, err error) {
+	ctx, span := startSpan(ctx, "AllAppointmentSeriesList", "appointment_series")
+	defer span.End()
+	err = dbSelect(ctx, DB, "AppointmentSeries", &appointmentSeriesList, "SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series")
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentSeriesList(appointmentSeriesList)
+	return appointmentSeriesList, nil
+}
+
+// AppointmentSeriesCount get the count of all the AppointmentSeries records.
+func AppointmentSeriesCount() (c int64, err error) {
+	return AppointmentSeriesCountContext(context.Background())
+}
+
+// AppointmentSeriesCountContext is same as AppointmentSeriesCount but with the context ctx.
+func AppointmentSeriesCountContext(ctx context.Context) (c int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesCount", "appointment_series")
+	defer span.End()
+	err = dbGet(ctx, DB, "AppointmentSeries", &c, "SELECT count(*) FROM appointment_series")
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+// AppointmentSeriesCountWhere get the count of all the AppointmentSeries records with a where clause.
+func AppointmentSeriesCountWhere(where string, args ...interface{}) (c int64, err error) {
+	return AppointmentSeriesCountWhereContext(context.Background(), where, args...)
+}
+
+// AppointmentSeriesCountWhereContext is same as AppointmentSeriesCountWhere but with the context ctx.
+func AppointmentSeriesCountWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesCountWhere", "appointment_series")
+	defer span.End()
+	sql := "SELECT count(*) FROM appointment_series"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	err = stmt.Get(ctx, &c, args...)
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+// AppointmentSeriesIncludesWhere get the AppointmentSeries associated models records, currently it's not same as the corresponding "includes" function but "preload" instead in Ruby on Rails. It means that the "sql" should be restricted on AppointmentSeries model.
+func AppointmentSeriesIncludesWhere(assocs []string, sql string, args ...interface{}) (_appointmentSeriesList []AppointmentSeries, err error) {
+	return AppointmentSeriesIncludesWhereContext(context.Background(), assocs, sql, args...)
+}
+
+// AppointmentSeriesIncludesWhereContext is same as AppointmentSeriesIncludesWhere but with the context ctx.
+func AppointmentSeriesIncludesWhereContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_appointmentSeriesList []AppointmentSeries, err error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesIncludesWhere", "appointment_series")
+	defer span.End()
+	return appointmentSeriesIncludesWhere(ctx, false, assocs, sql, args...)
+}
+
+// AppointmentSeriesIncludesWherePartial is same as AppointmentSeriesIncludesWhere but it returns the records with the associations loaded so far together with the errors of the ones failed to be loaded, joined by errors.Join, rather than failing with the first of them. A record keeps an association empty if it's failed to be loaded.
+func AppointmentSeriesIncludesWherePartial(assocs []string, sql string, args ...interface{}) (_appointmentSeriesList []AppointmentSeries, err error) {
+	return AppointmentSeriesIncludesWherePartialContext(context.Background(), assocs, sql, args...)
+}
+
+// AppointmentSeriesIncludesWherePartialContext is same as AppointmentSeriesIncludesWherePartial but with the context ctx.
+func AppointmentSeriesIncludesWherePartialContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_appointmentSeriesList []AppointmentSeries, err error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesIncludesWherePartial", "appointment_series")
+	defer span.End()
+	return appointmentSeriesIncludesWhere(ctx, true, assocs, sql, args...)
+}
+
+// appointmentSeriesIncludesWhere get the AppointmentSeries associated models records, it fails with the first error of loading an association unless partial, see AppointmentSeriesIncludesWherePartial.
+func appointmentSeriesIncludesWhere(ctx context.Context, partial bool, assocs []string, sql string, args ...interface{}) (_appointmentSeriesList []AppointmentSeries, err error) {
+	_appointmentSeriesList, err = FindAppointmentSeriesListWhereContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(assocs) == 0 {
+		logger().Debug("No associated fields are specified", "model", "AppointmentSeries")
+		return _appointmentSeriesList, err
+	}
+	if len(_appointmentSeriesList) <= 0 {
+		return nil, fmt.Errorf("AppointmentSeriesIncludesWhere error: %w", ErrNotFound)
+	}
+	ids := make([]interface{}, len(_appointmentSeriesList))
+	for _, v := range _appointmentSeriesList {
+		ids = append(ids, interface{}(v.Id))
+	}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	errs := &includesErrors{model: "AppointmentSeries", partial: partial}
+	for _, assoc := range assocs {
+		switch assoc {
+		case "appointments":
+			where := fmt.Sprintf("series_id IN (?%s)", idsHolder)
+			_appointments, err := FindAppointmentsWhereContext(ctx, where, ids...)
+			if err != nil {
+				if err = errs.add(assoc, err); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			for _, vv := range _appointments {
+				for i, vvv := range _appointmentSeriesList {
+					if vv.SeriesId != nil && *vv.SeriesId == vvv.Id {
+						vvv.Appointments = append(vvv.Appointments, vv)
+					}
+					_appointmentSeriesList[i].Appointments = vvv.Appointments
+				}
+			}
+		}
+	}
+	return _appointmentSeriesList, errs.err()
+}
+
+// AppointmentSeriesIds get all the IDs of AppointmentSeries records.
+func AppointmentSeriesIds() (ids []int64, err error) {
+	return AppointmentSeriesIdsContext(context.Background())
+}
+
+// AppointmentSeriesIdsContext is same as AppointmentSeriesIds but with the context ctx.
+func AppointmentSeriesIdsContext(ctx context.Context) (ids []int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesIds", "appointment_series")
+	defer span.End()
+	err = dbSelect(ctx, DB, "AppointmentSeries", &ids, "SELECT id FROM appointment_series")
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// AppointmentSeriesIdsWhere get all the IDs of AppointmentSeries records by where restriction.
+func AppointmentSeriesIdsWhere(where string, args ...interface{}) ([]int64, error) {
+	return AppointmentSeriesIdsWhereContext(context.Background(), where, args...)
+}
+
+// AppointmentSeriesIdsWhereContext is same as AppointmentSeriesIdsWhere but with the context ctx.
+func AppointmentSeriesIdsWhereContext(ctx context.Context, where string, args ...interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesIdsWhere", "appointment_series")
+	defer span.End()
+	ids, err := AppointmentSeriesIntColContext(ctx, "id", where, args...)
+	return ids, err
+}
+
+// AppointmentSeriesIntCol get some int64 typed column of AppointmentSeries by where restriction.
+func AppointmentSeriesIntCol(col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	return AppointmentSeriesIntColContext(context.Background(), col, where, args...)
+}
+
+// AppointmentSeriesIntColContext is same as AppointmentSeriesIntCol but with the context ctx.
+func AppointmentSeriesIntColContext(ctx context.Context, col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesIntCol", "appointment_series")
+	defer span.End()
+	sql := "SELECT " + col + " FROM appointment_series"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &intColRecs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return intColRecs, nil
+}
+
+// AppointmentSeriesStrCol get some string typed column of AppointmentSeries by where restriction.
+func AppointmentSeriesStrCol(col, where string, args ...interface{}) (strColRecs []string, err error) {
+	return AppointmentSeriesStrColContext(context.Background(), col, where, args...)
+}
+
+// AppointmentSeriesStrColContext is same as AppointmentSeriesStrCol but with the context ctx.
+func AppointmentSeriesStrColContext(ctx context.Context, col, where string, args ...interface{}) (strColRecs []string, err error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesStrCol", "appointment_series")
+	defer span.End()
+	sql := "SELECT " + col + " FROM appointment_series"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &strColRecs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return strColRecs, nil
+}
+
+// FindAppointmentSeriesListWhere query use a partial SQL clause that usually following after WHERE
+// with placeholders, eg: FindUsersWhere("first_name = ? AND age > ?", "John", 18)
+// will return those records in the table "users" whose first_name is "John" and age elder than 18.
+func FindAppointmentSeriesListWhere(where string, args ...interface{}) (appointmentSeriesList []AppointmentSeries, err error) {
+	return FindAppointmentSeriesListWhereContext(context.Background(), where, args...)
+}
+
+// FindAppointmentSeriesListWhereContext is same as FindAppointmentSeriesListWhere but with the context ctx.
+func FindAppointmentSeriesListWhereContext(ctx context.Context, where string, args ...interface{}) (appointmentSeriesList []AppointmentSeries, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesListWhere", "appointment_series")
+	defer span.End()
+	sql := "SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &appointmentSeriesList, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentSeriesList(appointmentSeriesList)
+	return appointmentSeriesList, nil
+}
+
+// FindAppointmentSeriesBySql query use a complete SQL clause
+// with placeholders, eg: FindUserBySql("SELECT * FROM users WHERE first_name = ? AND age > ? ORDER BY DESC LIMIT 1", "John", 18)
+// will return only One record in the table "users" whose first_name is "John" and age elder than 18.
+func FindAppointmentSeriesBySql(sql string, args ...interface{}) (*AppointmentSeries, error) {
+	return FindAppointmentSeriesBySqlContext(context.Background(), sql, args...)
+}
+
+// FindAppointmentSeriesBySqlContext is same as FindAppointmentSeriesBySql but with the context ctx.
+func FindAppointmentSeriesBySqlContext(ctx context.Context, sql string, args ...interface{}) (*AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesBySql", "appointment_series")
+	defer span.End()
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	_appointmentSeries := &AppointmentSeries{}
+	err = stmt.Get(ctx, _appointmentSeries, args...)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentSeries.snapshot()
+	return _appointmentSeries, nil
+}
+
+// FindAppointmentSeriesListBySql query use a complete SQL clause
+// with placeholders, eg: FindUsersBySql("SELECT * FROM users WHERE first_name = ? AND age > ?", "John", 18)
+// will return those records in the table "users" whose first_name is "John" and age elder than 18.
+func FindAppointmentSeriesListBySql(sql string, args ...interface{}) (appointmentSeriesList []AppointmentSeries, err error) {
+	return FindAppointmentSeriesListBySqlContext(context.Background(), sql, args...)
+}
+
+// FindAppointmentSeriesListBySqlContext is same as FindAppointmentSeriesListBySql but with the context ctx.
+func FindAppointmentSeriesListBySqlContext(ctx context.Context, sql string, args ...interface{}) (appointmentSeriesList []AppointmentSeries, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesListBySql", "appointment_series")
+	defer span.End()
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &appointmentSeriesList, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentSeriesList(appointmentSeriesList)
+	return appointmentSeriesList, nil
+}
+
+// FindAppointmentSeriesForUpdate find a single appointment series by an ID in the transaction tx and lock its row
+// FOR UPDATE until the transaction ends.
+func FindAppointmentSeriesForUpdate(tx *sqlx.Tx, id int64) (*AppointmentSeries, error) {
+	return FindAppointmentSeriesForUpdateContext(context.Background(), tx, id)
+}
+
+// FindAppointmentSeriesForUpdateContext is same as FindAppointmentSeriesForUpdate but with the context ctx.
+func FindAppointmentSeriesForUpdateContext(ctx context.Context, tx *sqlx.Tx, id int64) (*AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesForUpdate", "appointment_series")
+	defer span.End()
+	return findAppointmentSeriesLock(ctx, tx, ForUpdate, id)
+}
+
+// FindAppointmentSeriesForShare find a single appointment series by an ID in the transaction tx and lock its row
+// FOR SHARE until the transaction ends.
+func FindAppointmentSeriesForShare(tx *sqlx.Tx, id int64) (*AppointmentSeries, error) {
+	return FindAppointmentSeriesForShareContext(context.Background(), tx, id)
+}
+
+// FindAppointmentSeriesForShareContext is same as FindAppointmentSeriesForShare but with the context ctx.
+func FindAppointmentSeriesForShareContext(ctx context.Context, tx *sqlx.Tx, id int64) (*AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesForShare", "appointment_series")
+	defer span.End()
+	return findAppointmentSeriesLock(ctx, tx, ForShare, id)
+}
+
+// findAppointmentSeriesLock find a single appointment series by an ID in the transaction tx with a row lock mode.
+func findAppointmentSeriesLock(ctx context.Context, tx *sqlx.Tx, mode LockMode, id int64) (*AppointmentSeries, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("Lock AppointmentSeries error: %w", ErrInvalidID)
+	}
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentSeries := AppointmentSeries{}
+	err = dbGet(ctx, tx, "AppointmentSeries", &_appointmentSeries, tx.Rebind(`SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series WHERE appointment_series.id = ? LIMIT 1 `+lock), id)
+	if err != nil {
+		return nil, err
+	}
+	_appointmentSeries.snapshot()
+	return &_appointmentSeries, nil
+}
+
+// FindAppointmentSeriesListWhereLock is same as FindAppointmentSeriesListWhere but runs in the transaction tx and lock
+// the selected rows with the lock mode, e.g. ForUpdateSkipLocked, until the transaction ends.
+// The where clause should be a condition only, i.e. with no ORDER BY or LIMIT.
+func FindAppointmentSeriesListWhereLock(tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (appointmentSeriesList []AppointmentSeries, err error) {
+	return FindAppointmentSeriesListWhereLockContext(context.Background(), tx, mode, where, args...)
+}
+
+// FindAppointmentSeriesListWhereLockContext is same as FindAppointmentSeriesListWhereLock but with the context ctx.
+func FindAppointmentSeriesListWhereLockContext(ctx context.Context, tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (appointmentSeriesList []AppointmentSeries, err error) {
+	ctx, span := startSpan(ctx, "FindAppointmentSeriesListWhereLock", "appointment_series")
+	defer span.End()
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	sql := "SELECT COALESCE(appointment_series.physician_id, 0) AS physician_id, COALESCE(appointment_series.patient_id, 0) AS patient_id, appointment_series.starts_at, COALESCE(appointment_series.frequency, '') AS frequency, COALESCE(appointment_series.interval_count, 0) AS interval_count, COALESCE(appointment_series.count, 0) AS count, appointment_series.repeat_until, COALESCE(appointment_series.by_day, '') AS by_day, COALESCE(appointment_series.exdates, '') AS exdates, appointment_series.id, appointment_series.created_at, appointment_series.updated_at FROM appointment_series"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	err = dbSelect(ctx, tx, "AppointmentSeries", &appointmentSeriesList, tx.Rebind(sql+" "+lock), args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotAppointmentSeriesList(appointmentSeriesList)
+	return appointmentSeriesList, nil
+}
+
+// CreateAppointmentSeries use a named params to create a single AppointmentSeries record.
+// A named params is key-value map like map[string]interface{}{"first_name": "John", "age": 23} .
+func CreateAppointmentSeries(am map[string]interface{}) (int64, error) {
+	return CreateAppointmentSeriesContext(context.Background(), am)
+}
+
+// CreateAppointmentSeriesContext is same as CreateAppointmentSeries but with the context ctx.
+func CreateAppointmentSeriesContext(ctx context.Context, am map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointmentSeries", "appointment_series")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("CreateAppointmentSeries error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	keys := allKeys(am)
+	sqlFmt := `INSERT INTO appointment_series (%s) VALUES (%s)`
+	sql := fmt.Sprintf(sqlFmt, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
+	result, err := dbNamedExec(ctx, DB, "AppointmentSeries", sql, am)
+	if err != nil {
+		return 0, err
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		logger().Error("Get the last insert id error", "model", "AppointmentSeries", "error", err)
+		return 0, err
+	}
+	return lastId, nil
+}
+
+// CreateAppointmentSeriesList creates the AppointmentSeries records of the slice appointment series with multi-row INSERT statements,
+// see BulkInsertChunkSize. The structs are validated and time stamped, and their Id fields
+// are set to the ids of the created records, which are returned too. No callbacks are run.
+func CreateAppointmentSeriesList(appointmentSeriesList []AppointmentSeries) ([]int64, error) {
+	return CreateAppointmentSeriesListContext(context.Background(), appointmentSeriesList)
+}
+
+// CreateAppointmentSeriesListContext is same as CreateAppointmentSeriesList but with the context ctx.
+func CreateAppointmentSeriesListContext(ctx context.Context, appointmentSeriesList []AppointmentSeries) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointmentSeriesList", "appointment_series")
+	defer span.End()
+	t := time.Now()
+	rows := make([][]interface{}, len(appointmentSeriesList))
+	for i := range appointmentSeriesList {
+		_appointmentSeries := &appointmentSeriesList[i]
+		ok, err := govalidator.ValidateStruct(_appointmentSeries)
+		if !ok {
+			err = &ValidationError{Model: "AppointmentSeries", Index: i, Err: err}
+			logger().Warn(err.Error(), "model", "AppointmentSeries")
+			return nil, err
+		}
+		_appointmentSeries.CreatedAt = t
+		_appointmentSeries.UpdatedAt = t
+		rows[i] = []interface{}{_appointmentSeries.PhysicianId, _appointmentSeries.PatientId, _appointmentSeries.StartsAt, _appointmentSeries.Frequency, _appointmentSeries.Interval, _appointmentSeries.Count, _appointmentSeries.Until, _appointmentSeries.ByDay, _appointmentSeries.Exdates, _appointmentSeries.CreatedAt, _appointmentSeries.UpdatedAt}
+	}
+	ids, err := bulkInsert(ctx, "AppointmentSeries", "appointment_series", []string{"physician_id", "patient_id", "starts_at", "frequency", "interval_count", "count", "repeat_until", "by_day", "exdates", "created_at", "updated_at"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		appointmentSeriesList[i].Id = id
+		appointmentSeriesList[i].snapshot()
+	}
+	return ids, nil
+}
+
+// CreateAppointmentSeriesListMaps use a slice of named params to create AppointmentSeries records like CreateAppointmentSeries does,
+// but with multi-row INSERT statements, see BulkInsertChunkSize. A column missing in some
+// of the maps gets its default value. The ids of the created records are returned in order.
+func CreateAppointmentSeriesListMaps(ams []map[string]interface{}) ([]int64, error) {
+	return CreateAppointmentSeriesListMapsContext(context.Background(), ams)
+}
+
+// CreateAppointmentSeriesListMapsContext is same as CreateAppointmentSeriesListMaps but with the context ctx.
+func CreateAppointmentSeriesListMapsContext(ctx context.Context, ams []map[string]interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreateAppointmentSeriesListMaps", "appointment_series")
+	defer span.End()
+	for i, am := range ams {
+		if len(am) == 0 {
+			return nil, fmt.Errorf("CreateAppointmentSeriesListMaps error: %w at index %d", ErrEmptyAttributes, i)
+		}
+	}
+	keys, rows := bulkMapRows(ams)
+	return bulkInsert(ctx, "AppointmentSeries", "appointment_series", keys, rows)
+}
+
+// Create is a method for AppointmentSeries to create a record, the Id of the object is set to the new id.
+// It runs the BeforeValidate, BeforeCreate, AfterCreate and AfterCommit callbacks if implemented.
+func (_appointmentSeries *AppointmentSeries) Create() (int64, error) {
+	return _appointmentSeries.CreateContext(context.Background())
+}
+
+// CreateContext is same as Create but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) CreateContext(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Create", "appointment_series")
+	defer span.End()
+	if err := runBeforeValidate(_appointmentSeries); err != nil {
+		return 0, err
+	}
+	ok, err := govalidator.ValidateStruct(_appointmentSeries)
+	if !ok {
+		err = &ValidationError{Model: "AppointmentSeries", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "AppointmentSeries")
+		return 0, err
+	}
+	if err = runBeforeCreate(_appointmentSeries); err != nil {
+		return 0, err
+	}
+	t := time.Now()
+	_appointmentSeries.CreatedAt = t
+	_appointmentSeries.UpdatedAt = t
+	sql := `INSERT INTO appointment_series (physician_id,patient_id,starts_at,frequency,interval_count,count,repeat_until,by_day,exdates,created_at,updated_at) VALUES (:physician_id,:patient_id,:starts_at,:frequency,:interval_count,:count,:repeat_until,:by_day,:exdates,:created_at,:updated_at)`
+	result, err := dbNamedExec(ctx, DB, "AppointmentSeries", sql, _appointmentSeries)
+	if err != nil {
+		return 0, err
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		logger().Error("Get the last insert id error", "model", "AppointmentSeries", "error", err)
+		return 0, err
+	}
+	_appointmentSeries.Id = lastId
+	_appointmentSeries.savedChanges = diffAttributes(nil, _appointmentSeries.attributes())
+	_appointmentSeries.snapshot()
+	if err = runAfterCreate(ctx, _appointmentSeries); err != nil {
+		return lastId, err
+	}
+	return lastId, nil
+}
+
+// AppointmentsCreate is used for AppointmentSeries to create the associated objects Appointments
+func (_appointmentSeries *AppointmentSeries) AppointmentsCreate(am map[string]interface{}) error {
+	return _appointmentSeries.AppointmentsCreateContext(context.Background(), am)
+}
+
+// AppointmentsCreateContext is same as AppointmentsCreate but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) AppointmentsCreateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.AppointmentsCreate", "appointment_series")
+	defer span.End()
+	am["series_id"] = _appointmentSeries.Id
+	_, err := CreateAppointmentContext(ctx, am)
+	return err
+}
+
+// GetAppointments is used for AppointmentSeries to get associated objects Appointments
+// Say you have a AppointmentSeries object named appointmentSeries, when you call appointmentSeries.GetAppointments(),
+// the object will get the associated Appointments attributes evaluated in the struct.
+func (_appointmentSeries *AppointmentSeries) GetAppointments() error {
+	return _appointmentSeries.GetAppointmentsContext(context.Background())
+}
+
+// GetAppointmentsContext is same as GetAppointments but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) GetAppointmentsContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.GetAppointments", "appointment_series")
+	defer span.End()
+	_appointments, err := AppointmentSeriesGetAppointmentsContext(ctx, _appointmentSeries.Id)
+	if err == nil {
+		_appointmentSeries.Appointments = _appointments
+	}
+	return err
+}
+
+// AppointmentSeriesGetAppointments a helper fuction used to get associated objects for AppointmentSeriesIncludesWhere().
+func AppointmentSeriesGetAppointments(id int64) ([]Appointment, error) {
+	return AppointmentSeriesGetAppointmentsContext(context.Background(), id)
+}
+
+// AppointmentSeriesGetAppointmentsContext is same as AppointmentSeriesGetAppointments but with the context ctx.
+func AppointmentSeriesGetAppointmentsContext(ctx context.Context, id int64) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeriesGetAppointments", "appointment_series")
+	defer span.End()
+	_appointments, err := FindAppointmentsByContext(ctx, "series_id", id)
+	return _appointments, err
+}
+
+// Reload is a method for AppointmentSeries to reload the attributes of the object from the database.
+// The associations loaded into the object are reset.
+func (_appointmentSeries *AppointmentSeries) Reload() error {
+	return _appointmentSeries.ReloadContext(context.Background())
+}
+
+// ReloadContext is same as Reload but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) ReloadContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Reload", "appointment_series")
+	defer span.End()
+	if _appointmentSeries.Id == 0 {
+		return fmt.Errorf("AppointmentSeries.Reload error: %w", ErrInvalidID)
+	}
+	appointmentSeries, err := FindAppointmentSeriesContext(ctx, _appointmentSeries.Id)
+	if err != nil {
+		return err
+	}
+	*_appointmentSeries = *appointmentSeries
+	return nil
+}
+
+// reloadColumns reloads the columns of the AppointmentSeries object like Reload, but keeps its loaded associations.
+func (_appointmentSeries *AppointmentSeries) reloadColumns(ctx context.Context) error {
+	appointments := _appointmentSeries.Appointments
+	if err := _appointmentSeries.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_appointmentSeries.Appointments = appointments
+	return nil
+}
+
+// Destroy is method used for a AppointmentSeries object to be destroyed.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
+func (_appointmentSeries *AppointmentSeries) Destroy() error {
+	return _appointmentSeries.DestroyContext(context.Background())
+}
+
+// DestroyContext is same as Destroy but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) DestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Destroy", "appointment_series")
+	defer span.End()
+	if _appointmentSeries.Id == 0 {
+		return fmt.Errorf("AppointmentSeries.Destroy error: %w", ErrInvalidID)
+	}
+	if err := runBeforeDestroy(_appointmentSeries); err != nil {
+		return err
+	}
+	err := DestroyAppointmentSeriesContext(ctx, _appointmentSeries.Id)
+	if err != nil {
+		return err
+	}
+	return runAfterDestroy(ctx, _appointmentSeries)
+}
+
+// DestroyAppointmentSeries will destroy a AppointmentSeries record specified by the id parameter.
+func DestroyAppointmentSeries(id int64) error {
+	return DestroyAppointmentSeriesContext(context.Background(), id)
+}
+
+// DestroyAppointmentSeriesContext is same as DestroyAppointmentSeries but with the context ctx.
+func DestroyAppointmentSeriesContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DestroyAppointmentSeries", "appointment_series")
+	defer span.End()
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(`DELETE FROM appointment_series WHERE id = ?`))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(ctx, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DestroyAppointmentSeriesList will destroy AppointmentSeries records those specified by the ids parameters.
+func DestroyAppointmentSeriesList(ids ...int64) (int64, error) {
+	return DestroyAppointmentSeriesListContext(context.Background(), ids...)
+}
+
+// DestroyAppointmentSeriesListContext is same as DestroyAppointmentSeriesList but with the context ctx.
+func DestroyAppointmentSeriesListContext(ctx context.Context, ids ...int64) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyAppointmentSeriesList", "appointment_series")
+	defer span.End()
+	if len(ids) == 0 {
+		err := fmt.Errorf("DestroyAppointmentSeriesList error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "AppointmentSeries")
+		return 0, err
+	}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	sql := fmt.Sprintf(`DELETE FROM appointment_series WHERE id IN (?%s)`, idsHolder)
+	idsT := []interface{}{}
+	for _, id := range ids {
+		idsT = append(idsT, interface{}(id))
+	}
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, idsT...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// DestroyAppointmentSeriesListWhere delete records by a where clause restriction.
+// e.g. DestroyAppointmentSeriesListWhere("name = ?", "John")
+// And this func will not call the association dependent action
+func DestroyAppointmentSeriesListWhere(where string, args ...interface{}) (int64, error) {
+	return DestroyAppointmentSeriesListWhereContext(context.Background(), where, args...)
+}
+
+// DestroyAppointmentSeriesListWhereContext is same as DestroyAppointmentSeriesListWhere but with the context ctx.
+func DestroyAppointmentSeriesListWhereContext(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyAppointmentSeriesListWhere", "appointment_series")
+	defer span.End()
+	sql := `DELETE FROM appointment_series WHERE `
+	if len(where) > 0 {
+		sql = sql + where
+	} else {
+		return 0, fmt.Errorf("DestroyAppointmentSeriesListWhere error: %w", ErrNoWhere)
+	}
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// Save method is used for a AppointmentSeries object to update an existed record mainly.
+// If no id provided a new record will be created, and if the record of the id doesn't exist
+// it will be created with the id.
+// A loaded object only updates its changed columns, see ChangedFields.
+// The object is reloaded from the database after it's saved, its loaded associations are kept.
+// It runs the BeforeValidate, BeforeUpdate, AfterUpdate and AfterCommit callbacks if implemented.
+func (_appointmentSeries *AppointmentSeries) Save() error {
+	return _appointmentSeries.SaveContext(context.Background())
+}
+
+// SaveContext is same as Save but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) SaveContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Save", "appointment_series")
+	defer span.End()
+	if _appointmentSeries.Id == 0 {
+		_, err := _appointmentSeries.CreateContext(ctx)
+		return err
+	}
+	if err := runBeforeValidate(_appointmentSeries); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_appointmentSeries)
+	if !ok {
+		err = &ValidationError{Model: "AppointmentSeries", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "AppointmentSeries")
+		return err
+	}
+	if err = runBeforeUpdate(_appointmentSeries); err != nil {
+		return err
+	}
+	old := _appointmentSeries.original
+	if old != nil && !_appointmentSeries.Changed() {
+		// a loaded object without changes has nothing to write
+		_appointmentSeries.savedChanges = nil
+		return runAfterUpdate(ctx, _appointmentSeries)
+	}
+	_appointmentSeries.UpdatedAt = time.Now()
+	if old != nil {
+		// a loaded object only updates its changed columns
+		sqlFmt := `UPDATE appointment_series SET %s WHERE id = %v`
+		sqlStr := fmt.Sprintf(sqlFmt, namedSets(append(_appointmentSeries.ChangedFields(), "updated_at")), _appointmentSeries.Id)
+		_, err = dbNamedExec(ctx, DB, "AppointmentSeries", sqlStr, _appointmentSeries)
+	} else {
+		// an object not loaded updates all its columns, or it's created with the id if the record doesn't exist
+		if _appointmentSeries.CreatedAt.IsZero() {
+			_appointmentSeries.CreatedAt = _appointmentSeries.UpdatedAt
+		}
+		_, err = upsertWithId(ctx, "AppointmentSeries", "appointment_series", _appointmentSeries.Id, _appointmentSeries, []string{"id", "physician_id", "patient_id", "starts_at", "frequency", "interval_count", "count", "repeat_until", "by_day", "exdates", "created_at", "updated_at"}, []string{"physician_id", "patient_id", "starts_at", "frequency", "interval_count", "count", "repeat_until", "by_day", "exdates", "updated_at"})
+	}
+	if err != nil {
+		return err
+	}
+	if err = _appointmentSeries.reloadColumns(ctx); err != nil {
+		return err
+	}
+	_appointmentSeries.savedChanges = diffAttributes(old, _appointmentSeries.attributes())
+	return runAfterUpdate(ctx, _appointmentSeries)
+}
+
+// UpsertAppointmentSeries use a named params to create a single AppointmentSeries record, or to update the updateColumns
+// of the existed record instead if it conflicts with the params on the conflictColumns.
+// The id of the created or updated record is returned. With MySQL the conflict is detected
+// on any unique key of the table, the conflictColumns is only used by PostgreSQL and SQLite, so the
+// record conflicting on another unique key than the conflictColumns is updated and its id is returned.
+func UpsertAppointmentSeries(am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	return UpsertAppointmentSeriesContext(context.Background(), am, conflictColumns, updateColumns)
+}
+
+// UpsertAppointmentSeriesContext is same as UpsertAppointmentSeries but with the context ctx.
+func UpsertAppointmentSeriesContext(ctx context.Context, am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	ctx, span := startSpan(ctx, "UpsertAppointmentSeries", "appointment_series")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("UpsertAppointmentSeries error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	return upsert(ctx, "AppointmentSeries", "appointment_series", am, allKeys(am), conflictColumns, updateColumns)
+}
+
+// Upsert is a method for AppointmentSeries to create a record, or to update the updateColumns of the existed
+// record instead if it conflicts with the object on the conflictColumns, see UpsertAppointmentSeries.
+// The Id of the object is set to the id of the created or updated record.
+// It runs the BeforeValidate callback if implemented.
+func (_appointmentSeries *AppointmentSeries) Upsert(conflictColumns, updateColumns []string) error {
+	return _appointmentSeries.UpsertContext(context.Background(), conflictColumns, updateColumns)
+}
+
+// UpsertContext is same as Upsert but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) UpsertContext(ctx context.Context, conflictColumns, updateColumns []string) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Upsert", "appointment_series")
+	defer span.End()
+	if err := runBeforeValidate(_appointmentSeries); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_appointmentSeries)
+	if !ok {
+		err = &ValidationError{Model: "AppointmentSeries", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "AppointmentSeries")
+		return err
+	}
+	t := time.Now()
+	if _appointmentSeries.CreatedAt.IsZero() {
+		_appointmentSeries.CreatedAt = t
+	}
+	_appointmentSeries.UpdatedAt = t
+	keys := []string{"physician_id", "patient_id", "starts_at", "frequency", "interval_count", "count", "repeat_until", "by_day", "exdates", "created_at", "updated_at"}
+	if _appointmentSeries.Id != 0 {
+		keys = append([]string{"id"}, keys...)
+	}
+	id, err := upsert(ctx, "AppointmentSeries", "appointment_series", _appointmentSeries, keys, conflictColumns, updateColumns)
+	if err != nil {
+		return err
+	}
+	_appointmentSeries.Id = id
+	_appointmentSeries.snapshot()
+	return nil
+}
+
+// UpdateAppointmentSeries is used to update a record with a id and map[string]interface{} typed key-value parameters.
+func UpdateAppointmentSeries(id int64, am map[string]interface{}) error {
+	return UpdateAppointmentSeriesContext(context.Background(), id, am)
+}
+
+// UpdateAppointmentSeriesContext is same as UpdateAppointmentSeries but with the context ctx.
+func UpdateAppointmentSeriesContext(ctx context.Context, id int64, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "UpdateAppointmentSeries", "appointment_series")
+	defer span.End()
+	if len(am) == 0 {
+		return fmt.Errorf("UpdateAppointmentSeries error: %w", ErrEmptyAttributes)
+	}
+	am["updated_at"] = time.Now()
+	return updateAppointmentSeriesColumns(ctx, id, am)
+}
+
+// updateAppointmentSeriesColumns is used to update the columns of a record with a id as they are in the attributes map.
+func updateAppointmentSeriesColumns(ctx context.Context, id int64, am map[string]interface{}) error {
+	if len(am) == 0 {
+		return fmt.Errorf("AppointmentSeries.UpdateColumns error: %w", ErrEmptyAttributes)
+	}
+	keys := allKeys(am)
+	sqlFmt := `UPDATE appointment_series SET %s WHERE id = %v`
+	setKeysArr := []string{}
+	for _, v := range keys {
+		s := fmt.Sprintf(" %s = :%s", v, v)
+		setKeysArr = append(setKeysArr, s)
+	}
+	sqlStr := fmt.Sprintf(sqlFmt, strings.Join(setKeysArr, ", "), id)
+	_, err := dbNamedExec(ctx, DB, "AppointmentSeries", sqlStr, am)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update is a method used to update a AppointmentSeries record with the map[string]interface{} typed key-value parameters.
+// The object is reloaded from the database after it's updated, its loaded associations are kept.
+func (_appointmentSeries *AppointmentSeries) Update(am map[string]interface{}) error {
+	return _appointmentSeries.UpdateContext(context.Background(), am)
+}
+
+// UpdateContext is same as Update but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) UpdateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Update", "appointment_series")
+	defer span.End()
+	if _appointmentSeries.Id == 0 {
+		return fmt.Errorf("AppointmentSeries.Update error: %w", ErrInvalidID)
+	}
+	if err := runBeforeUpdate(_appointmentSeries); err != nil {
+		return err
+	}
+	old := _appointmentSeries.attributes()
+	err := UpdateAppointmentSeriesContext(ctx, _appointmentSeries.Id, am)
+	if err != nil {
+		return err
+	}
+	if err = _appointmentSeries.reloadColumns(ctx); err != nil {
+		return err
+	}
+	_appointmentSeries.savedChanges = diffAttributes(old, _appointmentSeries.attributes())
+	return runAfterUpdate(ctx, _appointmentSeries)
+}
+
+// UpdateAttributes method is supposed to be used to update AppointmentSeries records as corresponding update_attributes in Ruby on Rails.
+// The attributes are assigned to the object which is then saved, i.e. it's validated,
+// the callbacks are run and the updated_at is stamped, see Save.
+func (_appointmentSeries *AppointmentSeries) UpdateAttributes(am map[string]interface{}) error {
+	return _appointmentSeries.UpdateAttributesContext(context.Background(), am)
+}
+
+// UpdateAttributesContext is same as UpdateAttributes but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) UpdateAttributesContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.UpdateAttributes", "appointment_series")
+	defer span.End()
+	if _appointmentSeries.Id == 0 {
+		return fmt.Errorf("AppointmentSeries.UpdateAttributes error: %w", ErrInvalidID)
+	}
+	if err := assignColumns(_appointmentSeries, am); err != nil {
+		return err
+	}
+	return _appointmentSeries.SaveContext(ctx)
+}
+
+// UpdateColumns method is supposed to be used to update AppointmentSeries records as corresponding update_columns in Ruby on Rails.
+// The columns are written as they are, skipping the validation, the callbacks and the updated_at
+// stamp, and then assigned to the object. A value which can't be assigned fails before anything is written.
+func (_appointmentSeries *AppointmentSeries) UpdateColumns(am map[string]interface{}) error {
+	return _appointmentSeries.UpdateColumnsContext(context.Background(), am)
+}
+
+// UpdateColumnsContext is same as UpdateColumns but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) UpdateColumnsContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.UpdateColumns", "appointment_series")
+	defer span.End()
+	if _appointmentSeries.Id == 0 {
+		return fmt.Errorf("AppointmentSeries.UpdateColumns error: %w", ErrInvalidID)
+	}
+	assigned := *_appointmentSeries
+	if err := assignColumns(&assigned, am); err != nil {
+		return err
+	}
+	if err := updateAppointmentSeriesColumns(ctx, _appointmentSeries.Id, am); err != nil {
+		return err
+	}
+	*_appointmentSeries = assigned
+	markSaved(_appointmentSeries.original, _appointmentSeries.attributes(), allKeys(am))
+	return nil
+}
+
+// UpdateAppointmentSeriesListBySql is used to update AppointmentSeries records by a SQL clause
+// using the '?' binding syntax.
+func UpdateAppointmentSeriesListBySql(sql string, args ...interface{}) (int64, error) {
+	return UpdateAppointmentSeriesListBySqlContext(context.Background(), sql, args...)
+}
+
+// UpdateAppointmentSeriesListBySqlContext is same as UpdateAppointmentSeriesListBySql but with the context ctx.
+func UpdateAppointmentSeriesListBySqlContext(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "UpdateAppointmentSeriesListBySql", "appointment_series")
+	defer span.End()
+	if sql == "" {
+		return 0, fmt.Errorf("UpdateAppointmentSeriesListBySql error: %w", ErrBlankSQL)
+	}
+	stmt, err := prepare(ctx, "AppointmentSeries", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
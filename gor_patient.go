@@ -2,28 +2,77 @@
 package models
 
 import (
-	"errors"
+	"context"
+	"database/sql"
 	"fmt"
-	"log"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
 )
 
-// set flags to output more detailed log
-func init() {
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-}
-
 type Patient struct {
 	Id           int64         `json:"id,omitempty" db:"id" valid:"-"`
 	Name         string        `json:"name,omitempty" db:"name" valid:"-"`
 	CreatedAt    time.Time     `json:"created_at,omitempty" db:"created_at" valid:"-"`
 	UpdatedAt    time.Time     `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
+	DeletedAt    *time.Time    `json:"deleted_at,omitempty" db:"deleted_at" valid:"-"`
 	Appointments []Appointment `json:"appointments,omitempty" db:"appointments" valid:"-"`
 	Physicians   []Physician   `json:"physicians,omitempty" db:"physicians" valid:"-"`
+	original     map[string]interface{}
+	savedChanges map[string]Change
+}
+
+// Patient records are soft deleted: Destroy only sets the deleted_at column.
+// The scopes below are used as the FROM target of the finders to filter
+// the soft deleted records out, or in.
+const (
+	patientScope            = "(SELECT * FROM patients WHERE deleted_at IS NULL) AS patients"
+	patientWithDeletedScope = "patients"
+	patientOnlyDeletedScope = "(SELECT * FROM patients WHERE deleted_at IS NOT NULL) AS patients"
+)
+
+// attributes returns the values of the changeable columns of the Patient object by column names.
+func (_patient *Patient) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"name": _patient.Name,
+	}
+}
+
+// snapshot keeps the current attributes of the Patient object as the original ones for the dirty tracking.
+func (_patient *Patient) snapshot() {
+	_patient.original = _patient.attributes()
+}
+
+// snapshotPatients keeps the current attributes of the loaded Patient objects as the original ones.
+func snapshotPatients(_patients []Patient) {
+	for i := range _patients {
+		_patients[i].snapshot()
+	}
+}
+
+// Changed reports whether any attribute of the Patient object is changed since it was loaded
+// or saved. An object which is not loaded from the database is always changed.
+func (_patient *Patient) Changed() bool {
+	return len(_patient.ChangedFields()) > 0
+}
+
+// ChangedFields returns the sorted column names of the changed attributes of the Patient object.
+func (_patient *Patient) ChangedFields() []string {
+	return changedFields(_patient.original, _patient.attributes())
+}
+
+// Changes returns the old and new values of the changed attributes of the Patient object by column names.
+func (_patient *Patient) Changes() map[string]Change {
+	return diffAttributes(_patient.original, _patient.attributes())
+}
+
+// SavedChanges returns the changes written by the last Create, Save, Update or UpdateAttributes
+// of the Patient object, e.g. for an After callback to audit them.
+func (_patient *Patient) SavedChanges() map[string]Change {
+	return _patient.savedChanges
 }
 
 // DataStruct for the pagination
@@ -42,12 +91,19 @@ type PatientPage struct {
 
 // Current get the current page of PatientPage object for pagination.
 func (_p *PatientPage) Current() ([]Patient, error) {
+	return _p.CurrentContext(context.Background())
+}
+
+// CurrentContext is same as Current but with the context ctx.
+func (_p *PatientPage) CurrentContext(ctx context.Context) ([]Patient, error) {
+	ctx, span := startSpan(ctx, "PatientPage.Current", "patients")
+	defer span.End()
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PatientPage.Current error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -56,7 +112,7 @@ func (_p *PatientPage) Current() ([]Patient, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	patients, err := FindPatientsWhere(whereStr, whereParams...)
+	patients, err := FindPatientsWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,15 +124,22 @@ func (_p *PatientPage) Current() ([]Patient, error) {
 
 // Previous get the previous page of PatientPage object for pagination.
 func (_p *PatientPage) Previous() ([]Patient, error) {
+	return _p.PreviousContext(context.Background())
+}
+
+// PreviousContext is same as Previous but with the context ctx.
+func (_p *PatientPage) PreviousContext(ctx context.Context) ([]Patient, error) {
+	ctx, span := startSpan(ctx, "PatientPage.Previous", "patients")
+	defer span.End()
 	if _p.PageNum == 0 {
-		return nil, errors.New("This's the first page, no previous page yet")
+		return nil, fmt.Errorf("PatientPage.Previous error: %w", ErrFirstPage)
 	}
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PatientPage.Previous error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -85,7 +148,7 @@ func (_p *PatientPage) Previous() ([]Patient, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	patients, err := FindPatientsWhere(whereStr, whereParams...)
+	patients, err := FindPatientsWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,15 +161,22 @@ func (_p *PatientPage) Previous() ([]Patient, error) {
 
 // Next get the next page of PatientPage object for pagination.
 func (_p *PatientPage) Next() ([]Patient, error) {
+	return _p.NextContext(context.Background())
+}
+
+// NextContext is same as Next but with the context ctx.
+func (_p *PatientPage) NextContext(ctx context.Context) ([]Patient, error) {
+	ctx, span := startSpan(ctx, "PatientPage.Next", "patients")
+	defer span.End()
 	if _p.PageNum == _p.TotalPages-1 {
-		return nil, errors.New("This's the last page, no next page yet")
+		return nil, fmt.Errorf("PatientPage.Next error: %w", ErrLastPage)
 	}
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PatientPage.Next error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -115,7 +185,7 @@ func (_p *PatientPage) Next() ([]Patient, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	patients, err := FindPatientsWhere(whereStr, whereParams...)
+	patients, err := FindPatientsWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -129,15 +199,22 @@ func (_p *PatientPage) Next() ([]Patient, error) {
 // GetPage is a helper function for the PatientPage object to return a corresponding page due to
 // the parameter passed in, i.e. one of "previous, current or next".
 func (_p *PatientPage) GetPage(direction string) (ps []Patient, err error) {
+	return _p.GetPageContext(context.Background(), direction)
+}
+
+// GetPageContext is same as GetPage but with the context ctx.
+func (_p *PatientPage) GetPageContext(ctx context.Context, direction string) (ps []Patient, err error) {
+	ctx, span := startSpan(ctx, "PatientPage.GetPage", "patients")
+	defer span.End()
 	switch direction {
 	case "previous":
-		ps, _ = _p.Previous()
+		ps, err = _p.PreviousContext(ctx)
 	case "next":
-		ps, _ = _p.Next()
+		ps, err = _p.NextContext(ctx)
 	case "current":
-		ps, _ = _p.Current()
+		ps, err = _p.CurrentContext(ctx)
 	default:
-		return nil, errors.New("Error: wrong dircetion! None of previous, current or next!")
+		return nil, fmt.Errorf("PatientPage.GetPage error: %w: %q", ErrWrongDirection, direction)
 	}
 	return
 }
@@ -193,8 +270,8 @@ func (_p *PatientPage) buildIdRestrict(direction string) (idStr string, idParams
 }
 
 // buildPageCount calculate the TotalItems/TotalPages for the PatientPage object.
-func (_p *PatientPage) buildPageCount() error {
-	count, err := PatientCountWhere(_p.WhereString, _p.WhereParams...)
+func (_p *PatientPage) buildPageCount(ctx context.Context) error {
+	count, err := PatientCountWhereContext(ctx, _p.WhereString, _p.WhereParams...)
 	if err != nil {
 		return err
 	}
@@ -208,126 +285,195 @@ func (_p *PatientPage) buildPageCount() error {
 
 // FindPatient find a single patient by an ID.
 func FindPatient(id int64) (*Patient, error) {
+	return FindPatientContext(context.Background(), id)
+}
+
+// FindPatientContext is same as FindPatient but with the context ctx.
+func FindPatientContext(ctx context.Context, id int64) (*Patient, error) {
+	ctx, span := startSpan(ctx, "FindPatient", "patients")
+	defer span.End()
 	if id == 0 {
-		return nil, errors.New("Invalid ID: it can't be zero")
+		return nil, fmt.Errorf("FindPatient error: %w", ErrInvalidID)
 	}
 	_patient := Patient{}
-	err := DB.Get(&_patient, DB.Rebind(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients WHERE patients.id = ? LIMIT 1`), id)
+	err := dbGet(ctx, DB, "Patient", &_patient, rebind(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM `+patientScope+` WHERE patients.id = ? LIMIT 1`), id)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_patient.snapshot()
 	return &_patient, nil
 }
 
 // FirstPatient find the first one patient by ID ASC order.
 func FirstPatient() (*Patient, error) {
+	return FirstPatientContext(context.Background())
+}
+
+// FirstPatientContext is same as FirstPatient but with the context ctx.
+func FirstPatientContext(ctx context.Context) (*Patient, error) {
+	ctx, span := startSpan(ctx, "FirstPatient", "patients")
+	defer span.End()
 	_patient := Patient{}
-	err := DB.Get(&_patient, DB.Rebind(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients ORDER BY patients.id ASC LIMIT 1`))
+	err := dbGet(ctx, DB, "Patient", &_patient, rebind(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM `+patientScope+` ORDER BY patients.id ASC LIMIT 1`))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_patient.snapshot()
 	return &_patient, nil
 }
 
 // FirstPatients find the first N patients by ID ASC order.
 func FirstPatients(n uint32) ([]Patient, error) {
+	return FirstPatientsContext(context.Background(), n)
+}
+
+// FirstPatientsContext is same as FirstPatients but with the context ctx.
+func FirstPatientsContext(ctx context.Context, n uint32) ([]Patient, error) {
+	ctx, span := startSpan(ctx, "FirstPatients", "patients")
+	defer span.End()
 	_patients := []Patient{}
-	sql := fmt.Sprintf("SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients ORDER BY patients.id ASC LIMIT %v", n)
-	err := DB.Select(&_patients, DB.Rebind(sql))
+	sql := fmt.Sprintf("SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM "+patientScope+" ORDER BY patients.id ASC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "Patient", &_patients, rebind(sql))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPatients(_patients)
 	return _patients, nil
 }
 
 // LastPatient find the last one patient by ID DESC order.
 func LastPatient() (*Patient, error) {
+	return LastPatientContext(context.Background())
+}
+
+// LastPatientContext is same as LastPatient but with the context ctx.
+func LastPatientContext(ctx context.Context) (*Patient, error) {
+	ctx, span := startSpan(ctx, "LastPatient", "patients")
+	defer span.End()
 	_patient := Patient{}
-	err := DB.Get(&_patient, DB.Rebind(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients ORDER BY patients.id DESC LIMIT 1`))
+	err := dbGet(ctx, DB, "Patient", &_patient, rebind(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM `+patientScope+` ORDER BY patients.id DESC LIMIT 1`))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_patient.snapshot()
 	return &_patient, nil
 }
 
 // LastPatients find the last N patients by ID DESC order.
 func LastPatients(n uint32) ([]Patient, error) {
+	return LastPatientsContext(context.Background(), n)
+}
+
+// LastPatientsContext is same as LastPatients but with the context ctx.
+func LastPatientsContext(ctx context.Context, n uint32) ([]Patient, error) {
+	ctx, span := startSpan(ctx, "LastPatients", "patients")
+	defer span.End()
 	_patients := []Patient{}
-	sql := fmt.Sprintf("SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients ORDER BY patients.id DESC LIMIT %v", n)
-	err := DB.Select(&_patients, DB.Rebind(sql))
+	sql := fmt.Sprintf("SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM "+patientScope+" ORDER BY patients.id DESC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "Patient", &_patients, rebind(sql))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPatients(_patients)
 	return _patients, nil
 }
 
 // FindPatients find one or more patients by the given ID(s).
 func FindPatients(ids ...int64) ([]Patient, error) {
+	return FindPatientsContext(context.Background(), ids...)
+}
+
+// FindPatientsContext is same as FindPatients but with the context ctx.
+func FindPatientsContext(ctx context.Context, ids ...int64) ([]Patient, error) {
+	ctx, span := startSpan(ctx, "FindPatients", "patients")
+	defer span.End()
 	if len(ids) == 0 {
-		msg := "At least one or more ids needed"
-		log.Println(msg)
-		return nil, errors.New(msg)
+		err := fmt.Errorf("FindPatients error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Patient")
+		return nil, err
 	}
 	_patients := []Patient{}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
-	sql := DB.Rebind(fmt.Sprintf(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients WHERE patients.id IN (?%s)`, idsHolder))
+	sql := rebind(fmt.Sprintf(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM `+patientScope+` WHERE patients.id IN (?%s)`, idsHolder))
 	idsT := []interface{}{}
 	for _, id := range ids {
 		idsT = append(idsT, interface{}(id))
 	}
-	err := DB.Select(&_patients, sql, idsT...)
+	err := dbSelect(ctx, DB, "Patient", &_patients, sql, idsT...)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPatients(_patients)
 	return _patients, nil
 }
 
 // FindPatientBy find a single patient by a field name and a value.
 func FindPatientBy(field string, val interface{}) (*Patient, error) {
+	return FindPatientByContext(context.Background(), field, val)
+}
+
+// FindPatientByContext is same as FindPatientBy but with the context ctx.
+func FindPatientByContext(ctx context.Context, field string, val interface{}) (*Patient, error) {
+	ctx, span := startSpan(ctx, "FindPatientBy", "patients")
+	defer span.End()
 	_patient := Patient{}
-	sqlFmt := `SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients WHERE %s = ? LIMIT 1`
+	sqlFmt := `SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM ` + patientScope + ` WHERE %s = ? LIMIT 1`
 	sqlStr := fmt.Sprintf(sqlFmt, field)
-	err := DB.Get(&_patient, DB.Rebind(sqlStr), val)
+	err := dbGet(ctx, DB, "Patient", &_patient, rebind(sqlStr), val)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_patient.snapshot()
 	return &_patient, nil
 }
 
 // FindPatientsBy find all patients by a field name and a value.
 func FindPatientsBy(field string, val interface{}) (_patients []Patient, err error) {
-	sqlFmt := `SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients WHERE %s = ?`
+	return FindPatientsByContext(context.Background(), field, val)
+}
+
+// FindPatientsByContext is same as FindPatientsBy but with the context ctx.
+func FindPatientsByContext(ctx context.Context, field string, val interface{}) (_patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "FindPatientsBy", "patients")
+	defer span.End()
+	sqlFmt := `SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM ` + patientScope + ` WHERE %s = ?`
 	sqlStr := fmt.Sprintf(sqlFmt, field)
-	err = DB.Select(&_patients, DB.Rebind(sqlStr), val)
+	err = dbSelect(ctx, DB, "Patient", &_patients, rebind(sqlStr), val)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPatients(_patients)
 	return _patients, nil
 }
 
 // AllPatients get all the Patient records.
 func AllPatients() (patients []Patient, err error) {
-	err = DB.Select(&patients, "SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients")
+	return AllPatientsContext(context.Background())
+}
+
+// AllPatientsContext is same as AllPatients but with the context ctx.
+func AllPatientsContext(ctx context.Context) (patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "AllPatients", "patients")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Patient", &patients, "SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM "+patientScope)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPatients(patients)
 	return patients, nil
 }
 
 // PatientCount get the count of all the Patient records.
 func PatientCount() (c int64, err error) {
-	err = DB.Get(&c, "SELECT count(*) FROM patients")
+	return PatientCountContext(context.Background())
+}
+
+// PatientCountContext is same as PatientCount but with the context ctx.
+func PatientCountContext(ctx context.Context) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PatientCount", "patients")
+	defer span.End()
+	err = dbGet(ctx, DB, "Patient", &c, "SELECT count(*) FROM "+patientScope)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	return c, nil
@@ -335,18 +481,52 @@ func PatientCount() (c int64, err error) {
 
 // PatientCountWhere get the count of all the Patient records with a where clause.
 func PatientCountWhere(where string, args ...interface{}) (c int64, err error) {
-	sql := "SELECT count(*) FROM patients"
+	return PatientCountWhereContext(context.Background(), where, args...)
+}
+
+// PatientCountWhereContext is same as PatientCountWhere but with the context ctx.
+func PatientCountWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PatientCountWhere", "patients")
+	defer span.End()
+	return patientCountWhere(ctx, patientScope, where, args...)
+}
+
+// PatientCountWithDeletedWhere get the count of the Patient records with a where clause, soft deleted ones included.
+func PatientCountWithDeletedWhere(where string, args ...interface{}) (c int64, err error) {
+	return PatientCountWithDeletedWhereContext(context.Background(), where, args...)
+}
+
+// PatientCountWithDeletedWhereContext is same as PatientCountWithDeletedWhere but with the context ctx.
+func PatientCountWithDeletedWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PatientCountWithDeletedWhere", "patients")
+	defer span.End()
+	return patientCountWhere(ctx, patientWithDeletedScope, where, args...)
+}
+
+// PatientCountOnlyDeletedWhere get the count of the soft deleted Patient records with a where clause.
+func PatientCountOnlyDeletedWhere(where string, args ...interface{}) (c int64, err error) {
+	return PatientCountOnlyDeletedWhereContext(context.Background(), where, args...)
+}
+
+// PatientCountOnlyDeletedWhereContext is same as PatientCountOnlyDeletedWhere but with the context ctx.
+func PatientCountOnlyDeletedWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PatientCountOnlyDeletedWhere", "patients")
+	defer span.End()
+	return patientCountWhere(ctx, patientOnlyDeletedScope, where, args...)
+}
+
+// patientCountWhere get the count of the Patient records in a scope with a where clause.
+func patientCountWhere(ctx context.Context, scope, where string, args ...interface{}) (c int64, err error) {
+	sql := "SELECT count(*) FROM " + scope
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Patient", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
-	err = stmt.Get(&c, args...)
+	err = stmt.Get(ctx, &c, args...)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	return c, nil
@@ -354,35 +534,61 @@ func PatientCountWhere(where string, args ...interface{}) (c int64, err error) {
 
 // PatientIncludesWhere get the Patient associated models records, currently it's not same as the corresponding "includes" function but "preload" instead in Ruby on Rails. It means that the "sql" should be restricted on Patient model.
 func PatientIncludesWhere(assocs []string, sql string, args ...interface{}) (_patients []Patient, err error) {
-	_patients, err = FindPatientsWhere(sql, args...)
+	return PatientIncludesWhereContext(context.Background(), assocs, sql, args...)
+}
+
+// PatientIncludesWhereContext is same as PatientIncludesWhere but with the context ctx.
+func PatientIncludesWhereContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "PatientIncludesWhere", "patients")
+	defer span.End()
+	return patientIncludesWhere(ctx, false, assocs, sql, args...)
+}
+
+// PatientIncludesWherePartial is same as PatientIncludesWhere but it returns the records with the associations loaded so far together with the errors of the ones failed to be loaded, joined by errors.Join, rather than failing with the first of them. A record keeps an association empty if it's failed to be loaded.
+func PatientIncludesWherePartial(assocs []string, sql string, args ...interface{}) (_patients []Patient, err error) {
+	return PatientIncludesWherePartialContext(context.Background(), assocs, sql, args...)
+}
+
+// PatientIncludesWherePartialContext is same as PatientIncludesWherePartial but with the context ctx.
+func PatientIncludesWherePartialContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "PatientIncludesWherePartial", "patients")
+	defer span.End()
+	return patientIncludesWhere(ctx, true, assocs, sql, args...)
+}
+
+// patientIncludesWhere get the Patient associated models records, it fails with the first error of loading an association unless partial, see PatientIncludesWherePartial.
+func patientIncludesWhere(ctx context.Context, partial bool, assocs []string, sql string, args ...interface{}) (_patients []Patient, err error) {
+	_patients, err = FindPatientsWhereContext(ctx, sql, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	if len(assocs) == 0 {
-		log.Println("No associated fields ard specified")
+		logger().Debug("No associated fields are specified", "model", "Patient")
 		return _patients, err
 	}
 	if len(_patients) <= 0 {
-		return nil, errors.New("No results available")
+		return nil, fmt.Errorf("PatientIncludesWhere error: %w", ErrNotFound)
 	}
 	ids := make([]interface{}, len(_patients))
 	for _, v := range _patients {
 		ids = append(ids, interface{}(v.Id))
 	}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
+	errs := &includesErrors{model: "Patient", partial: partial}
 	for _, assoc := range assocs {
 		switch assoc {
 		case "appointments":
 			where := fmt.Sprintf("patient_id IN (?%s)", idsHolder)
-			_appointments, err := FindAppointmentsWhere(where, ids...)
+			_appointments, err := FindAppointmentsWhereContext(ctx, where, ids...)
 			if err != nil {
-				log.Printf("Error when query associated objects: %v\n", assoc)
+				if err = errs.add(assoc, err); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			for _, vv := range _appointments {
 				for i, vvv := range _patients {
-					if vv.PatientId == vvv.Id {
+					if vv.PatientId != nil && *vv.PatientId == vvv.Id {
 						vvv.Appointments = append(vvv.Appointments, vv)
 					}
 					_patients[i].Appointments = vvv.Appointments
@@ -391,8 +597,11 @@ func PatientIncludesWhere(assocs []string, sql string, args ...interface{}) (_pa
 		case "physicians":
 			// FIXME: optimize the query
 			for i, vvv := range _patients {
-				_physicians, err := PatientGetPhysicians(vvv.Id)
+				_physicians, err := PatientGetPhysiciansContext(ctx, vvv.Id)
 				if err != nil {
+					if err = errs.add(assoc, err); err != nil {
+						return nil, err
+					}
 					continue
 				}
 				vvv.Physicians = _physicians
@@ -400,14 +609,20 @@ func PatientIncludesWhere(assocs []string, sql string, args ...interface{}) (_pa
 			}
 		}
 	}
-	return _patients, nil
+	return _patients, errs.err()
 }
 
 // PatientIds get all the IDs of Patient records.
 func PatientIds() (ids []int64, err error) {
-	err = DB.Select(&ids, "SELECT id FROM patients")
+	return PatientIdsContext(context.Background())
+}
+
+// PatientIdsContext is same as PatientIds but with the context ctx.
+func PatientIdsContext(ctx context.Context) (ids []int64, err error) {
+	ctx, span := startSpan(ctx, "PatientIds", "patients")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Patient", &ids, "SELECT id FROM "+patientScope)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return ids, nil
@@ -415,24 +630,36 @@ func PatientIds() (ids []int64, err error) {
 
 // PatientIdsWhere get all the IDs of Patient records by where restriction.
 func PatientIdsWhere(where string, args ...interface{}) ([]int64, error) {
-	ids, err := PatientIntCol("id", where, args...)
+	return PatientIdsWhereContext(context.Background(), where, args...)
+}
+
+// PatientIdsWhereContext is same as PatientIdsWhere but with the context ctx.
+func PatientIdsWhereContext(ctx context.Context, where string, args ...interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "PatientIdsWhere", "patients")
+	defer span.End()
+	ids, err := PatientIntColContext(ctx, "id", where, args...)
 	return ids, err
 }
 
 // PatientIntCol get some int64 typed column of Patient by where restriction.
 func PatientIntCol(col, where string, args ...interface{}) (intColRecs []int64, err error) {
-	sql := "SELECT " + col + " FROM patients"
+	return PatientIntColContext(context.Background(), col, where, args...)
+}
+
+// PatientIntColContext is same as PatientIntCol but with the context ctx.
+func PatientIntColContext(ctx context.Context, col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	ctx, span := startSpan(ctx, "PatientIntCol", "patients")
+	defer span.End()
+	sql := "SELECT " + col + " FROM " + patientScope
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Patient", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&intColRecs, args...)
+	err = stmt.Select(ctx, &intColRecs, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return intColRecs, nil
@@ -440,18 +667,23 @@ func PatientIntCol(col, where string, args ...interface{}) (intColRecs []int64,
 
 // PatientStrCol get some string typed column of Patient by where restriction.
 func PatientStrCol(col, where string, args ...interface{}) (strColRecs []string, err error) {
-	sql := "SELECT " + col + " FROM patients"
+	return PatientStrColContext(context.Background(), col, where, args...)
+}
+
+// PatientStrColContext is same as PatientStrCol but with the context ctx.
+func PatientStrColContext(ctx context.Context, col, where string, args ...interface{}) (strColRecs []string, err error) {
+	ctx, span := startSpan(ctx, "PatientStrCol", "patients")
+	defer span.End()
+	sql := "SELECT " + col + " FROM " + patientScope
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Patient", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&strColRecs, args...)
+	err = stmt.Select(ctx, &strColRecs, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return strColRecs, nil
@@ -461,63 +693,190 @@ func PatientStrCol(col, where string, args ...interface{}) (strColRecs []string,
 // with placeholders, eg: FindUsersWhere("first_name = ? AND age > ?", "John", 18)
 // will return those records in the table "users" whose first_name is "John" and age elder than 18.
 func FindPatientsWhere(where string, args ...interface{}) (patients []Patient, err error) {
-	sql := "SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at FROM patients"
+	return FindPatientsWhereContext(context.Background(), where, args...)
+}
+
+// FindPatientsWhereContext is same as FindPatientsWhere but with the context ctx.
+func FindPatientsWhereContext(ctx context.Context, where string, args ...interface{}) (patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "FindPatientsWhere", "patients")
+	defer span.End()
+	return findPatientsWhere(ctx, patientScope, where, args...)
+}
+
+// FindPatientsWithDeletedWhere is same as FindPatientsWhere but the soft deleted records are included.
+func FindPatientsWithDeletedWhere(where string, args ...interface{}) (patients []Patient, err error) {
+	return FindPatientsWithDeletedWhereContext(context.Background(), where, args...)
+}
+
+// FindPatientsWithDeletedWhereContext is same as FindPatientsWithDeletedWhere but with the context ctx.
+func FindPatientsWithDeletedWhereContext(ctx context.Context, where string, args ...interface{}) (patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "FindPatientsWithDeletedWhere", "patients")
+	defer span.End()
+	return findPatientsWhere(ctx, patientWithDeletedScope, where, args...)
+}
+
+// FindPatientsOnlyDeletedWhere is same as FindPatientsWhere but only the soft deleted records are queried.
+func FindPatientsOnlyDeletedWhere(where string, args ...interface{}) (patients []Patient, err error) {
+	return FindPatientsOnlyDeletedWhereContext(context.Background(), where, args...)
+}
+
+// FindPatientsOnlyDeletedWhereContext is same as FindPatientsOnlyDeletedWhere but with the context ctx.
+func FindPatientsOnlyDeletedWhereContext(ctx context.Context, where string, args ...interface{}) (patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "FindPatientsOnlyDeletedWhere", "patients")
+	defer span.End()
+	return findPatientsWhere(ctx, patientOnlyDeletedScope, where, args...)
+}
+
+// findPatientsWhere query the Patient records in a scope with a partial SQL clause.
+func findPatientsWhere(ctx context.Context, scope, where string, args ...interface{}) (patients []Patient, err error) {
+	sql := "SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM " + scope
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Patient", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&patients, args...)
+	err = stmt.Select(ctx, &patients, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPatients(patients)
 	return patients, nil
 }
 
 // FindPatientBySql query use a complete SQL clause
 // with placeholders, eg: FindUserBySql("SELECT * FROM users WHERE first_name = ? AND age > ? ORDER BY DESC LIMIT 1", "John", 18)
 // will return only One record in the table "users" whose first_name is "John" and age elder than 18.
+// The soft deleted records are not filtered out of a complete SQL clause.
 func FindPatientBySql(sql string, args ...interface{}) (*Patient, error) {
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	return FindPatientBySqlContext(context.Background(), sql, args...)
+}
+
+// FindPatientBySqlContext is same as FindPatientBySql but with the context ctx.
+func FindPatientBySqlContext(ctx context.Context, sql string, args ...interface{}) (*Patient, error) {
+	ctx, span := startSpan(ctx, "FindPatientBySql", "patients")
+	defer span.End()
+	stmt, err := prepare(ctx, "Patient", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	_patient := &Patient{}
-	err = stmt.Get(_patient, args...)
+	err = stmt.Get(ctx, _patient, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	_patient.snapshot()
 	return _patient, nil
 }
 
 // FindPatientsBySql query use a complete SQL clause
 // with placeholders, eg: FindUsersBySql("SELECT * FROM users WHERE first_name = ? AND age > ?", "John", 18)
 // will return those records in the table "users" whose first_name is "John" and age elder than 18.
+// The soft deleted records are not filtered out of a complete SQL clause.
 func FindPatientsBySql(sql string, args ...interface{}) (patients []Patient, err error) {
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	return FindPatientsBySqlContext(context.Background(), sql, args...)
+}
+
+// FindPatientsBySqlContext is same as FindPatientsBySql but with the context ctx.
+func FindPatientsBySqlContext(ctx context.Context, sql string, args ...interface{}) (patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "FindPatientsBySql", "patients")
+	defer span.End()
+	stmt, err := prepare(ctx, "Patient", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &patients, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotPatients(patients)
+	return patients, nil
+}
+
+// FindPatientForUpdate find a single patient by an ID in the transaction tx and lock its row
+// FOR UPDATE until the transaction ends.
+func FindPatientForUpdate(tx *sqlx.Tx, id int64) (*Patient, error) {
+	return FindPatientForUpdateContext(context.Background(), tx, id)
+}
+
+// FindPatientForUpdateContext is same as FindPatientForUpdate but with the context ctx.
+func FindPatientForUpdateContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Patient, error) {
+	ctx, span := startSpan(ctx, "FindPatientForUpdate", "patients")
+	defer span.End()
+	return findPatientLock(ctx, tx, ForUpdate, id)
+}
+
+// FindPatientForShare find a single patient by an ID in the transaction tx and lock its row
+// FOR SHARE until the transaction ends.
+func FindPatientForShare(tx *sqlx.Tx, id int64) (*Patient, error) {
+	return FindPatientForShareContext(context.Background(), tx, id)
+}
+
+// FindPatientForShareContext is same as FindPatientForShare but with the context ctx.
+func FindPatientForShareContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Patient, error) {
+	ctx, span := startSpan(ctx, "FindPatientForShare", "patients")
+	defer span.End()
+	return findPatientLock(ctx, tx, ForShare, id)
+}
+
+// findPatientLock find a single patient by an ID in the transaction tx with a row lock mode.
+func findPatientLock(ctx context.Context, tx *sqlx.Tx, mode LockMode, id int64) (*Patient, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("Lock Patient error: %w", ErrInvalidID)
+	}
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	_patient := Patient{}
+	err = dbGet(ctx, tx, "Patient", &_patient, tx.Rebind(`SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM patients WHERE patients.id = ? AND patients.deleted_at IS NULL LIMIT 1 `+lock), id)
+	if err != nil {
+		return nil, err
+	}
+	_patient.snapshot()
+	return &_patient, nil
+}
+
+// FindPatientsWhereLock is same as FindPatientsWhere but runs in the transaction tx and lock
+// the selected rows with the lock mode, e.g. ForUpdateSkipLocked, until the transaction ends.
+// The where clause should be a condition only, i.e. with no ORDER BY or LIMIT.
+func FindPatientsWhereLock(tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (patients []Patient, err error) {
+	return FindPatientsWhereLockContext(context.Background(), tx, mode, where, args...)
+}
+
+// FindPatientsWhereLockContext is same as FindPatientsWhereLock but with the context ctx.
+func FindPatientsWhereLockContext(ctx context.Context, tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (patients []Patient, err error) {
+	ctx, span := startSpan(ctx, "FindPatientsWhereLock", "patients")
+	defer span.End()
+	lock, err := lockClause(tx, mode)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&patients, args...)
+	sql := "SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at FROM patients WHERE patients.deleted_at IS NULL"
+	if len(where) > 0 {
+		sql = sql + " AND (" + where + ")"
+	}
+	err = dbSelect(ctx, tx, "Patient", &patients, tx.Rebind(sql+" "+lock), args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPatients(patients)
 	return patients, nil
 }
 
 // CreatePatient use a named params to create a single Patient record.
 // A named params is key-value map like map[string]interface{}{"first_name": "John", "age": 23} .
 func CreatePatient(am map[string]interface{}) (int64, error) {
+	return CreatePatientContext(context.Background(), am)
+}
+
+// CreatePatientContext is same as CreatePatient but with the context ctx.
+func CreatePatientContext(ctx context.Context, am map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CreatePatient", "patients")
+	defer span.End()
 	if len(am) == 0 {
-		return 0, fmt.Errorf("Zero key in the attributes map!")
+		return 0, fmt.Errorf("CreatePatient error: %w", ErrEmptyAttributes)
 	}
 	t := time.Now()
 	for _, v := range []string{"created_at", "updated_at"} {
@@ -528,51 +887,129 @@ func CreatePatient(am map[string]interface{}) (int64, error) {
 	keys := allKeys(am)
 	sqlFmt := `INSERT INTO patients (%s) VALUES (%s)`
 	sql := fmt.Sprintf(sqlFmt, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
-	result, err := DB.NamedExec(sql, am)
+	result, err := dbNamedExec(ctx, DB, "Patient", sql, am)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		log.Println(err)
+		logger().Error("Get the last insert id error", "model", "Patient", "error", err)
 		return 0, err
 	}
 	return lastId, nil
 }
 
-// Create is a method for Patient to create a record.
+// CreatePatients creates the Patient records of the slice patients with multi-row INSERT statements,
+// see BulkInsertChunkSize. The structs are validated and time stamped, and their Id fields
+// are set to the ids of the created records, which are returned too. No callbacks are run.
+func CreatePatients(patients []Patient) ([]int64, error) {
+	return CreatePatientsContext(context.Background(), patients)
+}
+
+// CreatePatientsContext is same as CreatePatients but with the context ctx.
+func CreatePatientsContext(ctx context.Context, patients []Patient) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreatePatients", "patients")
+	defer span.End()
+	t := time.Now()
+	rows := make([][]interface{}, len(patients))
+	for i := range patients {
+		_patient := &patients[i]
+		ok, err := govalidator.ValidateStruct(_patient)
+		if !ok {
+			err = &ValidationError{Model: "Patient", Index: i, Err: err}
+			logger().Warn(err.Error(), "model", "Patient")
+			return nil, err
+		}
+		_patient.CreatedAt = t
+		_patient.UpdatedAt = t
+		rows[i] = []interface{}{_patient.Name, _patient.CreatedAt, _patient.UpdatedAt}
+	}
+	ids, err := bulkInsert(ctx, "Patient", "patients", []string{"name", "created_at", "updated_at"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		patients[i].Id = id
+		patients[i].snapshot()
+	}
+	return ids, nil
+}
+
+// CreatePatientsMaps use a slice of named params to create Patient records like CreatePatient does,
+// but with multi-row INSERT statements, see BulkInsertChunkSize. A column missing in some
+// of the maps gets its default value. The ids of the created records are returned in order.
+func CreatePatientsMaps(ams []map[string]interface{}) ([]int64, error) {
+	return CreatePatientsMapsContext(context.Background(), ams)
+}
+
+// CreatePatientsMapsContext is same as CreatePatientsMaps but with the context ctx.
+func CreatePatientsMapsContext(ctx context.Context, ams []map[string]interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreatePatientsMaps", "patients")
+	defer span.End()
+	for i, am := range ams {
+		if len(am) == 0 {
+			return nil, fmt.Errorf("CreatePatientsMaps error: %w at index %d", ErrEmptyAttributes, i)
+		}
+	}
+	keys, rows := bulkMapRows(ams)
+	return bulkInsert(ctx, "Patient", "patients", keys, rows)
+}
+
+// Create is a method for Patient to create a record, the Id of the object is set to the new id.
+// It runs the BeforeValidate, BeforeCreate, AfterCreate and AfterCommit callbacks if implemented.
 func (_patient *Patient) Create() (int64, error) {
+	return _patient.CreateContext(context.Background())
+}
+
+// CreateContext is same as Create but with the context ctx.
+func (_patient *Patient) CreateContext(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "Patient.Create", "patients")
+	defer span.End()
+	if err := runBeforeValidate(_patient); err != nil {
+		return 0, err
+	}
 	ok, err := govalidator.ValidateStruct(_patient)
 	if !ok {
-		errMsg := "Validate Patient struct error: Unknown error"
-		if err != nil {
-			errMsg = "Validate Patient struct error: " + err.Error()
-		}
-		log.Println(errMsg)
-		return 0, errors.New(errMsg)
+		err = &ValidationError{Model: "Patient", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Patient")
+		return 0, err
+	}
+	if err = runBeforeCreate(_patient); err != nil {
+		return 0, err
 	}
 	t := time.Now()
 	_patient.CreatedAt = t
 	_patient.UpdatedAt = t
 	sql := `INSERT INTO patients (name,created_at,updated_at) VALUES (:name,:created_at,:updated_at)`
-	result, err := DB.NamedExec(sql, _patient)
+	result, err := dbNamedExec(ctx, DB, "Patient", sql, _patient)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		log.Println(err)
+		logger().Error("Get the last insert id error", "model", "Patient", "error", err)
 		return 0, err
 	}
+	_patient.Id = lastId
+	_patient.savedChanges = diffAttributes(nil, _patient.attributes())
+	_patient.snapshot()
+	if err = runAfterCreate(ctx, _patient); err != nil {
+		return lastId, err
+	}
 	return lastId, nil
 }
 
 // AppointmentsCreate is used for Patient to create the associated objects Appointments
 func (_patient *Patient) AppointmentsCreate(am map[string]interface{}) error {
+	return _patient.AppointmentsCreateContext(context.Background(), am)
+}
+
+// AppointmentsCreateContext is same as AppointmentsCreate but with the context ctx.
+func (_patient *Patient) AppointmentsCreateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Patient.AppointmentsCreate", "patients")
+	defer span.End()
 	am["patient_id"] = _patient.Id
-	_, err := CreateAppointment(am)
+	_, err := CreateAppointmentContext(ctx, am)
 	return err
 }
 
@@ -580,7 +1017,14 @@ func (_patient *Patient) AppointmentsCreate(am map[string]interface{}) error {
 // Say you have a Patient object named patient, when you call patient.GetAppointments(),
 // the object will get the associated Appointments attributes evaluated in the struct.
 func (_patient *Patient) GetAppointments() error {
-	_appointments, err := PatientGetAppointments(_patient.Id)
+	return _patient.GetAppointmentsContext(context.Background())
+}
+
+// GetAppointmentsContext is same as GetAppointments but with the context ctx.
+func (_patient *Patient) GetAppointmentsContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Patient.GetAppointments", "patients")
+	defer span.End()
+	_appointments, err := PatientGetAppointmentsContext(ctx, _patient.Id)
 	if err == nil {
 		_patient.Appointments = _appointments
 	}
@@ -589,18 +1033,32 @@ func (_patient *Patient) GetAppointments() error {
 
 // PatientGetAppointments a helper fuction used to get associated objects for PatientIncludesWhere().
 func PatientGetAppointments(id int64) ([]Appointment, error) {
-	_appointments, err := FindAppointmentsBy("patient_id", id)
+	return PatientGetAppointmentsContext(context.Background(), id)
+}
+
+// PatientGetAppointmentsContext is same as PatientGetAppointments but with the context ctx.
+func PatientGetAppointmentsContext(ctx context.Context, id int64) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "PatientGetAppointments", "patients")
+	defer span.End()
+	_appointments, err := FindAppointmentsByContext(ctx, "patient_id", id)
 	return _appointments, err
 }
 
 // PhysiciansCreate is used for Patient to create the associated objects Physicians
 func (_patient *Patient) PhysiciansCreate(am map[string]interface{}) error {
+	return _patient.PhysiciansCreateContext(context.Background(), am)
+}
+
+// PhysiciansCreateContext is same as PhysiciansCreate but with the context ctx.
+func (_patient *Patient) PhysiciansCreateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Patient.PhysiciansCreate", "patients")
+	defer span.End()
 	// FIXME: use transaction to create these associated objects
-	physicianId, err := CreatePhysician(am)
+	physicianId, err := CreatePhysicianContext(ctx, am)
 	if err != nil {
 		return err
 	}
-	_, err = CreateAppointment(map[string]interface{}{"patient_id": _patient.Id, "physician_id": physicianId})
+	_, err = CreateAppointmentContext(ctx, map[string]interface{}{"patient_id": _patient.Id, "physician_id": physicianId})
 	return err
 }
 
@@ -608,7 +1066,14 @@ func (_patient *Patient) PhysiciansCreate(am map[string]interface{}) error {
 // Say you have a Patient object named patient, when you call patient.GetPhysicians(),
 // the object will get the associated Physicians attributes evaluated in the struct.
 func (_patient *Patient) GetPhysicians() error {
-	_physicians, err := PatientGetPhysicians(_patient.Id)
+	return _patient.GetPhysiciansContext(context.Background())
+}
+
+// GetPhysiciansContext is same as GetPhysicians but with the context ctx.
+func (_patient *Patient) GetPhysiciansContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Patient.GetPhysicians", "patients")
+	defer span.End()
+	_physicians, err := PatientGetPhysiciansContext(ctx, _patient.Id)
 	if err == nil {
 		_patient.Physicians = _physicians
 	}
@@ -617,50 +1082,200 @@ func (_patient *Patient) GetPhysicians() error {
 
 // PatientGetPhysicians a helper fuction used to get associated objects for PatientIncludesWhere().
 func PatientGetPhysicians(id int64) ([]Physician, error) {
+	return PatientGetPhysiciansContext(context.Background(), id)
+}
+
+// PatientGetPhysiciansContext is same as PatientGetPhysicians but with the context ctx.
+func PatientGetPhysiciansContext(ctx context.Context, id int64) ([]Physician, error) {
+	ctx, span := startSpan(ctx, "PatientGetPhysicians", "patients")
+	defer span.End()
 	// FIXME: use transaction to create these associated objects
-	sql := `SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at
+	sql := `SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at
 		        FROM   physicians
 		               INNER JOIN appointments
 		                       ON physicians.id = appointments.physician_id
-		        WHERE appointments.patient_id = ?`
-	_physicians, err := FindPhysiciansBySql(sql, id)
+		        WHERE appointments.patient_id = ? AND appointments.deleted_at IS NULL`
+	_physicians, err := FindPhysiciansBySqlContext(ctx, sql, id)
 	return _physicians, err
 }
 
+// Reload is a method for Patient to reload the attributes of the object from the database,
+// soft deleted or not. The associations loaded into the object are reset.
+func (_patient *Patient) Reload() error {
+	return _patient.ReloadContext(context.Background())
+}
+
+// ReloadContext is same as Reload but with the context ctx.
+func (_patient *Patient) ReloadContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Patient.Reload", "patients")
+	defer span.End()
+	if _patient.Id == 0 {
+		return fmt.Errorf("Patient.Reload error: %w", ErrInvalidID)
+	}
+	patients, err := FindPatientsWithDeletedWhereContext(ctx, "id = ?", _patient.Id)
+	if err != nil {
+		return err
+	}
+	if len(patients) == 0 {
+		return notFound("Patient", sql.ErrNoRows)
+	}
+	*_patient = patients[0]
+	return nil
+}
+
+// reloadColumns reloads the columns of the Patient object like Reload, but keeps its loaded associations.
+func (_patient *Patient) reloadColumns(ctx context.Context) error {
+	appointments, physicians := _patient.Appointments, _patient.Physicians
+	if err := _patient.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_patient.Appointments, _patient.Physicians = appointments, physicians
+	return nil
+}
+
 // Destroy is method used for a Patient object to be destroyed.
+// The record is soft deleted, see HardDestroy for removing it physically. ErrNotFound is returned
+// without running the AfterDestroy callbacks if it doesn't exist or it's soft deleted already.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
 func (_patient *Patient) Destroy() error {
+	return _patient.DestroyContext(context.Background())
+}
+
+// DestroyContext is same as Destroy but with the context ctx.
+func (_patient *Patient) DestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Patient.Destroy", "patients")
+	defer span.End()
 	if _patient.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Patient.Destroy error: %w", ErrInvalidID)
 	}
-	err := DestroyPatient(_patient.Id)
-	return err
+	if err := runBeforeDestroy(_patient); err != nil {
+		return err
+	}
+	t := time.Now()
+	cnt, err := softDestroyPatients(ctx, t, "id = ?", _patient.Id)
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return fmt.Errorf("Patient.Destroy error: %w", ErrNotFound)
+	}
+	_patient.DeletedAt = &t
+	return runAfterDestroy(ctx, _patient)
+}
+
+// HardDestroy is method used for a Patient object to be deleted from the database.
+// ErrNotFound is returned without running the AfterDestroy callbacks if the record doesn't exist.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
+func (_patient *Patient) HardDestroy() error {
+	return _patient.HardDestroyContext(context.Background())
 }
 
-// DestroyPatient will destroy a Patient record specified by the id parameter.
+// HardDestroyContext is same as HardDestroy but with the context ctx.
+func (_patient *Patient) HardDestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Patient.HardDestroy", "patients")
+	defer span.End()
+	if _patient.Id == 0 {
+		return fmt.Errorf("Patient.HardDestroy error: %w", ErrInvalidID)
+	}
+	if err := runBeforeDestroy(_patient); err != nil {
+		return err
+	}
+	err := HardDestroyPatientContext(ctx, _patient.Id)
+	if err != nil {
+		return err
+	}
+	return runAfterDestroy(ctx, _patient)
+}
+
+// Restore is method used for a soft deleted Patient object to be restored.
+// ErrNotFound is returned if there's no soft deleted record of its id.
+func (_patient *Patient) Restore() error {
+	return _patient.RestoreContext(context.Background())
+}
+
+// RestoreContext is same as Restore but with the context ctx.
+func (_patient *Patient) RestoreContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Patient.Restore", "patients")
+	defer span.End()
+	if _patient.Id == 0 {
+		return fmt.Errorf("Patient.Restore error: %w", ErrInvalidID)
+	}
+	err := RestorePatientContext(ctx, _patient.Id)
+	if err != nil {
+		return err
+	}
+	_patient.DeletedAt = nil
+	return nil
+}
+
+// DestroyPatient will soft delete a Patient record specified by the id parameter.
+// ErrNotFound is returned if the record doesn't exist or it's soft deleted already.
 func DestroyPatient(id int64) error {
-	stmt, err := DB.Preparex(DB.Rebind(`DELETE FROM patients WHERE id = ?`))
-	_, err = stmt.Exec(id)
+	return DestroyPatientContext(context.Background(), id)
+}
+
+// DestroyPatientContext is same as DestroyPatient but with the context ctx.
+func DestroyPatientContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DestroyPatient", "patients")
+	defer span.End()
+	cnt, err := softDestroyPatients(ctx, time.Now(), "id = ?", id)
 	if err != nil {
 		return err
 	}
+	if cnt == 0 {
+		return fmt.Errorf("DestroyPatient error: %w", ErrNotFound)
+	}
 	return nil
 }
 
-// DestroyPatients will destroy Patient records those specified by the ids parameters.
+// DestroyPatients will soft delete Patient records those specified by the ids parameters.
 func DestroyPatients(ids ...int64) (int64, error) {
+	return DestroyPatientsContext(context.Background(), ids...)
+}
+
+// DestroyPatientsContext is same as DestroyPatients but with the context ctx.
+func DestroyPatientsContext(ctx context.Context, ids ...int64) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyPatients", "patients")
+	defer span.End()
 	if len(ids) == 0 {
-		msg := "At least one or more ids needed"
-		log.Println(msg)
-		return 0, errors.New(msg)
+		err := fmt.Errorf("DestroyPatients error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Patient")
+		return 0, err
 	}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
-	sql := fmt.Sprintf(`DELETE FROM patients WHERE id IN (?%s)`, idsHolder)
 	idsT := []interface{}{}
 	for _, id := range ids {
 		idsT = append(idsT, interface{}(id))
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(idsT...)
+	return softDestroyPatients(ctx, time.Now(), fmt.Sprintf("id IN (?%s)", idsHolder), idsT...)
+}
+
+// DestroyPatientsWhere soft delete records by a where clause restriction.
+// e.g. DestroyPatientsWhere("name = ?", "John")
+// And this func will not call the association dependent action
+func DestroyPatientsWhere(where string, args ...interface{}) (int64, error) {
+	return DestroyPatientsWhereContext(context.Background(), where, args...)
+}
+
+// DestroyPatientsWhereContext is same as DestroyPatientsWhere but with the context ctx.
+func DestroyPatientsWhereContext(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyPatientsWhere", "patients")
+	defer span.End()
+	if len(where) == 0 {
+		return 0, fmt.Errorf("DestroyPatientsWhere error: %w", ErrNoWhere)
+	}
+	return softDestroyPatients(ctx, time.Now(), where, args...)
+}
+
+// softDestroyPatients set the deleted_at column to t for the not yet deleted Patient records
+// matching the where clause.
+func softDestroyPatients(ctx context.Context, t time.Time, where string, args ...interface{}) (int64, error) {
+	sql := "UPDATE patients SET deleted_at = ? WHERE deleted_at IS NULL AND (" + where + ")"
+	stmt, err := prepare(ctx, "Patient", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, append([]interface{}{t}, args...)...)
 	if err != nil {
 		return 0, err
 	}
@@ -671,57 +1286,206 @@ func DestroyPatients(ids ...int64) (int64, error) {
 	return cnt, nil
 }
 
-// DestroyPatientsWhere delete records by a where clause restriction.
-// e.g. DestroyPatientsWhere("name = ?", "John")
-// And this func will not call the association dependent action
-func DestroyPatientsWhere(where string, args ...interface{}) (int64, error) {
-	sql := `DELETE FROM patients WHERE `
-	if len(where) > 0 {
-		sql = sql + where
-	} else {
-		return 0, errors.New("No WHERE conditions provided")
+// RestorePatient will restore a soft deleted Patient record specified by the id parameter.
+// ErrNotFound is returned if there's no soft deleted record of the id.
+func RestorePatient(id int64) error {
+	return RestorePatientContext(context.Background(), id)
+}
+
+// RestorePatientContext is same as RestorePatient but with the context ctx.
+func RestorePatientContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "RestorePatient", "patients")
+	defer span.End()
+	stmt, err := prepare(ctx, "Patient", rebind(`UPDATE patients SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`))
+	if err != nil {
+		return err
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(args...)
+	result, err := stmt.Exec(ctx, id)
 	if err != nil {
-		return 0, err
+		return err
 	}
 	cnt, err := result.RowsAffected()
 	if err != nil {
-		return 0, err
+		return err
 	}
-	return cnt, nil
+	if cnt == 0 {
+		return fmt.Errorf("RestorePatient error: %w", ErrNotFound)
+	}
+	return nil
+}
+
+// HardDestroyPatient will delete a Patient record specified by the id parameter from the database,
+// no matter it's soft deleted or not. ErrNotFound is returned if the record doesn't exist.
+func HardDestroyPatient(id int64) error {
+	return HardDestroyPatientContext(context.Background(), id)
+}
+
+// HardDestroyPatientContext is same as HardDestroyPatient but with the context ctx.
+func HardDestroyPatientContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "HardDestroyPatient", "patients")
+	defer span.End()
+	stmt, err := prepare(ctx, "Patient", rebind(`DELETE FROM patients WHERE id = ?`))
+	if err != nil {
+		return err
+	}
+	result, err := stmt.Exec(ctx, id)
+	if err != nil {
+		return err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return fmt.Errorf("HardDestroyPatient error: %w", ErrNotFound)
+	}
+	return nil
 }
 
 // Save method is used for a Patient object to update an existed record mainly.
-// If no id provided a new record will be created. FIXME: A UPSERT action will be implemented further.
+// If no id provided a new record will be created, and if the record of the id doesn't exist
+// it will be created with the id.
+// A loaded object only updates its changed columns, see ChangedFields.
+// The object is reloaded from the database after it's saved, its loaded associations are kept.
+// It runs the BeforeValidate, BeforeUpdate, AfterUpdate and AfterCommit callbacks if implemented.
 func (_patient *Patient) Save() error {
+	return _patient.SaveContext(context.Background())
+}
+
+// SaveContext is same as Save but with the context ctx.
+func (_patient *Patient) SaveContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Patient.Save", "patients")
+	defer span.End()
+	if _patient.Id == 0 {
+		_, err := _patient.CreateContext(ctx)
+		return err
+	}
+	if err := runBeforeValidate(_patient); err != nil {
+		return err
+	}
 	ok, err := govalidator.ValidateStruct(_patient)
 	if !ok {
-		errMsg := "Validate Patient struct error: Unknown error"
-		if err != nil {
-			errMsg = "Validate Patient struct error: " + err.Error()
-		}
-		log.Println(errMsg)
-		return errors.New(errMsg)
+		err = &ValidationError{Model: "Patient", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Patient")
+		return err
 	}
-	if _patient.Id == 0 {
-		_, err = _patient.Create()
+	if err = runBeforeUpdate(_patient); err != nil {
 		return err
 	}
+	old := _patient.original
+	if old != nil && !_patient.Changed() {
+		// a loaded object without changes has nothing to write
+		_patient.savedChanges = nil
+		return runAfterUpdate(ctx, _patient)
+	}
 	_patient.UpdatedAt = time.Now()
-	sqlFmt := `UPDATE patients SET %s WHERE id = %v`
-	sqlStr := fmt.Sprintf(sqlFmt, "name = :name, updated_at = :updated_at", _patient.Id)
-	_, err = DB.NamedExec(sqlStr, _patient)
-	return err
+	if old != nil {
+		// a loaded object only updates its changed columns
+		sqlFmt := `UPDATE patients SET %s WHERE id = %v`
+		sqlStr := fmt.Sprintf(sqlFmt, namedSets(append(_patient.ChangedFields(), "updated_at")), _patient.Id)
+		_, err = dbNamedExec(ctx, DB, "Patient", sqlStr, _patient)
+	} else {
+		// an object not loaded updates all its columns, or it's created with the id if the record doesn't exist
+		if _patient.CreatedAt.IsZero() {
+			_patient.CreatedAt = _patient.UpdatedAt
+		}
+		_, err = upsertWithId(ctx, "Patient", "patients", _patient.Id, _patient, []string{"id", "name", "created_at", "updated_at"}, []string{"name", "updated_at"})
+	}
+	if err != nil {
+		return err
+	}
+	if err = _patient.reloadColumns(ctx); err != nil {
+		return err
+	}
+	_patient.savedChanges = diffAttributes(old, _patient.attributes())
+	return runAfterUpdate(ctx, _patient)
+}
+
+// UpsertPatient use a named params to create a single Patient record, or to update the updateColumns
+// of the existed record instead if it conflicts with the params on the conflictColumns.
+// The id of the created or updated record is returned. With MySQL the conflict is detected
+// on any unique key of the table, the conflictColumns is only used by PostgreSQL and SQLite, so the
+// record conflicting on another unique key than the conflictColumns is updated and its id is returned.
+func UpsertPatient(am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	return UpsertPatientContext(context.Background(), am, conflictColumns, updateColumns)
+}
+
+// UpsertPatientContext is same as UpsertPatient but with the context ctx.
+func UpsertPatientContext(ctx context.Context, am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	ctx, span := startSpan(ctx, "UpsertPatient", "patients")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("UpsertPatient error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	return upsert(ctx, "Patient", "patients", am, allKeys(am), conflictColumns, updateColumns)
+}
+
+// Upsert is a method for Patient to create a record, or to update the updateColumns of the existed
+// record instead if it conflicts with the object on the conflictColumns, see UpsertPatient.
+// The Id of the object is set to the id of the created or updated record.
+// It runs the BeforeValidate callback if implemented.
+func (_patient *Patient) Upsert(conflictColumns, updateColumns []string) error {
+	return _patient.UpsertContext(context.Background(), conflictColumns, updateColumns)
+}
+
+// UpsertContext is same as Upsert but with the context ctx.
+func (_patient *Patient) UpsertContext(ctx context.Context, conflictColumns, updateColumns []string) error {
+	ctx, span := startSpan(ctx, "Patient.Upsert", "patients")
+	defer span.End()
+	if err := runBeforeValidate(_patient); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_patient)
+	if !ok {
+		err = &ValidationError{Model: "Patient", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Patient")
+		return err
+	}
+	t := time.Now()
+	if _patient.CreatedAt.IsZero() {
+		_patient.CreatedAt = t
+	}
+	_patient.UpdatedAt = t
+	keys := []string{"name", "created_at", "updated_at"}
+	if _patient.Id != 0 {
+		keys = append([]string{"id"}, keys...)
+	}
+	id, err := upsert(ctx, "Patient", "patients", _patient, keys, conflictColumns, updateColumns)
+	if err != nil {
+		return err
+	}
+	_patient.Id = id
+	_patient.snapshot()
+	return nil
 }
 
 // UpdatePatient is used to update a record with a id and map[string]interface{} typed key-value parameters.
 func UpdatePatient(id int64, am map[string]interface{}) error {
+	return UpdatePatientContext(context.Background(), id, am)
+}
+
+// UpdatePatientContext is same as UpdatePatient but with the context ctx.
+func UpdatePatientContext(ctx context.Context, id int64, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "UpdatePatient", "patients")
+	defer span.End()
 	if len(am) == 0 {
-		return errors.New("Zero key in the attributes map!")
+		return fmt.Errorf("UpdatePatient error: %w", ErrEmptyAttributes)
 	}
 	am["updated_at"] = time.Now()
+	return updatePatientColumns(ctx, id, am)
+}
+
+// updatePatientColumns is used to update the columns of a record with a id as they are in the attributes map.
+func updatePatientColumns(ctx context.Context, id int64, am map[string]interface{}) error {
+	if len(am) == 0 {
+		return fmt.Errorf("Patient.UpdateColumns error: %w", ErrEmptyAttributes)
+	}
 	keys := allKeys(am)
 	sqlFmt := `UPDATE patients SET %s WHERE id = %v`
 	setKeysArr := []string{}
@@ -730,49 +1494,105 @@ func UpdatePatient(id int64, am map[string]interface{}) error {
 		setKeysArr = append(setKeysArr, s)
 	}
 	sqlStr := fmt.Sprintf(sqlFmt, strings.Join(setKeysArr, ", "), id)
-	_, err := DB.NamedExec(sqlStr, am)
+	_, err := dbNamedExec(ctx, DB, "Patient", sqlStr, am)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 	return nil
 }
 
 // Update is a method used to update a Patient record with the map[string]interface{} typed key-value parameters.
+// The object is reloaded from the database after it's updated, its loaded associations are kept.
 func (_patient *Patient) Update(am map[string]interface{}) error {
+	return _patient.UpdateContext(context.Background(), am)
+}
+
+// UpdateContext is same as Update but with the context ctx.
+func (_patient *Patient) UpdateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Patient.Update", "patients")
+	defer span.End()
 	if _patient.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Patient.Update error: %w", ErrInvalidID)
 	}
-	err := UpdatePatient(_patient.Id, am)
-	return err
+	if err := runBeforeUpdate(_patient); err != nil {
+		return err
+	}
+	old := _patient.attributes()
+	err := UpdatePatientContext(ctx, _patient.Id, am)
+	if err != nil {
+		return err
+	}
+	if err = _patient.reloadColumns(ctx); err != nil {
+		return err
+	}
+	_patient.savedChanges = diffAttributes(old, _patient.attributes())
+	return runAfterUpdate(ctx, _patient)
 }
 
 // UpdateAttributes method is supposed to be used to update Patient records as corresponding update_attributes in Ruby on Rails.
+// The attributes are assigned to the object which is then saved, i.e. it's validated,
+// the callbacks are run and the updated_at is stamped, see Save.
 func (_patient *Patient) UpdateAttributes(am map[string]interface{}) error {
+	return _patient.UpdateAttributesContext(context.Background(), am)
+}
+
+// UpdateAttributesContext is same as UpdateAttributes but with the context ctx.
+func (_patient *Patient) UpdateAttributesContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Patient.UpdateAttributes", "patients")
+	defer span.End()
 	if _patient.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Patient.UpdateAttributes error: %w", ErrInvalidID)
 	}
-	err := UpdatePatient(_patient.Id, am)
-	return err
+	if err := assignColumns(_patient, am); err != nil {
+		return err
+	}
+	return _patient.SaveContext(ctx)
 }
 
 // UpdateColumns method is supposed to be used to update Patient records as corresponding update_columns in Ruby on Rails.
+// The columns are written as they are, skipping the validation, the callbacks and the updated_at
+// stamp, and then assigned to the object. A value which can't be assigned fails before anything is written.
 func (_patient *Patient) UpdateColumns(am map[string]interface{}) error {
+	return _patient.UpdateColumnsContext(context.Background(), am)
+}
+
+// UpdateColumnsContext is same as UpdateColumns but with the context ctx.
+func (_patient *Patient) UpdateColumnsContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Patient.UpdateColumns", "patients")
+	defer span.End()
 	if _patient.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Patient.UpdateColumns error: %w", ErrInvalidID)
 	}
-	err := UpdatePatient(_patient.Id, am)
-	return err
+	assigned := *_patient
+	if err := assignColumns(&assigned, am); err != nil {
+		return err
+	}
+	if err := updatePatientColumns(ctx, _patient.Id, am); err != nil {
+		return err
+	}
+	*_patient = assigned
+	markSaved(_patient.original, _patient.attributes(), allKeys(am))
+	return nil
 }
 
 // UpdatePatientsBySql is used to update Patient records by a SQL clause
 // using the '?' binding syntax.
 func UpdatePatientsBySql(sql string, args ...interface{}) (int64, error) {
+	return UpdatePatientsBySqlContext(context.Background(), sql, args...)
+}
+
+// UpdatePatientsBySqlContext is same as UpdatePatientsBySql but with the context ctx.
+func UpdatePatientsBySqlContext(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "UpdatePatientsBySql", "patients")
+	defer span.End()
 	if sql == "" {
-		return 0, errors.New("A blank SQL clause")
+		return 0, fmt.Errorf("UpdatePatientsBySql error: %w", ErrBlankSQL)
+	}
+	stmt, err := prepare(ctx, "Patient", rebind(sql))
+	if err != nil {
+		return 0, err
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(args...)
+	result, err := stmt.Exec(ctx, args...)
 	if err != nil {
 		return 0, err
 	}
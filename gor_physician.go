@@ -2,30 +2,72 @@
 package models
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"log"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
 )
 
-// set flags to output more detailed log
-func init() {
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
+type Physician struct {
+	Id                      int64                   `json:"id,omitempty" db:"id" valid:"-"`
+	Name                    string                  `json:"name,omitempty" db:"name" valid:"required,length(6|15)"`
+	CreatedAt               time.Time               `json:"created_at,omitempty" db:"created_at" valid:"-"`
+	UpdatedAt               time.Time               `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
+	Introduction            string                  `json:"introduction,omitempty" db:"introduction" valid:"required"`
+	TimeZone                string                  `json:"time_zone,omitempty" db:"time_zone" valid:"-"`
+	Appointments            []Appointment           `json:"appointments,omitempty" db:"appointments" valid:"-"`
+	Patients                []Patient               `json:"patients,omitempty" db:"patients" valid:"-"`
+	Pictures                []Picture               `json:"pictures,omitempty" db:"pictures" valid:"-"`
+	PhysicianAvailabilities []PhysicianAvailability `json:"physician_availabilities,omitempty" db:"physician_availabilities" valid:"-"`
+	original                map[string]interface{}
+	savedChanges            map[string]Change
 }
 
-type Physician struct {
-	Id           int64         `json:"id,omitempty" db:"id" valid:"-"`
-	Name         string        `json:"name,omitempty" db:"name" valid:"required,length(6|15)"`
-	CreatedAt    time.Time     `json:"created_at,omitempty" db:"created_at" valid:"-"`
-	UpdatedAt    time.Time     `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
-	Introduction string        `json:"introduction,omitempty" db:"introduction" valid:"required"`
-	Appointments []Appointment `json:"appointments,omitempty" db:"appointments" valid:"-"`
-	Patients     []Patient     `json:"patients,omitempty" db:"patients" valid:"-"`
-	Pictures     []Picture     `json:"pictures,omitempty" db:"pictures" valid:"-"`
+// attributes returns the values of the changeable columns of the Physician object by column names.
+func (_physician *Physician) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"name":         _physician.Name,
+		"introduction": _physician.Introduction,
+		"time_zone":    _physician.TimeZone,
+	}
+}
+
+// snapshot keeps the current attributes of the Physician object as the original ones for the dirty tracking.
+func (_physician *Physician) snapshot() {
+	_physician.original = _physician.attributes()
+}
+
+// snapshotPhysicians keeps the current attributes of the loaded Physician objects as the original ones.
+func snapshotPhysicians(_physicians []Physician) {
+	for i := range _physicians {
+		_physicians[i].snapshot()
+	}
+}
+
+// Changed reports whether any attribute of the Physician object is changed since it was loaded
+// or saved. An object which is not loaded from the database is always changed.
+func (_physician *Physician) Changed() bool {
+	return len(_physician.ChangedFields()) > 0
+}
+
+// ChangedFields returns the sorted column names of the changed attributes of the Physician object.
+func (_physician *Physician) ChangedFields() []string {
+	return changedFields(_physician.original, _physician.attributes())
+}
+
+// Changes returns the old and new values of the changed attributes of the Physician object by column names.
+func (_physician *Physician) Changes() map[string]Change {
+	return diffAttributes(_physician.original, _physician.attributes())
+}
+
+// SavedChanges returns the changes written by the last Create, Save, Update or UpdateAttributes
+// of the Physician object, e.g. for an After callback to audit them.
+func (_physician *Physician) SavedChanges() map[string]Change {
+	return _physician.savedChanges
 }
 
 // DataStruct for the pagination
@@ -44,12 +86,19 @@ type PhysicianPage struct {
 
 // Current get the current page of PhysicianPage object for pagination.
 func (_p *PhysicianPage) Current() ([]Physician, error) {
+	return _p.CurrentContext(context.Background())
+}
+
+// CurrentContext is same as Current but with the context ctx.
+func (_p *PhysicianPage) CurrentContext(ctx context.Context) ([]Physician, error) {
+	ctx, span := startSpan(ctx, "PhysicianPage.Current", "physicians")
+	defer span.End()
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PhysicianPage.Current error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -58,7 +107,7 @@ func (_p *PhysicianPage) Current() ([]Physician, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	physicians, err := FindPhysiciansWhere(whereStr, whereParams...)
+	physicians, err := FindPhysiciansWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -70,15 +119,22 @@ func (_p *PhysicianPage) Current() ([]Physician, error) {
 
 // Previous get the previous page of PhysicianPage object for pagination.
 func (_p *PhysicianPage) Previous() ([]Physician, error) {
+	return _p.PreviousContext(context.Background())
+}
+
+// PreviousContext is same as Previous but with the context ctx.
+func (_p *PhysicianPage) PreviousContext(ctx context.Context) ([]Physician, error) {
+	ctx, span := startSpan(ctx, "PhysicianPage.Previous", "physicians")
+	defer span.End()
 	if _p.PageNum == 0 {
-		return nil, errors.New("This's the first page, no previous page yet")
+		return nil, fmt.Errorf("PhysicianPage.Previous error: %w", ErrFirstPage)
 	}
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PhysicianPage.Previous error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -87,7 +143,7 @@ func (_p *PhysicianPage) Previous() ([]Physician, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	physicians, err := FindPhysiciansWhere(whereStr, whereParams...)
+	physicians, err := FindPhysiciansWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,15 +156,22 @@ func (_p *PhysicianPage) Previous() ([]Physician, error) {
 
 // Next get the next page of PhysicianPage object for pagination.
 func (_p *PhysicianPage) Next() ([]Physician, error) {
+	return _p.NextContext(context.Background())
+}
+
+// NextContext is same as Next but with the context ctx.
+func (_p *PhysicianPage) NextContext(ctx context.Context) ([]Physician, error) {
+	ctx, span := startSpan(ctx, "PhysicianPage.Next", "physicians")
+	defer span.End()
 	if _p.PageNum == _p.TotalPages-1 {
-		return nil, errors.New("This's the last page, no next page yet")
+		return nil, fmt.Errorf("PhysicianPage.Next error: %w", ErrLastPage)
 	}
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PhysicianPage.Next error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -117,7 +180,7 @@ func (_p *PhysicianPage) Next() ([]Physician, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	physicians, err := FindPhysiciansWhere(whereStr, whereParams...)
+	physicians, err := FindPhysiciansWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -131,15 +194,22 @@ func (_p *PhysicianPage) Next() ([]Physician, error) {
 // GetPage is a helper function for the PhysicianPage object to return a corresponding page due to
 // the parameter passed in, i.e. one of "previous, current or next".
 func (_p *PhysicianPage) GetPage(direction string) (ps []Physician, err error) {
+	return _p.GetPageContext(context.Background(), direction)
+}
+
+// GetPageContext is same as GetPage but with the context ctx.
+func (_p *PhysicianPage) GetPageContext(ctx context.Context, direction string) (ps []Physician, err error) {
+	ctx, span := startSpan(ctx, "PhysicianPage.GetPage", "physicians")
+	defer span.End()
 	switch direction {
 	case "previous":
-		ps, _ = _p.Previous()
+		ps, err = _p.PreviousContext(ctx)
 	case "next":
-		ps, _ = _p.Next()
+		ps, err = _p.NextContext(ctx)
 	case "current":
-		ps, _ = _p.Current()
+		ps, err = _p.CurrentContext(ctx)
 	default:
-		return nil, errors.New("Error: wrong dircetion! None of previous, current or next!")
+		return nil, fmt.Errorf("PhysicianPage.GetPage error: %w: %q", ErrWrongDirection, direction)
 	}
 	return
 }
@@ -195,8 +265,8 @@ func (_p *PhysicianPage) buildIdRestrict(direction string) (idStr string, idPara
 }
 
 // buildPageCount calculate the TotalItems/TotalPages for the PhysicianPage object.
-func (_p *PhysicianPage) buildPageCount() error {
-	count, err := PhysicianCountWhere(_p.WhereString, _p.WhereParams...)
+func (_p *PhysicianPage) buildPageCount(ctx context.Context) error {
+	count, err := PhysicianCountWhereContext(ctx, _p.WhereString, _p.WhereParams...)
 	if err != nil {
 		return err
 	}
@@ -210,126 +280,195 @@ func (_p *PhysicianPage) buildPageCount() error {
 
 // FindPhysician find a single physician by an ID.
 func FindPhysician(id int64) (*Physician, error) {
+	return FindPhysicianContext(context.Background(), id)
+}
+
+// FindPhysicianContext is same as FindPhysician but with the context ctx.
+func FindPhysicianContext(ctx context.Context, id int64) (*Physician, error) {
+	ctx, span := startSpan(ctx, "FindPhysician", "physicians")
+	defer span.End()
 	if id == 0 {
-		return nil, errors.New("Invalid ID: it can't be zero")
+		return nil, fmt.Errorf("FindPhysician error: %w", ErrInvalidID)
 	}
 	_physician := Physician{}
-	err := DB.Get(&_physician, DB.Rebind(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE physicians.id = ? LIMIT 1`), id)
+	err := dbGet(ctx, DB, "Physician", &_physician, rebind(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE physicians.id = ? LIMIT 1`), id)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_physician.snapshot()
 	return &_physician, nil
 }
 
 // FirstPhysician find the first one physician by ID ASC order.
 func FirstPhysician() (*Physician, error) {
+	return FirstPhysicianContext(context.Background())
+}
+
+// FirstPhysicianContext is same as FirstPhysician but with the context ctx.
+func FirstPhysicianContext(ctx context.Context) (*Physician, error) {
+	ctx, span := startSpan(ctx, "FirstPhysician", "physicians")
+	defer span.End()
 	_physician := Physician{}
-	err := DB.Get(&_physician, DB.Rebind(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians ORDER BY physicians.id ASC LIMIT 1`))
+	err := dbGet(ctx, DB, "Physician", &_physician, rebind(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians ORDER BY physicians.id ASC LIMIT 1`))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_physician.snapshot()
 	return &_physician, nil
 }
 
 // FirstPhysicians find the first N physicians by ID ASC order.
 func FirstPhysicians(n uint32) ([]Physician, error) {
+	return FirstPhysiciansContext(context.Background(), n)
+}
+
+// FirstPhysiciansContext is same as FirstPhysicians but with the context ctx.
+func FirstPhysiciansContext(ctx context.Context, n uint32) ([]Physician, error) {
+	ctx, span := startSpan(ctx, "FirstPhysicians", "physicians")
+	defer span.End()
 	_physicians := []Physician{}
-	sql := fmt.Sprintf("SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians ORDER BY physicians.id ASC LIMIT %v", n)
-	err := DB.Select(&_physicians, DB.Rebind(sql))
+	sql := fmt.Sprintf("SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians ORDER BY physicians.id ASC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "Physician", &_physicians, rebind(sql))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPhysicians(_physicians)
 	return _physicians, nil
 }
 
 // LastPhysician find the last one physician by ID DESC order.
 func LastPhysician() (*Physician, error) {
+	return LastPhysicianContext(context.Background())
+}
+
+// LastPhysicianContext is same as LastPhysician but with the context ctx.
+func LastPhysicianContext(ctx context.Context) (*Physician, error) {
+	ctx, span := startSpan(ctx, "LastPhysician", "physicians")
+	defer span.End()
 	_physician := Physician{}
-	err := DB.Get(&_physician, DB.Rebind(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians ORDER BY physicians.id DESC LIMIT 1`))
+	err := dbGet(ctx, DB, "Physician", &_physician, rebind(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians ORDER BY physicians.id DESC LIMIT 1`))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_physician.snapshot()
 	return &_physician, nil
 }
 
 // LastPhysicians find the last N physicians by ID DESC order.
 func LastPhysicians(n uint32) ([]Physician, error) {
+	return LastPhysiciansContext(context.Background(), n)
+}
+
+// LastPhysiciansContext is same as LastPhysicians but with the context ctx.
+func LastPhysiciansContext(ctx context.Context, n uint32) ([]Physician, error) {
+	ctx, span := startSpan(ctx, "LastPhysicians", "physicians")
+	defer span.End()
 	_physicians := []Physician{}
-	sql := fmt.Sprintf("SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians ORDER BY physicians.id DESC LIMIT %v", n)
-	err := DB.Select(&_physicians, DB.Rebind(sql))
+	sql := fmt.Sprintf("SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians ORDER BY physicians.id DESC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "Physician", &_physicians, rebind(sql))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPhysicians(_physicians)
 	return _physicians, nil
 }
 
 // FindPhysicians find one or more physicians by the given ID(s).
 func FindPhysicians(ids ...int64) ([]Physician, error) {
+	return FindPhysiciansContext(context.Background(), ids...)
+}
+
+// FindPhysiciansContext is same as FindPhysicians but with the context ctx.
+func FindPhysiciansContext(ctx context.Context, ids ...int64) ([]Physician, error) {
+	ctx, span := startSpan(ctx, "FindPhysicians", "physicians")
+	defer span.End()
 	if len(ids) == 0 {
-		msg := "At least one or more ids needed"
-		log.Println(msg)
-		return nil, errors.New(msg)
+		err := fmt.Errorf("FindPhysicians error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Physician")
+		return nil, err
 	}
 	_physicians := []Physician{}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
-	sql := DB.Rebind(fmt.Sprintf(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE physicians.id IN (?%s)`, idsHolder))
+	sql := rebind(fmt.Sprintf(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE physicians.id IN (?%s)`, idsHolder))
 	idsT := []interface{}{}
 	for _, id := range ids {
 		idsT = append(idsT, interface{}(id))
 	}
-	err := DB.Select(&_physicians, sql, idsT...)
+	err := dbSelect(ctx, DB, "Physician", &_physicians, sql, idsT...)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPhysicians(_physicians)
 	return _physicians, nil
 }
 
 // FindPhysicianBy find a single physician by a field name and a value.
 func FindPhysicianBy(field string, val interface{}) (*Physician, error) {
+	return FindPhysicianByContext(context.Background(), field, val)
+}
+
+// FindPhysicianByContext is same as FindPhysicianBy but with the context ctx.
+func FindPhysicianByContext(ctx context.Context, field string, val interface{}) (*Physician, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianBy", "physicians")
+	defer span.End()
 	_physician := Physician{}
-	sqlFmt := `SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE %s = ? LIMIT 1`
+	sqlFmt := `SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE %s = ? LIMIT 1`
 	sqlStr := fmt.Sprintf(sqlFmt, field)
-	err := DB.Get(&_physician, DB.Rebind(sqlStr), val)
+	err := dbGet(ctx, DB, "Physician", &_physician, rebind(sqlStr), val)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_physician.snapshot()
 	return &_physician, nil
 }
 
 // FindPhysiciansBy find all physicians by a field name and a value.
 func FindPhysiciansBy(field string, val interface{}) (_physicians []Physician, err error) {
-	sqlFmt := `SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE %s = ?`
+	return FindPhysiciansByContext(context.Background(), field, val)
+}
+
+// FindPhysiciansByContext is same as FindPhysiciansBy but with the context ctx.
+func FindPhysiciansByContext(ctx context.Context, field string, val interface{}) (_physicians []Physician, err error) {
+	ctx, span := startSpan(ctx, "FindPhysiciansBy", "physicians")
+	defer span.End()
+	sqlFmt := `SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE %s = ?`
 	sqlStr := fmt.Sprintf(sqlFmt, field)
-	err = DB.Select(&_physicians, DB.Rebind(sqlStr), val)
+	err = dbSelect(ctx, DB, "Physician", &_physicians, rebind(sqlStr), val)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPhysicians(_physicians)
 	return _physicians, nil
 }
 
 // AllPhysicians get all the Physician records.
 func AllPhysicians() (physicians []Physician, err error) {
-	err = DB.Select(&physicians, "SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians")
+	return AllPhysiciansContext(context.Background())
+}
+
+// AllPhysiciansContext is same as AllPhysicians but with the context ctx.
+func AllPhysiciansContext(ctx context.Context) (physicians []Physician, err error) {
+	ctx, span := startSpan(ctx, "AllPhysicians", "physicians")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Physician", &physicians, "SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians")
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPhysicians(physicians)
 	return physicians, nil
 }
 
 // PhysicianCount get the count of all the Physician records.
 func PhysicianCount() (c int64, err error) {
-	err = DB.Get(&c, "SELECT count(*) FROM physicians")
+	return PhysicianCountContext(context.Background())
+}
+
+// PhysicianCountContext is same as PhysicianCount but with the context ctx.
+func PhysicianCountContext(ctx context.Context) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PhysicianCount", "physicians")
+	defer span.End()
+	err = dbGet(ctx, DB, "Physician", &c, "SELECT count(*) FROM physicians")
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	return c, nil
@@ -337,18 +476,23 @@ func PhysicianCount() (c int64, err error) {
 
 // PhysicianCountWhere get the count of all the Physician records with a where clause.
 func PhysicianCountWhere(where string, args ...interface{}) (c int64, err error) {
+	return PhysicianCountWhereContext(context.Background(), where, args...)
+}
+
+// PhysicianCountWhereContext is same as PhysicianCountWhere but with the context ctx.
+func PhysicianCountWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PhysicianCountWhere", "physicians")
+	defer span.End()
 	sql := "SELECT count(*) FROM physicians"
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
-	err = stmt.Get(&c, args...)
+	err = stmt.Get(ctx, &c, args...)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	return c, nil
@@ -356,35 +500,61 @@ func PhysicianCountWhere(where string, args ...interface{}) (c int64, err error)
 
 // PhysicianIncludesWhere get the Physician associated models records, currently it's not same as the corresponding "includes" function but "preload" instead in Ruby on Rails. It means that the "sql" should be restricted on Physician model.
 func PhysicianIncludesWhere(assocs []string, sql string, args ...interface{}) (_physicians []Physician, err error) {
-	_physicians, err = FindPhysiciansWhere(sql, args...)
+	return PhysicianIncludesWhereContext(context.Background(), assocs, sql, args...)
+}
+
+// PhysicianIncludesWhereContext is same as PhysicianIncludesWhere but with the context ctx.
+func PhysicianIncludesWhereContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_physicians []Physician, err error) {
+	ctx, span := startSpan(ctx, "PhysicianIncludesWhere", "physicians")
+	defer span.End()
+	return physicianIncludesWhere(ctx, false, assocs, sql, args...)
+}
+
+// PhysicianIncludesWherePartial is same as PhysicianIncludesWhere but it returns the records with the associations loaded so far together with the errors of the ones failed to be loaded, joined by errors.Join, rather than failing with the first of them. A record keeps an association empty if it's failed to be loaded.
+func PhysicianIncludesWherePartial(assocs []string, sql string, args ...interface{}) (_physicians []Physician, err error) {
+	return PhysicianIncludesWherePartialContext(context.Background(), assocs, sql, args...)
+}
+
+// PhysicianIncludesWherePartialContext is same as PhysicianIncludesWherePartial but with the context ctx.
+func PhysicianIncludesWherePartialContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_physicians []Physician, err error) {
+	ctx, span := startSpan(ctx, "PhysicianIncludesWherePartial", "physicians")
+	defer span.End()
+	return physicianIncludesWhere(ctx, true, assocs, sql, args...)
+}
+
+// physicianIncludesWhere get the Physician associated models records, it fails with the first error of loading an association unless partial, see PhysicianIncludesWherePartial.
+func physicianIncludesWhere(ctx context.Context, partial bool, assocs []string, sql string, args ...interface{}) (_physicians []Physician, err error) {
+	_physicians, err = FindPhysiciansWhereContext(ctx, sql, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	if len(assocs) == 0 {
-		log.Println("No associated fields ard specified")
+		logger().Debug("No associated fields are specified", "model", "Physician")
 		return _physicians, err
 	}
 	if len(_physicians) <= 0 {
-		return nil, errors.New("No results available")
+		return nil, fmt.Errorf("PhysicianIncludesWhere error: %w", ErrNotFound)
 	}
 	ids := make([]interface{}, len(_physicians))
 	for _, v := range _physicians {
 		ids = append(ids, interface{}(v.Id))
 	}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
+	errs := &includesErrors{model: "Physician", partial: partial}
 	for _, assoc := range assocs {
 		switch assoc {
 		case "appointments":
 			where := fmt.Sprintf("physician_id IN (?%s)", idsHolder)
-			_appointments, err := FindAppointmentsWhere(where, ids...)
+			_appointments, err := FindAppointmentsWhereContext(ctx, where, ids...)
 			if err != nil {
-				log.Printf("Error when query associated objects: %v\n", assoc)
+				if err = errs.add(assoc, err); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			for _, vv := range _appointments {
 				for i, vvv := range _physicians {
-					if vv.PhysicianId == vvv.Id {
+					if vv.PhysicianId != nil && *vv.PhysicianId == vvv.Id {
 						vvv.Appointments = append(vvv.Appointments, vv)
 					}
 					_physicians[i].Appointments = vvv.Appointments
@@ -393,8 +563,11 @@ func PhysicianIncludesWhere(assocs []string, sql string, args ...interface{}) (_
 		case "patients":
 			// FIXME: optimize the query
 			for i, vvv := range _physicians {
-				_patients, err := PhysicianGetPatients(vvv.Id)
+				_patients, err := PhysicianGetPatientsContext(ctx, vvv.Id)
 				if err != nil {
+					if err = errs.add(assoc, err); err != nil {
+						return nil, err
+					}
 					continue
 				}
 				vvv.Patients = _patients
@@ -403,23 +576,49 @@ func PhysicianIncludesWhere(assocs []string, sql string, args ...interface{}) (_
 		case "pictures":
 			// FIXME: optimize the query
 			for i, vvv := range _physicians {
-				_pictures, err := PhysicianGetPictures(vvv.Id)
+				_pictures, err := PhysicianGetPicturesContext(ctx, vvv.Id)
 				if err != nil {
+					if err = errs.add(assoc, err); err != nil {
+						return nil, err
+					}
 					continue
 				}
 				vvv.Pictures = _pictures
 				_physicians[i] = vvv
 			}
+		case "physician_availabilities":
+			where := fmt.Sprintf("physician_id IN (?%s)", idsHolder)
+			_physicianAvailabilities, err := FindPhysicianAvailabilitiesWhereContext(ctx, where, ids...)
+			if err != nil {
+				if err = errs.add(assoc, err); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			for _, vv := range _physicianAvailabilities {
+				for i, vvv := range _physicians {
+					if vv.PhysicianId == vvv.Id {
+						vvv.PhysicianAvailabilities = append(vvv.PhysicianAvailabilities, vv)
+					}
+					_physicians[i].PhysicianAvailabilities = vvv.PhysicianAvailabilities
+				}
+			}
 		}
 	}
-	return _physicians, nil
+	return _physicians, errs.err()
 }
 
 // PhysicianIds get all the IDs of Physician records.
 func PhysicianIds() (ids []int64, err error) {
-	err = DB.Select(&ids, "SELECT id FROM physicians")
+	return PhysicianIdsContext(context.Background())
+}
+
+// PhysicianIdsContext is same as PhysicianIds but with the context ctx.
+func PhysicianIdsContext(ctx context.Context) (ids []int64, err error) {
+	ctx, span := startSpan(ctx, "PhysicianIds", "physicians")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Physician", &ids, "SELECT id FROM physicians")
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return ids, nil
@@ -427,24 +626,36 @@ func PhysicianIds() (ids []int64, err error) {
 
 // PhysicianIdsWhere get all the IDs of Physician records by where restriction.
 func PhysicianIdsWhere(where string, args ...interface{}) ([]int64, error) {
-	ids, err := PhysicianIntCol("id", where, args...)
+	return PhysicianIdsWhereContext(context.Background(), where, args...)
+}
+
+// PhysicianIdsWhereContext is same as PhysicianIdsWhere but with the context ctx.
+func PhysicianIdsWhereContext(ctx context.Context, where string, args ...interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "PhysicianIdsWhere", "physicians")
+	defer span.End()
+	ids, err := PhysicianIntColContext(ctx, "id", where, args...)
 	return ids, err
 }
 
 // PhysicianIntCol get some int64 typed column of Physician by where restriction.
 func PhysicianIntCol(col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	return PhysicianIntColContext(context.Background(), col, where, args...)
+}
+
+// PhysicianIntColContext is same as PhysicianIntCol but with the context ctx.
+func PhysicianIntColContext(ctx context.Context, col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	ctx, span := startSpan(ctx, "PhysicianIntCol", "physicians")
+	defer span.End()
 	sql := "SELECT " + col + " FROM physicians"
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&intColRecs, args...)
+	err = stmt.Select(ctx, &intColRecs, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return intColRecs, nil
@@ -452,18 +663,23 @@ func PhysicianIntCol(col, where string, args ...interface{}) (intColRecs []int64
 
 // PhysicianStrCol get some string typed column of Physician by where restriction.
 func PhysicianStrCol(col, where string, args ...interface{}) (strColRecs []string, err error) {
+	return PhysicianStrColContext(context.Background(), col, where, args...)
+}
+
+// PhysicianStrColContext is same as PhysicianStrCol but with the context ctx.
+func PhysicianStrColContext(ctx context.Context, col, where string, args ...interface{}) (strColRecs []string, err error) {
+	ctx, span := startSpan(ctx, "PhysicianStrCol", "physicians")
+	defer span.End()
 	sql := "SELECT " + col + " FROM physicians"
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&strColRecs, args...)
+	err = stmt.Select(ctx, &strColRecs, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return strColRecs, nil
@@ -473,20 +689,26 @@ func PhysicianStrCol(col, where string, args ...interface{}) (strColRecs []strin
 // with placeholders, eg: FindUsersWhere("first_name = ? AND age > ?", "John", 18)
 // will return those records in the table "users" whose first_name is "John" and age elder than 18.
 func FindPhysiciansWhere(where string, args ...interface{}) (physicians []Physician, err error) {
-	sql := "SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, physicians.id, physicians.created_at, physicians.updated_at FROM physicians"
+	return FindPhysiciansWhereContext(context.Background(), where, args...)
+}
+
+// FindPhysiciansWhereContext is same as FindPhysiciansWhere but with the context ctx.
+func FindPhysiciansWhereContext(ctx context.Context, where string, args ...interface{}) (physicians []Physician, err error) {
+	ctx, span := startSpan(ctx, "FindPhysiciansWhere", "physicians")
+	defer span.End()
+	sql := "SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians"
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&physicians, args...)
+	err = stmt.Select(ctx, &physicians, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPhysicians(physicians)
 	return physicians, nil
 }
 
@@ -494,17 +716,23 @@ func FindPhysiciansWhere(where string, args ...interface{}) (physicians []Physic
 // with placeholders, eg: FindUserBySql("SELECT * FROM users WHERE first_name = ? AND age > ? ORDER BY DESC LIMIT 1", "John", 18)
 // will return only One record in the table "users" whose first_name is "John" and age elder than 18.
 func FindPhysicianBySql(sql string, args ...interface{}) (*Physician, error) {
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	return FindPhysicianBySqlContext(context.Background(), sql, args...)
+}
+
+// FindPhysicianBySqlContext is same as FindPhysicianBySql but with the context ctx.
+func FindPhysicianBySqlContext(ctx context.Context, sql string, args ...interface{}) (*Physician, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianBySql", "physicians")
+	defer span.End()
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	_physician := &Physician{}
-	err = stmt.Get(_physician, args...)
+	err = stmt.Get(ctx, _physician, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	_physician.snapshot()
 	return _physician, nil
 }
 
@@ -512,24 +740,108 @@ func FindPhysicianBySql(sql string, args ...interface{}) (*Physician, error) {
 // with placeholders, eg: FindUsersBySql("SELECT * FROM users WHERE first_name = ? AND age > ?", "John", 18)
 // will return those records in the table "users" whose first_name is "John" and age elder than 18.
 func FindPhysiciansBySql(sql string, args ...interface{}) (physicians []Physician, err error) {
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	return FindPhysiciansBySqlContext(context.Background(), sql, args...)
+}
+
+// FindPhysiciansBySqlContext is same as FindPhysiciansBySql but with the context ctx.
+func FindPhysiciansBySqlContext(ctx context.Context, sql string, args ...interface{}) (physicians []Physician, err error) {
+	ctx, span := startSpan(ctx, "FindPhysiciansBySql", "physicians")
+	defer span.End()
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &physicians, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicians(physicians)
+	return physicians, nil
+}
+
+// FindPhysicianForUpdate find a single physician by an ID in the transaction tx and lock its row
+// FOR UPDATE until the transaction ends.
+func FindPhysicianForUpdate(tx *sqlx.Tx, id int64) (*Physician, error) {
+	return FindPhysicianForUpdateContext(context.Background(), tx, id)
+}
+
+// FindPhysicianForUpdateContext is same as FindPhysicianForUpdate but with the context ctx.
+func FindPhysicianForUpdateContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Physician, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianForUpdate", "physicians")
+	defer span.End()
+	return findPhysicianLock(ctx, tx, ForUpdate, id)
+}
+
+// FindPhysicianForShare find a single physician by an ID in the transaction tx and lock its row
+// FOR SHARE until the transaction ends.
+func FindPhysicianForShare(tx *sqlx.Tx, id int64) (*Physician, error) {
+	return FindPhysicianForShareContext(context.Background(), tx, id)
+}
+
+// FindPhysicianForShareContext is same as FindPhysicianForShare but with the context ctx.
+func FindPhysicianForShareContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Physician, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianForShare", "physicians")
+	defer span.End()
+	return findPhysicianLock(ctx, tx, ForShare, id)
+}
+
+// findPhysicianLock find a single physician by an ID in the transaction tx with a row lock mode.
+func findPhysicianLock(ctx context.Context, tx *sqlx.Tx, mode LockMode, id int64) (*Physician, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("Lock Physician error: %w", ErrInvalidID)
+	}
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	_physician := Physician{}
+	err = dbGet(ctx, tx, "Physician", &_physician, tx.Rebind(`SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians WHERE physicians.id = ? LIMIT 1 `+lock), id)
+	if err != nil {
+		return nil, err
+	}
+	_physician.snapshot()
+	return &_physician, nil
+}
+
+// FindPhysiciansWhereLock is same as FindPhysiciansWhere but runs in the transaction tx and lock
+// the selected rows with the lock mode, e.g. ForUpdateSkipLocked, until the transaction ends.
+// The where clause should be a condition only, i.e. with no ORDER BY or LIMIT.
+func FindPhysiciansWhereLock(tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (physicians []Physician, err error) {
+	return FindPhysiciansWhereLockContext(context.Background(), tx, mode, where, args...)
+}
+
+// FindPhysiciansWhereLockContext is same as FindPhysiciansWhereLock but with the context ctx.
+func FindPhysiciansWhereLockContext(ctx context.Context, tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (physicians []Physician, err error) {
+	ctx, span := startSpan(ctx, "FindPhysiciansWhereLock", "physicians")
+	defer span.End()
+	lock, err := lockClause(tx, mode)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&physicians, args...)
+	sql := "SELECT COALESCE(physicians.name, '') AS name, COALESCE(physicians.introduction, '') AS introduction, COALESCE(physicians.time_zone, '') AS time_zone, physicians.id, physicians.created_at, physicians.updated_at FROM physicians"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	err = dbSelect(ctx, tx, "Physician", &physicians, tx.Rebind(sql+" "+lock), args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPhysicians(physicians)
 	return physicians, nil
 }
 
 // CreatePhysician use a named params to create a single Physician record.
 // A named params is key-value map like map[string]interface{}{"first_name": "John", "age": 23} .
 func CreatePhysician(am map[string]interface{}) (int64, error) {
+	return CreatePhysicianContext(context.Background(), am)
+}
+
+// CreatePhysicianContext is same as CreatePhysician but with the context ctx.
+func CreatePhysicianContext(ctx context.Context, am map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CreatePhysician", "physicians")
+	defer span.End()
 	if len(am) == 0 {
-		return 0, fmt.Errorf("Zero key in the attributes map!")
+		return 0, fmt.Errorf("CreatePhysician error: %w", ErrEmptyAttributes)
 	}
 	t := time.Now()
 	for _, v := range []string{"created_at", "updated_at"} {
@@ -540,51 +852,129 @@ func CreatePhysician(am map[string]interface{}) (int64, error) {
 	keys := allKeys(am)
 	sqlFmt := `INSERT INTO physicians (%s) VALUES (%s)`
 	sql := fmt.Sprintf(sqlFmt, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
-	result, err := DB.NamedExec(sql, am)
+	result, err := dbNamedExec(ctx, DB, "Physician", sql, am)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		log.Println(err)
+		logger().Error("Get the last insert id error", "model", "Physician", "error", err)
 		return 0, err
 	}
 	return lastId, nil
 }
 
-// Create is a method for Physician to create a record.
+// CreatePhysicians creates the Physician records of the slice physicians with multi-row INSERT statements,
+// see BulkInsertChunkSize. The structs are validated and time stamped, and their Id fields
+// are set to the ids of the created records, which are returned too. No callbacks are run.
+func CreatePhysicians(physicians []Physician) ([]int64, error) {
+	return CreatePhysiciansContext(context.Background(), physicians)
+}
+
+// CreatePhysiciansContext is same as CreatePhysicians but with the context ctx.
+func CreatePhysiciansContext(ctx context.Context, physicians []Physician) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreatePhysicians", "physicians")
+	defer span.End()
+	t := time.Now()
+	rows := make([][]interface{}, len(physicians))
+	for i := range physicians {
+		_physician := &physicians[i]
+		ok, err := govalidator.ValidateStruct(_physician)
+		if !ok {
+			err = &ValidationError{Model: "Physician", Index: i, Err: err}
+			logger().Warn(err.Error(), "model", "Physician")
+			return nil, err
+		}
+		_physician.CreatedAt = t
+		_physician.UpdatedAt = t
+		rows[i] = []interface{}{_physician.Name, _physician.CreatedAt, _physician.UpdatedAt, _physician.Introduction, _physician.TimeZone}
+	}
+	ids, err := bulkInsert(ctx, "Physician", "physicians", []string{"name", "created_at", "updated_at", "introduction", "time_zone"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		physicians[i].Id = id
+		physicians[i].snapshot()
+	}
+	return ids, nil
+}
+
+// CreatePhysiciansMaps use a slice of named params to create Physician records like CreatePhysician does,
+// but with multi-row INSERT statements, see BulkInsertChunkSize. A column missing in some
+// of the maps gets its default value. The ids of the created records are returned in order.
+func CreatePhysiciansMaps(ams []map[string]interface{}) ([]int64, error) {
+	return CreatePhysiciansMapsContext(context.Background(), ams)
+}
+
+// CreatePhysiciansMapsContext is same as CreatePhysiciansMaps but with the context ctx.
+func CreatePhysiciansMapsContext(ctx context.Context, ams []map[string]interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreatePhysiciansMaps", "physicians")
+	defer span.End()
+	for i, am := range ams {
+		if len(am) == 0 {
+			return nil, fmt.Errorf("CreatePhysiciansMaps error: %w at index %d", ErrEmptyAttributes, i)
+		}
+	}
+	keys, rows := bulkMapRows(ams)
+	return bulkInsert(ctx, "Physician", "physicians", keys, rows)
+}
+
+// Create is a method for Physician to create a record, the Id of the object is set to the new id.
+// It runs the BeforeValidate, BeforeCreate, AfterCreate and AfterCommit callbacks if implemented.
 func (_physician *Physician) Create() (int64, error) {
+	return _physician.CreateContext(context.Background())
+}
+
+// CreateContext is same as Create but with the context ctx.
+func (_physician *Physician) CreateContext(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "Physician.Create", "physicians")
+	defer span.End()
+	if err := runBeforeValidate(_physician); err != nil {
+		return 0, err
+	}
 	ok, err := govalidator.ValidateStruct(_physician)
 	if !ok {
-		errMsg := "Validate Physician struct error: Unknown error"
-		if err != nil {
-			errMsg = "Validate Physician struct error: " + err.Error()
-		}
-		log.Println(errMsg)
-		return 0, errors.New(errMsg)
+		err = &ValidationError{Model: "Physician", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Physician")
+		return 0, err
+	}
+	if err = runBeforeCreate(_physician); err != nil {
+		return 0, err
 	}
 	t := time.Now()
 	_physician.CreatedAt = t
 	_physician.UpdatedAt = t
-	sql := `INSERT INTO physicians (name,created_at,updated_at,introduction) VALUES (:name,:created_at,:updated_at,:introduction)`
-	result, err := DB.NamedExec(sql, _physician)
+	sql := `INSERT INTO physicians (name,created_at,updated_at,introduction,time_zone) VALUES (:name,:created_at,:updated_at,:introduction,:time_zone)`
+	result, err := dbNamedExec(ctx, DB, "Physician", sql, _physician)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		log.Println(err)
+		logger().Error("Get the last insert id error", "model", "Physician", "error", err)
 		return 0, err
 	}
+	_physician.Id = lastId
+	_physician.savedChanges = diffAttributes(nil, _physician.attributes())
+	_physician.snapshot()
+	if err = runAfterCreate(ctx, _physician); err != nil {
+		return lastId, err
+	}
 	return lastId, nil
 }
 
 // AppointmentsCreate is used for Physician to create the associated objects Appointments
 func (_physician *Physician) AppointmentsCreate(am map[string]interface{}) error {
+	return _physician.AppointmentsCreateContext(context.Background(), am)
+}
+
+// AppointmentsCreateContext is same as AppointmentsCreate but with the context ctx.
+func (_physician *Physician) AppointmentsCreateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Physician.AppointmentsCreate", "physicians")
+	defer span.End()
 	am["physician_id"] = _physician.Id
-	_, err := CreateAppointment(am)
+	_, err := CreateAppointmentContext(ctx, am)
 	return err
 }
 
@@ -592,7 +982,14 @@ func (_physician *Physician) AppointmentsCreate(am map[string]interface{}) error
 // Say you have a Physician object named physician, when you call physician.GetAppointments(),
 // the object will get the associated Appointments attributes evaluated in the struct.
 func (_physician *Physician) GetAppointments() error {
-	_appointments, err := PhysicianGetAppointments(_physician.Id)
+	return _physician.GetAppointmentsContext(context.Background())
+}
+
+// GetAppointmentsContext is same as GetAppointments but with the context ctx.
+func (_physician *Physician) GetAppointmentsContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Physician.GetAppointments", "physicians")
+	defer span.End()
+	_appointments, err := PhysicianGetAppointmentsContext(ctx, _physician.Id)
 	if err == nil {
 		_physician.Appointments = _appointments
 	}
@@ -601,18 +998,77 @@ func (_physician *Physician) GetAppointments() error {
 
 // PhysicianGetAppointments a helper fuction used to get associated objects for PhysicianIncludesWhere().
 func PhysicianGetAppointments(id int64) ([]Appointment, error) {
-	_appointments, err := FindAppointmentsBy("physician_id", id)
+	return PhysicianGetAppointmentsContext(context.Background(), id)
+}
+
+// PhysicianGetAppointmentsContext is same as PhysicianGetAppointments but with the context ctx.
+func PhysicianGetAppointmentsContext(ctx context.Context, id int64) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "PhysicianGetAppointments", "physicians")
+	defer span.End()
+	_appointments, err := FindAppointmentsByContext(ctx, "physician_id", id)
 	return _appointments, err
 }
 
+// PhysicianAvailabilitiesCreate is used for Physician to create the associated objects PhysicianAvailabilities
+func (_physician *Physician) PhysicianAvailabilitiesCreate(am map[string]interface{}) error {
+	return _physician.PhysicianAvailabilitiesCreateContext(context.Background(), am)
+}
+
+// PhysicianAvailabilitiesCreateContext is same as PhysicianAvailabilitiesCreate but with the context ctx.
+func (_physician *Physician) PhysicianAvailabilitiesCreateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Physician.PhysicianAvailabilitiesCreate", "physicians")
+	defer span.End()
+	am["physician_id"] = _physician.Id
+	_, err := CreatePhysicianAvailabilityContext(ctx, am)
+	return err
+}
+
+// GetPhysicianAvailabilities is used for Physician to get associated objects PhysicianAvailabilities
+// Say you have a Physician object named physician, when you call physician.GetPhysicianAvailabilities(),
+// the object will get the associated PhysicianAvailabilities attributes evaluated in the struct.
+func (_physician *Physician) GetPhysicianAvailabilities() error {
+	return _physician.GetPhysicianAvailabilitiesContext(context.Background())
+}
+
+// GetPhysicianAvailabilitiesContext is same as GetPhysicianAvailabilities but with the context ctx.
+func (_physician *Physician) GetPhysicianAvailabilitiesContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Physician.GetPhysicianAvailabilities", "physicians")
+	defer span.End()
+	_physicianAvailabilities, err := PhysicianGetPhysicianAvailabilitiesContext(ctx, _physician.Id)
+	if err == nil {
+		_physician.PhysicianAvailabilities = _physicianAvailabilities
+	}
+	return err
+}
+
+// PhysicianGetPhysicianAvailabilities a helper fuction used to get associated objects for PhysicianIncludesWhere().
+func PhysicianGetPhysicianAvailabilities(id int64) ([]PhysicianAvailability, error) {
+	return PhysicianGetPhysicianAvailabilitiesContext(context.Background(), id)
+}
+
+// PhysicianGetPhysicianAvailabilitiesContext is same as PhysicianGetPhysicianAvailabilities but with the context ctx.
+func PhysicianGetPhysicianAvailabilitiesContext(ctx context.Context, id int64) ([]PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "PhysicianGetPhysicianAvailabilities", "physicians")
+	defer span.End()
+	_physicianAvailabilities, err := FindPhysicianAvailabilitiesByContext(ctx, "physician_id", id)
+	return _physicianAvailabilities, err
+}
+
 // PatientsCreate is used for Physician to create the associated objects Patients
 func (_physician *Physician) PatientsCreate(am map[string]interface{}) error {
+	return _physician.PatientsCreateContext(context.Background(), am)
+}
+
+// PatientsCreateContext is same as PatientsCreate but with the context ctx.
+func (_physician *Physician) PatientsCreateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Physician.PatientsCreate", "physicians")
+	defer span.End()
 	// FIXME: use transaction to create these associated objects
-	patientId, err := CreatePatient(am)
+	patientId, err := CreatePatientContext(ctx, am)
 	if err != nil {
 		return err
 	}
-	_, err = CreateAppointment(map[string]interface{}{"physician_id": _physician.Id, "patient_id": patientId})
+	_, err = CreateAppointmentContext(ctx, map[string]interface{}{"physician_id": _physician.Id, "patient_id": patientId})
 	return err
 }
 
@@ -620,7 +1076,14 @@ func (_physician *Physician) PatientsCreate(am map[string]interface{}) error {
 // Say you have a Physician object named physician, when you call physician.GetPatients(),
 // the object will get the associated Patients attributes evaluated in the struct.
 func (_physician *Physician) GetPatients() error {
-	_patients, err := PhysicianGetPatients(_physician.Id)
+	return _physician.GetPatientsContext(context.Background())
+}
+
+// GetPatientsContext is same as GetPatients but with the context ctx.
+func (_physician *Physician) GetPatientsContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Physician.GetPatients", "physicians")
+	defer span.End()
+	_patients, err := PhysicianGetPatientsContext(ctx, _physician.Id)
 	if err == nil {
 		_physician.Patients = _patients
 	}
@@ -629,21 +1092,35 @@ func (_physician *Physician) GetPatients() error {
 
 // PhysicianGetPatients a helper fuction used to get associated objects for PhysicianIncludesWhere().
 func PhysicianGetPatients(id int64) ([]Patient, error) {
+	return PhysicianGetPatientsContext(context.Background(), id)
+}
+
+// PhysicianGetPatientsContext is same as PhysicianGetPatients but with the context ctx.
+func PhysicianGetPatientsContext(ctx context.Context, id int64) ([]Patient, error) {
+	ctx, span := startSpan(ctx, "PhysicianGetPatients", "physicians")
+	defer span.End()
 	// FIXME: use transaction to create these associated objects
-	sql := `SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at
+	sql := `SELECT COALESCE(patients.name, '') AS name, patients.id, patients.created_at, patients.updated_at, patients.deleted_at
 		        FROM   patients
 		               INNER JOIN appointments
 		                       ON patients.id = appointments.patient_id
-		        WHERE appointments.physician_id = ?`
-	_patients, err := FindPatientsBySql(sql, id)
+		        WHERE appointments.physician_id = ? AND appointments.deleted_at IS NULL AND patients.deleted_at IS NULL`
+	_patients, err := FindPatientsBySqlContext(ctx, sql, id)
 	return _patients, err
 }
 
 // PicturesCreate is used for Physician to create the associated objects Pictures
 func (_physician *Physician) PicturesCreate(am map[string]interface{}) error {
+	return _physician.PicturesCreateContext(context.Background(), am)
+}
+
+// PicturesCreateContext is same as PicturesCreate but with the context ctx.
+func (_physician *Physician) PicturesCreateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Physician.PicturesCreate", "physicians")
+	defer span.End()
 	am["imageable_id"] = _physician.Id
 	am["imageable_type"] = "Physician"
-	_, err := CreatePicture(am)
+	_, err := CreatePictureContext(ctx, am)
 	return err
 }
 
@@ -651,7 +1128,14 @@ func (_physician *Physician) PicturesCreate(am map[string]interface{}) error {
 // Say you have a Physician object named physician, when you call physician.GetPictures(),
 // the object will get the associated Pictures attributes evaluated in the struct.
 func (_physician *Physician) GetPictures() error {
-	_pictures, err := PhysicianGetPictures(_physician.Id)
+	return _physician.GetPicturesContext(context.Background())
+}
+
+// GetPicturesContext is same as GetPictures but with the context ctx.
+func (_physician *Physician) GetPicturesContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Physician.GetPictures", "physicians")
+	defer span.End()
+	_pictures, err := PhysicianGetPicturesContext(ctx, _physician.Id)
 	if err == nil {
 		_physician.Pictures = _pictures
 	}
@@ -660,24 +1144,86 @@ func (_physician *Physician) GetPictures() error {
 
 // PhysicianGetPictures a helper fuction used to get associated objects for PhysicianIncludesWhere().
 func PhysicianGetPictures(id int64) ([]Picture, error) {
+	return PhysicianGetPicturesContext(context.Background(), id)
+}
+
+// PhysicianGetPicturesContext is same as PhysicianGetPictures but with the context ctx.
+func PhysicianGetPicturesContext(ctx context.Context, id int64) ([]Picture, error) {
+	ctx, span := startSpan(ctx, "PhysicianGetPictures", "physicians")
+	defer span.End()
 	where := `imageable_type = "Physician" AND imageable_id = ?`
-	_pictures, err := FindPicturesWhere(where, id)
+	_pictures, err := FindPicturesWhereContext(ctx, where, id)
 	return _pictures, err
 }
 
+// Reload is a method for Physician to reload the attributes of the object from the database.
+// The associations loaded into the object are reset.
+func (_physician *Physician) Reload() error {
+	return _physician.ReloadContext(context.Background())
+}
+
+// ReloadContext is same as Reload but with the context ctx.
+func (_physician *Physician) ReloadContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Physician.Reload", "physicians")
+	defer span.End()
+	if _physician.Id == 0 {
+		return fmt.Errorf("Physician.Reload error: %w", ErrInvalidID)
+	}
+	physician, err := FindPhysicianContext(ctx, _physician.Id)
+	if err != nil {
+		return err
+	}
+	*_physician = *physician
+	return nil
+}
+
+// reloadColumns reloads the columns of the Physician object like Reload, but keeps its loaded associations.
+func (_physician *Physician) reloadColumns(ctx context.Context) error {
+	appointments, patients, pictures, physicianAvailabilities := _physician.Appointments, _physician.Patients, _physician.Pictures, _physician.PhysicianAvailabilities
+	if err := _physician.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_physician.Appointments, _physician.Patients, _physician.Pictures, _physician.PhysicianAvailabilities = appointments, patients, pictures, physicianAvailabilities
+	return nil
+}
+
 // Destroy is method used for a Physician object to be destroyed.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
 func (_physician *Physician) Destroy() error {
+	return _physician.DestroyContext(context.Background())
+}
+
+// DestroyContext is same as Destroy but with the context ctx.
+func (_physician *Physician) DestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Physician.Destroy", "physicians")
+	defer span.End()
 	if _physician.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Physician.Destroy error: %w", ErrInvalidID)
 	}
-	err := DestroyPhysician(_physician.Id)
-	return err
+	if err := runBeforeDestroy(_physician); err != nil {
+		return err
+	}
+	err := DestroyPhysicianContext(ctx, _physician.Id)
+	if err != nil {
+		return err
+	}
+	return runAfterDestroy(ctx, _physician)
 }
 
 // DestroyPhysician will destroy a Physician record specified by the id parameter.
 func DestroyPhysician(id int64) error {
-	stmt, err := DB.Preparex(DB.Rebind(`DELETE FROM physicians WHERE id = ?`))
-	_, err = stmt.Exec(id)
+	return DestroyPhysicianContext(context.Background(), id)
+}
+
+// DestroyPhysicianContext is same as DestroyPhysician but with the context ctx.
+func DestroyPhysicianContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DestroyPhysician", "physicians")
+	defer span.End()
+	stmt, err := prepare(ctx, "Physician", rebind(`DELETE FROM physicians WHERE id = ?`))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -686,10 +1232,17 @@ func DestroyPhysician(id int64) error {
 
 // DestroyPhysicians will destroy Physician records those specified by the ids parameters.
 func DestroyPhysicians(ids ...int64) (int64, error) {
+	return DestroyPhysiciansContext(context.Background(), ids...)
+}
+
+// DestroyPhysiciansContext is same as DestroyPhysicians but with the context ctx.
+func DestroyPhysiciansContext(ctx context.Context, ids ...int64) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyPhysicians", "physicians")
+	defer span.End()
 	if len(ids) == 0 {
-		msg := "At least one or more ids needed"
-		log.Println(msg)
-		return 0, errors.New(msg)
+		err := fmt.Errorf("DestroyPhysicians error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Physician")
+		return 0, err
 	}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
 	sql := fmt.Sprintf(`DELETE FROM physicians WHERE id IN (?%s)`, idsHolder)
@@ -697,8 +1250,11 @@ func DestroyPhysicians(ids ...int64) (int64, error) {
 	for _, id := range ids {
 		idsT = append(idsT, interface{}(id))
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(idsT...)
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, idsT...)
 	if err != nil {
 		return 0, err
 	}
@@ -713,14 +1269,24 @@ func DestroyPhysicians(ids ...int64) (int64, error) {
 // e.g. DestroyPhysiciansWhere("name = ?", "John")
 // And this func will not call the association dependent action
 func DestroyPhysiciansWhere(where string, args ...interface{}) (int64, error) {
+	return DestroyPhysiciansWhereContext(context.Background(), where, args...)
+}
+
+// DestroyPhysiciansWhereContext is same as DestroyPhysiciansWhere but with the context ctx.
+func DestroyPhysiciansWhereContext(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyPhysiciansWhere", "physicians")
+	defer span.End()
 	sql := `DELETE FROM physicians WHERE `
 	if len(where) > 0 {
 		sql = sql + where
 	} else {
-		return 0, errors.New("No WHERE conditions provided")
+		return 0, fmt.Errorf("DestroyPhysiciansWhere error: %w", ErrNoWhere)
+	}
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
+	if err != nil {
+		return 0, err
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(args...)
+	result, err := stmt.Exec(ctx, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -732,34 +1298,149 @@ func DestroyPhysiciansWhere(where string, args ...interface{}) (int64, error) {
 }
 
 // Save method is used for a Physician object to update an existed record mainly.
-// If no id provided a new record will be created. FIXME: A UPSERT action will be implemented further.
+// If no id provided a new record will be created, and if the record of the id doesn't exist
+// it will be created with the id.
+// A loaded object only updates its changed columns, see ChangedFields.
+// The object is reloaded from the database after it's saved, its loaded associations are kept.
+// It runs the BeforeValidate, BeforeUpdate, AfterUpdate and AfterCommit callbacks if implemented.
 func (_physician *Physician) Save() error {
+	return _physician.SaveContext(context.Background())
+}
+
+// SaveContext is same as Save but with the context ctx.
+func (_physician *Physician) SaveContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Physician.Save", "physicians")
+	defer span.End()
+	if _physician.Id == 0 {
+		_, err := _physician.CreateContext(ctx)
+		return err
+	}
+	if err := runBeforeValidate(_physician); err != nil {
+		return err
+	}
 	ok, err := govalidator.ValidateStruct(_physician)
 	if !ok {
-		errMsg := "Validate Physician struct error: Unknown error"
-		if err != nil {
-			errMsg = "Validate Physician struct error: " + err.Error()
-		}
-		log.Println(errMsg)
-		return errors.New(errMsg)
+		err = &ValidationError{Model: "Physician", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Physician")
+		return err
 	}
-	if _physician.Id == 0 {
-		_, err = _physician.Create()
+	if err = runBeforeUpdate(_physician); err != nil {
 		return err
 	}
+	old := _physician.original
+	if old != nil && !_physician.Changed() {
+		// a loaded object without changes has nothing to write
+		_physician.savedChanges = nil
+		return runAfterUpdate(ctx, _physician)
+	}
 	_physician.UpdatedAt = time.Now()
-	sqlFmt := `UPDATE physicians SET %s WHERE id = %v`
-	sqlStr := fmt.Sprintf(sqlFmt, "name = :name, updated_at = :updated_at, introduction = :introduction", _physician.Id)
-	_, err = DB.NamedExec(sqlStr, _physician)
-	return err
+	if old != nil {
+		// a loaded object only updates its changed columns
+		sqlFmt := `UPDATE physicians SET %s WHERE id = %v`
+		sqlStr := fmt.Sprintf(sqlFmt, namedSets(append(_physician.ChangedFields(), "updated_at")), _physician.Id)
+		_, err = dbNamedExec(ctx, DB, "Physician", sqlStr, _physician)
+	} else {
+		// an object not loaded updates all its columns, or it's created with the id if the record doesn't exist
+		if _physician.CreatedAt.IsZero() {
+			_physician.CreatedAt = _physician.UpdatedAt
+		}
+		_, err = upsertWithId(ctx, "Physician", "physicians", _physician.Id, _physician, []string{"id", "name", "created_at", "updated_at", "introduction", "time_zone"}, []string{"name", "updated_at", "introduction", "time_zone"})
+	}
+	if err != nil {
+		return err
+	}
+	if err = _physician.reloadColumns(ctx); err != nil {
+		return err
+	}
+	_physician.savedChanges = diffAttributes(old, _physician.attributes())
+	return runAfterUpdate(ctx, _physician)
+}
+
+// UpsertPhysician use a named params to create a single Physician record, or to update the updateColumns
+// of the existed record instead if it conflicts with the params on the conflictColumns.
+// The id of the created or updated record is returned. With MySQL the conflict is detected
+// on any unique key of the table, the conflictColumns is only used by PostgreSQL and SQLite, so the
+// record conflicting on another unique key than the conflictColumns is updated and its id is returned.
+func UpsertPhysician(am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	return UpsertPhysicianContext(context.Background(), am, conflictColumns, updateColumns)
+}
+
+// UpsertPhysicianContext is same as UpsertPhysician but with the context ctx.
+func UpsertPhysicianContext(ctx context.Context, am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	ctx, span := startSpan(ctx, "UpsertPhysician", "physicians")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("UpsertPhysician error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	return upsert(ctx, "Physician", "physicians", am, allKeys(am), conflictColumns, updateColumns)
+}
+
+// Upsert is a method for Physician to create a record, or to update the updateColumns of the existed
+// record instead if it conflicts with the object on the conflictColumns, see UpsertPhysician.
+// The Id of the object is set to the id of the created or updated record.
+// It runs the BeforeValidate callback if implemented.
+func (_physician *Physician) Upsert(conflictColumns, updateColumns []string) error {
+	return _physician.UpsertContext(context.Background(), conflictColumns, updateColumns)
+}
+
+// UpsertContext is same as Upsert but with the context ctx.
+func (_physician *Physician) UpsertContext(ctx context.Context, conflictColumns, updateColumns []string) error {
+	ctx, span := startSpan(ctx, "Physician.Upsert", "physicians")
+	defer span.End()
+	if err := runBeforeValidate(_physician); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_physician)
+	if !ok {
+		err = &ValidationError{Model: "Physician", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Physician")
+		return err
+	}
+	t := time.Now()
+	if _physician.CreatedAt.IsZero() {
+		_physician.CreatedAt = t
+	}
+	_physician.UpdatedAt = t
+	keys := []string{"name", "created_at", "updated_at", "introduction", "time_zone"}
+	if _physician.Id != 0 {
+		keys = append([]string{"id"}, keys...)
+	}
+	id, err := upsert(ctx, "Physician", "physicians", _physician, keys, conflictColumns, updateColumns)
+	if err != nil {
+		return err
+	}
+	_physician.Id = id
+	_physician.snapshot()
+	return nil
 }
 
 // UpdatePhysician is used to update a record with a id and map[string]interface{} typed key-value parameters.
 func UpdatePhysician(id int64, am map[string]interface{}) error {
+	return UpdatePhysicianContext(context.Background(), id, am)
+}
+
+// UpdatePhysicianContext is same as UpdatePhysician but with the context ctx.
+func UpdatePhysicianContext(ctx context.Context, id int64, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "UpdatePhysician", "physicians")
+	defer span.End()
 	if len(am) == 0 {
-		return errors.New("Zero key in the attributes map!")
+		return fmt.Errorf("UpdatePhysician error: %w", ErrEmptyAttributes)
 	}
 	am["updated_at"] = time.Now()
+	return updatePhysicianColumns(ctx, id, am)
+}
+
+// updatePhysicianColumns is used to update the columns of a record with a id as they are in the attributes map.
+func updatePhysicianColumns(ctx context.Context, id int64, am map[string]interface{}) error {
+	if len(am) == 0 {
+		return fmt.Errorf("Physician.UpdateColumns error: %w", ErrEmptyAttributes)
+	}
 	keys := allKeys(am)
 	sqlFmt := `UPDATE physicians SET %s WHERE id = %v`
 	setKeysArr := []string{}
@@ -768,49 +1449,105 @@ func UpdatePhysician(id int64, am map[string]interface{}) error {
 		setKeysArr = append(setKeysArr, s)
 	}
 	sqlStr := fmt.Sprintf(sqlFmt, strings.Join(setKeysArr, ", "), id)
-	_, err := DB.NamedExec(sqlStr, am)
+	_, err := dbNamedExec(ctx, DB, "Physician", sqlStr, am)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 	return nil
 }
 
 // Update is a method used to update a Physician record with the map[string]interface{} typed key-value parameters.
+// The object is reloaded from the database after it's updated, its loaded associations are kept.
 func (_physician *Physician) Update(am map[string]interface{}) error {
+	return _physician.UpdateContext(context.Background(), am)
+}
+
+// UpdateContext is same as Update but with the context ctx.
+func (_physician *Physician) UpdateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Physician.Update", "physicians")
+	defer span.End()
 	if _physician.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Physician.Update error: %w", ErrInvalidID)
 	}
-	err := UpdatePhysician(_physician.Id, am)
-	return err
+	if err := runBeforeUpdate(_physician); err != nil {
+		return err
+	}
+	old := _physician.attributes()
+	err := UpdatePhysicianContext(ctx, _physician.Id, am)
+	if err != nil {
+		return err
+	}
+	if err = _physician.reloadColumns(ctx); err != nil {
+		return err
+	}
+	_physician.savedChanges = diffAttributes(old, _physician.attributes())
+	return runAfterUpdate(ctx, _physician)
 }
 
 // UpdateAttributes method is supposed to be used to update Physician records as corresponding update_attributes in Ruby on Rails.
+// The attributes are assigned to the object which is then saved, i.e. it's validated,
+// the callbacks are run and the updated_at is stamped, see Save.
 func (_physician *Physician) UpdateAttributes(am map[string]interface{}) error {
+	return _physician.UpdateAttributesContext(context.Background(), am)
+}
+
+// UpdateAttributesContext is same as UpdateAttributes but with the context ctx.
+func (_physician *Physician) UpdateAttributesContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Physician.UpdateAttributes", "physicians")
+	defer span.End()
 	if _physician.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Physician.UpdateAttributes error: %w", ErrInvalidID)
 	}
-	err := UpdatePhysician(_physician.Id, am)
-	return err
+	if err := assignColumns(_physician, am); err != nil {
+		return err
+	}
+	return _physician.SaveContext(ctx)
 }
 
 // UpdateColumns method is supposed to be used to update Physician records as corresponding update_columns in Ruby on Rails.
+// The columns are written as they are, skipping the validation, the callbacks and the updated_at
+// stamp, and then assigned to the object. A value which can't be assigned fails before anything is written.
 func (_physician *Physician) UpdateColumns(am map[string]interface{}) error {
+	return _physician.UpdateColumnsContext(context.Background(), am)
+}
+
+// UpdateColumnsContext is same as UpdateColumns but with the context ctx.
+func (_physician *Physician) UpdateColumnsContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Physician.UpdateColumns", "physicians")
+	defer span.End()
 	if _physician.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Physician.UpdateColumns error: %w", ErrInvalidID)
 	}
-	err := UpdatePhysician(_physician.Id, am)
-	return err
+	assigned := *_physician
+	if err := assignColumns(&assigned, am); err != nil {
+		return err
+	}
+	if err := updatePhysicianColumns(ctx, _physician.Id, am); err != nil {
+		return err
+	}
+	*_physician = assigned
+	markSaved(_physician.original, _physician.attributes(), allKeys(am))
+	return nil
 }
 
 // UpdatePhysiciansBySql is used to update Physician records by a SQL clause
 // using the '?' binding syntax.
 func UpdatePhysiciansBySql(sql string, args ...interface{}) (int64, error) {
+	return UpdatePhysiciansBySqlContext(context.Background(), sql, args...)
+}
+
+// UpdatePhysiciansBySqlContext is same as UpdatePhysiciansBySql but with the context ctx.
+func UpdatePhysiciansBySqlContext(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "UpdatePhysiciansBySql", "physicians")
+	defer span.End()
 	if sql == "" {
-		return 0, errors.New("A blank SQL clause")
+		return 0, fmt.Errorf("UpdatePhysiciansBySql error: %w", ErrBlankSQL)
+	}
+	stmt, err := prepare(ctx, "Physician", rebind(sql))
+	if err != nil {
+		return 0, err
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(args...)
+	result, err := stmt.Exec(ctx, args...)
 	if err != nil {
 		return 0, err
 	}
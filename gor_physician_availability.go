@@ -0,0 +1,1283 @@
+// Package models includes the functions on the model PhysicianAvailability.
+package models
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
+)
+
+// PhysicianAvailability is the model of the table physician_availabilities, the working hours of
+// a physician. A weekly one recurs on the Weekday, an extra or closed one is an exception on the
+// Date, see FindOpenSlots. StartTime and EndTime are the times of day like "09:00" in the time zone
+// of the physician, a closed one without them covers the whole day. The Date is a calendar date,
+// set it at midnight UTC, e.g. time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC).
+type PhysicianAvailability struct {
+	Id           int64      `json:"id,omitempty" db:"id" valid:"-"`
+	PhysicianId  int64      `json:"physician_id,omitempty" db:"physician_id" valid:"required"`
+	Kind         string     `json:"kind,omitempty" db:"kind" valid:"required,in(weekly|extra|closed)"`
+	Weekday      int64      `json:"weekday,omitempty" db:"weekday" valid:"range(0|6)"`
+	Date         *time.Time `json:"date,omitempty" db:"date" valid:"-"`
+	StartTime    string     `json:"start_time,omitempty" db:"start_time" valid:"-"`
+	EndTime      string     `json:"end_time,omitempty" db:"end_time" valid:"-"`
+	Note         string     `json:"note,omitempty" db:"note" valid:"-"`
+	CreatedAt    time.Time  `json:"created_at,omitempty" db:"created_at" valid:"-"`
+	UpdatedAt    time.Time  `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
+	original     map[string]interface{}
+	savedChanges map[string]Change
+}
+
+// attributes returns the values of the changeable columns of the PhysicianAvailability object by column names.
+func (_physicianAvailability *PhysicianAvailability) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"physician_id": _physicianAvailability.PhysicianId,
+		"kind":         _physicianAvailability.Kind,
+		"weekday":      _physicianAvailability.Weekday,
+		"date":         nullableValue(_physicianAvailability.Date),
+		"start_time":   _physicianAvailability.StartTime,
+		"end_time":     _physicianAvailability.EndTime,
+		"note":         _physicianAvailability.Note,
+	}
+}
+
+// snapshot keeps the current attributes of the PhysicianAvailability object as the original ones for the dirty tracking.
+func (_physicianAvailability *PhysicianAvailability) snapshot() {
+	_physicianAvailability.original = _physicianAvailability.attributes()
+}
+
+// snapshotPhysicianAvailabilities keeps the current attributes of the loaded PhysicianAvailability objects as the original ones.
+func snapshotPhysicianAvailabilities(_physicianAvailabilities []PhysicianAvailability) {
+	for i := range _physicianAvailabilities {
+		_physicianAvailabilities[i].snapshot()
+	}
+}
+
+// Changed reports whether any attribute of the PhysicianAvailability object is changed since it was loaded
+// or saved. An object which is not loaded from the database is always changed.
+func (_physicianAvailability *PhysicianAvailability) Changed() bool {
+	return len(_physicianAvailability.ChangedFields()) > 0
+}
+
+// ChangedFields returns the sorted column names of the changed attributes of the PhysicianAvailability object.
+func (_physicianAvailability *PhysicianAvailability) ChangedFields() []string {
+	return changedFields(_physicianAvailability.original, _physicianAvailability.attributes())
+}
+
+// Changes returns the old and new values of the changed attributes of the PhysicianAvailability object by column names.
+func (_physicianAvailability *PhysicianAvailability) Changes() map[string]Change {
+	return diffAttributes(_physicianAvailability.original, _physicianAvailability.attributes())
+}
+
+// SavedChanges returns the changes written by the last Create, Save, Update or UpdateAttributes
+// of the PhysicianAvailability object, e.g. for an After callback to audit them.
+func (_physicianAvailability *PhysicianAvailability) SavedChanges() map[string]Change {
+	return _physicianAvailability.savedChanges
+}
+
+// DataStruct for the pagination
+type PhysicianAvailabilityPage struct {
+	WhereString string
+	WhereParams []interface{}
+	Order       map[string]string
+	FirstId     int64
+	LastId      int64
+	PageNum     int
+	PerPage     int
+	TotalPages  int
+	TotalItems  int64
+	orderStr    string
+}
+
+// Current get the current page of PhysicianAvailabilityPage object for pagination.
+func (_p *PhysicianAvailabilityPage) Current() ([]PhysicianAvailability, error) {
+	return _p.CurrentContext(context.Background())
+}
+
+// CurrentContext is same as Current but with the context ctx.
+func (_p *PhysicianAvailabilityPage) CurrentContext(ctx context.Context) ([]PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityPage.Current", "physician_availabilities")
+	defer span.End()
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("PhysicianAvailabilityPage.Current error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("current")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	physicianAvailabilities, err := FindPhysicianAvailabilitiesWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(physicianAvailabilities) != 0 {
+		_p.FirstId, _p.LastId = physicianAvailabilities[0].Id, physicianAvailabilities[len(physicianAvailabilities)-1].Id
+	}
+	return physicianAvailabilities, nil
+}
+
+// Previous get the previous page of PhysicianAvailabilityPage object for pagination.
+func (_p *PhysicianAvailabilityPage) Previous() ([]PhysicianAvailability, error) {
+	return _p.PreviousContext(context.Background())
+}
+
+// PreviousContext is same as Previous but with the context ctx.
+func (_p *PhysicianAvailabilityPage) PreviousContext(ctx context.Context) ([]PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityPage.Previous", "physician_availabilities")
+	defer span.End()
+	if _p.PageNum == 0 {
+		return nil, fmt.Errorf("PhysicianAvailabilityPage.Previous error: %w", ErrFirstPage)
+	}
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("PhysicianAvailabilityPage.Previous error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("previous")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	physicianAvailabilities, err := FindPhysicianAvailabilitiesWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(physicianAvailabilities) != 0 {
+		_p.FirstId, _p.LastId = physicianAvailabilities[0].Id, physicianAvailabilities[len(physicianAvailabilities)-1].Id
+	}
+	_p.PageNum -= 1
+	return physicianAvailabilities, nil
+}
+
+// Next get the next page of PhysicianAvailabilityPage object for pagination.
+func (_p *PhysicianAvailabilityPage) Next() ([]PhysicianAvailability, error) {
+	return _p.NextContext(context.Background())
+}
+
+// NextContext is same as Next but with the context ctx.
+func (_p *PhysicianAvailabilityPage) NextContext(ctx context.Context) ([]PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityPage.Next", "physician_availabilities")
+	defer span.End()
+	if _p.PageNum == _p.TotalPages-1 {
+		return nil, fmt.Errorf("PhysicianAvailabilityPage.Next error: %w", ErrLastPage)
+	}
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("PhysicianAvailabilityPage.Next error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("next")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	physicianAvailabilities, err := FindPhysicianAvailabilitiesWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(physicianAvailabilities) != 0 {
+		_p.FirstId, _p.LastId = physicianAvailabilities[0].Id, physicianAvailabilities[len(physicianAvailabilities)-1].Id
+	}
+	_p.PageNum += 1
+	return physicianAvailabilities, nil
+}
+
+// GetPage is a helper function for the PhysicianAvailabilityPage object to return a corresponding page due to
+// the parameter passed in, i.e. one of "previous, current or next".
+func (_p *PhysicianAvailabilityPage) GetPage(direction string) (ps []PhysicianAvailability, err error) {
+	return _p.GetPageContext(context.Background(), direction)
+}
+
+// GetPageContext is same as GetPage but with the context ctx.
+func (_p *PhysicianAvailabilityPage) GetPageContext(ctx context.Context, direction string) (ps []PhysicianAvailability, err error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityPage.GetPage", "physician_availabilities")
+	defer span.End()
+	switch direction {
+	case "previous":
+		ps, err = _p.PreviousContext(ctx)
+	case "next":
+		ps, err = _p.NextContext(ctx)
+	case "current":
+		ps, err = _p.CurrentContext(ctx)
+	default:
+		return nil, fmt.Errorf("PhysicianAvailabilityPage.GetPage error: %w: %q", ErrWrongDirection, direction)
+	}
+	return
+}
+
+// buildOrder is for PhysicianAvailabilityPage object to build a SQL ORDER BY clause.
+func (_p *PhysicianAvailabilityPage) buildOrder() {
+	tempList := []string{}
+	for k, v := range _p.Order {
+		tempList = append(tempList, fmt.Sprintf("%v %v", k, v))
+	}
+	_p.orderStr = " ORDER BY " + strings.Join(tempList, ", ")
+}
+
+// buildIdRestrict is for PhysicianAvailabilityPage object to build a SQL clause for ID restriction,
+// implementing a simple keyset style pagination.
+func (_p *PhysicianAvailabilityPage) buildIdRestrict(direction string) (idStr string, idParams []interface{}) {
+	switch direction {
+	case "previous":
+		if strings.ToLower(_p.Order["id"]) == "desc" {
+			idStr += "id > ? "
+			idParams = append(idParams, _p.FirstId)
+		} else {
+			idStr += "id < ? "
+			idParams = append(idParams, _p.FirstId)
+		}
+	case "current":
+		// trick to make Where function work
+		if _p.PageNum == 0 && _p.FirstId == 0 && _p.LastId == 0 {
+			idStr += "id > ? "
+			idParams = append(idParams, 0)
+		} else {
+			if strings.ToLower(_p.Order["id"]) == "desc" {
+				idStr += "id <= ? AND id >= ? "
+				idParams = append(idParams, _p.FirstId, _p.LastId)
+			} else {
+				idStr += "id >= ? AND id <= ? "
+				idParams = append(idParams, _p.FirstId, _p.LastId)
+			}
+		}
+	case "next":
+		if strings.ToLower(_p.Order["id"]) == "desc" {
+			idStr += "id < ? "
+			idParams = append(idParams, _p.LastId)
+		} else {
+			idStr += "id > ? "
+			idParams = append(idParams, _p.LastId)
+		}
+	}
+	if _p.WhereString != "" {
+		idStr = " AND " + idStr
+	}
+	return
+}
+
+// buildPageCount calculate the TotalItems/TotalPages for the PhysicianAvailabilityPage object.
+func (_p *PhysicianAvailabilityPage) buildPageCount(ctx context.Context) error {
+	count, err := PhysicianAvailabilityCountWhereContext(ctx, _p.WhereString, _p.WhereParams...)
+	if err != nil {
+		return err
+	}
+	_p.TotalItems = count
+	if _p.PerPage == 0 {
+		_p.PerPage = 10
+	}
+	_p.TotalPages = int(math.Ceil(float64(_p.TotalItems) / float64(_p.PerPage)))
+	return nil
+}
+
+// FindPhysicianAvailability find a single physician availability by an ID.
+func FindPhysicianAvailability(id int64) (*PhysicianAvailability, error) {
+	return FindPhysicianAvailabilityContext(context.Background(), id)
+}
+
+// FindPhysicianAvailabilityContext is same as FindPhysicianAvailability but with the context ctx.
+func FindPhysicianAvailabilityContext(ctx context.Context, id int64) (*PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailability", "physician_availabilities")
+	defer span.End()
+	if id == 0 {
+		return nil, fmt.Errorf("FindPhysicianAvailability error: %w", ErrInvalidID)
+	}
+	_physicianAvailability := PhysicianAvailability{}
+	err := dbGet(ctx, DB, "PhysicianAvailability", &_physicianAvailability, rebind(`SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities WHERE physician_availabilities.id = ? LIMIT 1`), id)
+	if err != nil {
+		return nil, err
+	}
+	_physicianAvailability.snapshot()
+	return &_physicianAvailability, nil
+}
+
+// FirstPhysicianAvailability find the first one physician availability by ID ASC order.
+func FirstPhysicianAvailability() (*PhysicianAvailability, error) {
+	return FirstPhysicianAvailabilityContext(context.Background())
+}
+
+// FirstPhysicianAvailabilityContext is same as FirstPhysicianAvailability but with the context ctx.
+func FirstPhysicianAvailabilityContext(ctx context.Context) (*PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "FirstPhysicianAvailability", "physician_availabilities")
+	defer span.End()
+	_physicianAvailability := PhysicianAvailability{}
+	err := dbGet(ctx, DB, "PhysicianAvailability", &_physicianAvailability, rebind(`SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities ORDER BY physician_availabilities.id ASC LIMIT 1`))
+	if err != nil {
+		return nil, err
+	}
+	_physicianAvailability.snapshot()
+	return &_physicianAvailability, nil
+}
+
+// FirstPhysicianAvailabilities find the first N physician availabilities by ID ASC order.
+func FirstPhysicianAvailabilities(n uint32) ([]PhysicianAvailability, error) {
+	return FirstPhysicianAvailabilitiesContext(context.Background(), n)
+}
+
+// FirstPhysicianAvailabilitiesContext is same as FirstPhysicianAvailabilities but with the context ctx.
+func FirstPhysicianAvailabilitiesContext(ctx context.Context, n uint32) ([]PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "FirstPhysicianAvailabilities", "physician_availabilities")
+	defer span.End()
+	_physicianAvailabilities := []PhysicianAvailability{}
+	sql := fmt.Sprintf("SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities ORDER BY physician_availabilities.id ASC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "PhysicianAvailability", &_physicianAvailabilities, rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicianAvailabilities(_physicianAvailabilities)
+	return _physicianAvailabilities, nil
+}
+
+// LastPhysicianAvailability find the last one physician availability by ID DESC order.
+func LastPhysicianAvailability() (*PhysicianAvailability, error) {
+	return LastPhysicianAvailabilityContext(context.Background())
+}
+
+// LastPhysicianAvailabilityContext is same as LastPhysicianAvailability but with the context ctx.
+func LastPhysicianAvailabilityContext(ctx context.Context) (*PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "LastPhysicianAvailability", "physician_availabilities")
+	defer span.End()
+	_physicianAvailability := PhysicianAvailability{}
+	err := dbGet(ctx, DB, "PhysicianAvailability", &_physicianAvailability, rebind(`SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities ORDER BY physician_availabilities.id DESC LIMIT 1`))
+	if err != nil {
+		return nil, err
+	}
+	_physicianAvailability.snapshot()
+	return &_physicianAvailability, nil
+}
+
+// LastPhysicianAvailabilities find the last N physician availabilities by ID DESC order.
+func LastPhysicianAvailabilities(n uint32) ([]PhysicianAvailability, error) {
+	return LastPhysicianAvailabilitiesContext(context.Background(), n)
+}
+
+// LastPhysicianAvailabilitiesContext is same as LastPhysicianAvailabilities but with the context ctx.
+func LastPhysicianAvailabilitiesContext(ctx context.Context, n uint32) ([]PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "LastPhysicianAvailabilities", "physician_availabilities")
+	defer span.End()
+	_physicianAvailabilities := []PhysicianAvailability{}
+	sql := fmt.Sprintf("SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities ORDER BY physician_availabilities.id DESC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "PhysicianAvailability", &_physicianAvailabilities, rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicianAvailabilities(_physicianAvailabilities)
+	return _physicianAvailabilities, nil
+}
+
+// FindPhysicianAvailabilities find one or more physician availabilities by the given ID(s).
+func FindPhysicianAvailabilities(ids ...int64) ([]PhysicianAvailability, error) {
+	return FindPhysicianAvailabilitiesContext(context.Background(), ids...)
+}
+
+// FindPhysicianAvailabilitiesContext is same as FindPhysicianAvailabilities but with the context ctx.
+func FindPhysicianAvailabilitiesContext(ctx context.Context, ids ...int64) ([]PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilities", "physician_availabilities")
+	defer span.End()
+	if len(ids) == 0 {
+		err := fmt.Errorf("FindPhysicianAvailabilities error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "PhysicianAvailability")
+		return nil, err
+	}
+	_physicianAvailabilities := []PhysicianAvailability{}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	sql := rebind(fmt.Sprintf(`SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities WHERE physician_availabilities.id IN (?%s)`, idsHolder))
+	idsT := []interface{}{}
+	for _, id := range ids {
+		idsT = append(idsT, interface{}(id))
+	}
+	err := dbSelect(ctx, DB, "PhysicianAvailability", &_physicianAvailabilities, sql, idsT...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicianAvailabilities(_physicianAvailabilities)
+	return _physicianAvailabilities, nil
+}
+
+// FindPhysicianAvailabilityBy find a single physician availability by a field name and a value.
+func FindPhysicianAvailabilityBy(field string, val interface{}) (*PhysicianAvailability, error) {
+	return FindPhysicianAvailabilityByContext(context.Background(), field, val)
+}
+
+// FindPhysicianAvailabilityByContext is same as FindPhysicianAvailabilityBy but with the context ctx.
+func FindPhysicianAvailabilityByContext(ctx context.Context, field string, val interface{}) (*PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilityBy", "physician_availabilities")
+	defer span.End()
+	_physicianAvailability := PhysicianAvailability{}
+	sqlFmt := `SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities WHERE %s = ? LIMIT 1`
+	sqlStr := fmt.Sprintf(sqlFmt, field)
+	err := dbGet(ctx, DB, "PhysicianAvailability", &_physicianAvailability, rebind(sqlStr), val)
+	if err != nil {
+		return nil, err
+	}
+	_physicianAvailability.snapshot()
+	return &_physicianAvailability, nil
+}
+
+// FindPhysicianAvailabilitiesBy find all physician availabilities by a field name and a value.
+func FindPhysicianAvailabilitiesBy(field string, val interface{}) (_physicianAvailabilities []PhysicianAvailability, err error) {
+	return FindPhysicianAvailabilitiesByContext(context.Background(), field, val)
+}
+
+// FindPhysicianAvailabilitiesByContext is same as FindPhysicianAvailabilitiesBy but with the context ctx.
+func FindPhysicianAvailabilitiesByContext(ctx context.Context, field string, val interface{}) (_physicianAvailabilities []PhysicianAvailability, err error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilitiesBy", "physician_availabilities")
+	defer span.End()
+	sqlFmt := `SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities WHERE %s = ?`
+	sqlStr := fmt.Sprintf(sqlFmt, field)
+	err = dbSelect(ctx, DB, "PhysicianAvailability", &_physicianAvailabilities, rebind(sqlStr), val)
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicianAvailabilities(_physicianAvailabilities)
+	return _physicianAvailabilities, nil
+}
+
+// AllPhysicianAvailabilities get all the PhysicianAvailability records.
+func AllPhysicianAvailabilities() (physicianAvailabilities []PhysicianAvailability, err error) {
+	return AllPhysicianAvailabilitiesContext(context.Background())
+}
+
+// AllPhysicianAvailabilitiesContext is same as AllPhysicianAvailabilities but with the context ctx.
+func AllPhysicianAvailabilitiesContext(ctx context.Context) (physicianAvailabilities []PhysicianAvailability, err error) {
+	ctx, span := startSpan(ctx, "AllPhysicianAvailabilities", "physician_availabilities")
+	defer span.End()
+	err = dbSelect(ctx, DB, "PhysicianAvailability", &physicianAvailabilities, "SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities")
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicianAvailabilities(physicianAvailabilities)
+	return physicianAvailabilities, nil
+}
+
+// PhysicianAvailabilityCount get the count of all the PhysicianAvailability records.
+func PhysicianAvailabilityCount() (c int64, err error) {
+	return PhysicianAvailabilityCountContext(context.Background())
+}
+
+// PhysicianAvailabilityCountContext is same as PhysicianAvailabilityCount but with the context ctx.
+func PhysicianAvailabilityCountContext(ctx context.Context) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityCount", "physician_availabilities")
+	defer span.End()
+	err = dbGet(ctx, DB, "PhysicianAvailability", &c, "SELECT count(*) FROM physician_availabilities")
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+// PhysicianAvailabilityCountWhere get the count of all the PhysicianAvailability records with a where clause.
+func PhysicianAvailabilityCountWhere(where string, args ...interface{}) (c int64, err error) {
+	return PhysicianAvailabilityCountWhereContext(context.Background(), where, args...)
+}
+
+// PhysicianAvailabilityCountWhereContext is same as PhysicianAvailabilityCountWhere but with the context ctx.
+func PhysicianAvailabilityCountWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityCountWhere", "physician_availabilities")
+	defer span.End()
+	sql := "SELECT count(*) FROM physician_availabilities"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	err = stmt.Get(ctx, &c, args...)
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+// PhysicianAvailabilityIncludesWhere get the PhysicianAvailability associated models records, currently it's not same as the corresponding "includes" function but "preload" instead in Ruby on Rails. It means that the "sql" should be restricted on PhysicianAvailability model.
+func PhysicianAvailabilityIncludesWhere(assocs []string, sql string, args ...interface{}) (_physicianAvailabilities []PhysicianAvailability, err error) {
+	return PhysicianAvailabilityIncludesWhereContext(context.Background(), assocs, sql, args...)
+}
+
+// PhysicianAvailabilityIncludesWhereContext is same as PhysicianAvailabilityIncludesWhere but with the context ctx.
+func PhysicianAvailabilityIncludesWhereContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_physicianAvailabilities []PhysicianAvailability, err error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityIncludesWhere", "physician_availabilities")
+	defer span.End()
+	_physicianAvailabilities, err = FindPhysicianAvailabilitiesWhereContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(assocs) == 0 {
+		logger().Debug("No associated fields are specified", "model", "PhysicianAvailability")
+		return _physicianAvailabilities, err
+	}
+	if len(_physicianAvailabilities) <= 0 {
+		return nil, fmt.Errorf("PhysicianAvailabilityIncludesWhere error: %w", ErrNotFound)
+	}
+	ids := make([]interface{}, len(_physicianAvailabilities))
+	for _, v := range _physicianAvailabilities {
+		ids = append(ids, interface{}(v.Id))
+	}
+	return _physicianAvailabilities, nil
+}
+
+// PhysicianAvailabilityIds get all the IDs of PhysicianAvailability records.
+func PhysicianAvailabilityIds() (ids []int64, err error) {
+	return PhysicianAvailabilityIdsContext(context.Background())
+}
+
+// PhysicianAvailabilityIdsContext is same as PhysicianAvailabilityIds but with the context ctx.
+func PhysicianAvailabilityIdsContext(ctx context.Context) (ids []int64, err error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityIds", "physician_availabilities")
+	defer span.End()
+	err = dbSelect(ctx, DB, "PhysicianAvailability", &ids, "SELECT id FROM physician_availabilities")
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// PhysicianAvailabilityIdsWhere get all the IDs of PhysicianAvailability records by where restriction.
+func PhysicianAvailabilityIdsWhere(where string, args ...interface{}) ([]int64, error) {
+	return PhysicianAvailabilityIdsWhereContext(context.Background(), where, args...)
+}
+
+// PhysicianAvailabilityIdsWhereContext is same as PhysicianAvailabilityIdsWhere but with the context ctx.
+func PhysicianAvailabilityIdsWhereContext(ctx context.Context, where string, args ...interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityIdsWhere", "physician_availabilities")
+	defer span.End()
+	ids, err := PhysicianAvailabilityIntColContext(ctx, "id", where, args...)
+	return ids, err
+}
+
+// PhysicianAvailabilityIntCol get some int64 typed column of PhysicianAvailability by where restriction.
+func PhysicianAvailabilityIntCol(col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	return PhysicianAvailabilityIntColContext(context.Background(), col, where, args...)
+}
+
+// PhysicianAvailabilityIntColContext is same as PhysicianAvailabilityIntCol but with the context ctx.
+func PhysicianAvailabilityIntColContext(ctx context.Context, col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityIntCol", "physician_availabilities")
+	defer span.End()
+	sql := "SELECT " + col + " FROM physician_availabilities"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &intColRecs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return intColRecs, nil
+}
+
+// PhysicianAvailabilityStrCol get some string typed column of PhysicianAvailability by where restriction.
+func PhysicianAvailabilityStrCol(col, where string, args ...interface{}) (strColRecs []string, err error) {
+	return PhysicianAvailabilityStrColContext(context.Background(), col, where, args...)
+}
+
+// PhysicianAvailabilityStrColContext is same as PhysicianAvailabilityStrCol but with the context ctx.
+func PhysicianAvailabilityStrColContext(ctx context.Context, col, where string, args ...interface{}) (strColRecs []string, err error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailabilityStrCol", "physician_availabilities")
+	defer span.End()
+	sql := "SELECT " + col + " FROM physician_availabilities"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &strColRecs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return strColRecs, nil
+}
+
+// FindPhysicianAvailabilitiesWhere query use a partial SQL clause that usually following after WHERE
+// with placeholders, eg: FindUsersWhere("first_name = ? AND age > ?", "John", 18)
+// will return those records in the table "users" whose first_name is "John" and age elder than 18.
+func FindPhysicianAvailabilitiesWhere(where string, args ...interface{}) (physicianAvailabilities []PhysicianAvailability, err error) {
+	return FindPhysicianAvailabilitiesWhereContext(context.Background(), where, args...)
+}
+
+// FindPhysicianAvailabilitiesWhereContext is same as FindPhysicianAvailabilitiesWhere but with the context ctx.
+func FindPhysicianAvailabilitiesWhereContext(ctx context.Context, where string, args ...interface{}) (physicianAvailabilities []PhysicianAvailability, err error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilitiesWhere", "physician_availabilities")
+	defer span.End()
+	sql := "SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &physicianAvailabilities, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicianAvailabilities(physicianAvailabilities)
+	return physicianAvailabilities, nil
+}
+
+// FindPhysicianAvailabilityBySql query use a complete SQL clause
+// with placeholders, eg: FindUserBySql("SELECT * FROM users WHERE first_name = ? AND age > ? ORDER BY DESC LIMIT 1", "John", 18)
+// will return only One record in the table "users" whose first_name is "John" and age elder than 18.
+func FindPhysicianAvailabilityBySql(sql string, args ...interface{}) (*PhysicianAvailability, error) {
+	return FindPhysicianAvailabilityBySqlContext(context.Background(), sql, args...)
+}
+
+// FindPhysicianAvailabilityBySqlContext is same as FindPhysicianAvailabilityBySql but with the context ctx.
+func FindPhysicianAvailabilityBySqlContext(ctx context.Context, sql string, args ...interface{}) (*PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilityBySql", "physician_availabilities")
+	defer span.End()
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	_physicianAvailability := &PhysicianAvailability{}
+	err = stmt.Get(ctx, _physicianAvailability, args...)
+	if err != nil {
+		return nil, err
+	}
+	_physicianAvailability.snapshot()
+	return _physicianAvailability, nil
+}
+
+// FindPhysicianAvailabilitiesBySql query use a complete SQL clause
+// with placeholders, eg: FindUsersBySql("SELECT * FROM users WHERE first_name = ? AND age > ?", "John", 18)
+// will return those records in the table "users" whose first_name is "John" and age elder than 18.
+func FindPhysicianAvailabilitiesBySql(sql string, args ...interface{}) (physicianAvailabilities []PhysicianAvailability, err error) {
+	return FindPhysicianAvailabilitiesBySqlContext(context.Background(), sql, args...)
+}
+
+// FindPhysicianAvailabilitiesBySqlContext is same as FindPhysicianAvailabilitiesBySql but with the context ctx.
+func FindPhysicianAvailabilitiesBySqlContext(ctx context.Context, sql string, args ...interface{}) (physicianAvailabilities []PhysicianAvailability, err error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilitiesBySql", "physician_availabilities")
+	defer span.End()
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &physicianAvailabilities, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicianAvailabilities(physicianAvailabilities)
+	return physicianAvailabilities, nil
+}
+
+// FindPhysicianAvailabilityForUpdate find a single physician availability by an ID in the transaction tx and lock its row
+// FOR UPDATE until the transaction ends.
+func FindPhysicianAvailabilityForUpdate(tx *sqlx.Tx, id int64) (*PhysicianAvailability, error) {
+	return FindPhysicianAvailabilityForUpdateContext(context.Background(), tx, id)
+}
+
+// FindPhysicianAvailabilityForUpdateContext is same as FindPhysicianAvailabilityForUpdate but with the context ctx.
+func FindPhysicianAvailabilityForUpdateContext(ctx context.Context, tx *sqlx.Tx, id int64) (*PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilityForUpdate", "physician_availabilities")
+	defer span.End()
+	return findPhysicianAvailabilityLock(ctx, tx, ForUpdate, id)
+}
+
+// FindPhysicianAvailabilityForShare find a single physician availability by an ID in the transaction tx and lock its row
+// FOR SHARE until the transaction ends.
+func FindPhysicianAvailabilityForShare(tx *sqlx.Tx, id int64) (*PhysicianAvailability, error) {
+	return FindPhysicianAvailabilityForShareContext(context.Background(), tx, id)
+}
+
+// FindPhysicianAvailabilityForShareContext is same as FindPhysicianAvailabilityForShare but with the context ctx.
+func FindPhysicianAvailabilityForShareContext(ctx context.Context, tx *sqlx.Tx, id int64) (*PhysicianAvailability, error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilityForShare", "physician_availabilities")
+	defer span.End()
+	return findPhysicianAvailabilityLock(ctx, tx, ForShare, id)
+}
+
+// findPhysicianAvailabilityLock find a single physician availability by an ID in the transaction tx with a row lock mode.
+func findPhysicianAvailabilityLock(ctx context.Context, tx *sqlx.Tx, mode LockMode, id int64) (*PhysicianAvailability, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("Lock PhysicianAvailability error: %w", ErrInvalidID)
+	}
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	_physicianAvailability := PhysicianAvailability{}
+	err = dbGet(ctx, tx, "PhysicianAvailability", &_physicianAvailability, tx.Rebind(`SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities WHERE physician_availabilities.id = ? LIMIT 1 `+lock), id)
+	if err != nil {
+		return nil, err
+	}
+	_physicianAvailability.snapshot()
+	return &_physicianAvailability, nil
+}
+
+// FindPhysicianAvailabilitiesWhereLock is same as FindPhysicianAvailabilitiesWhere but runs in the transaction tx and lock
+// the selected rows with the lock mode, e.g. ForUpdateSkipLocked, until the transaction ends.
+// The where clause should be a condition only, i.e. with no ORDER BY or LIMIT.
+func FindPhysicianAvailabilitiesWhereLock(tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (physicianAvailabilities []PhysicianAvailability, err error) {
+	return FindPhysicianAvailabilitiesWhereLockContext(context.Background(), tx, mode, where, args...)
+}
+
+// FindPhysicianAvailabilitiesWhereLockContext is same as FindPhysicianAvailabilitiesWhereLock but with the context ctx.
+func FindPhysicianAvailabilitiesWhereLockContext(ctx context.Context, tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (physicianAvailabilities []PhysicianAvailability, err error) {
+	ctx, span := startSpan(ctx, "FindPhysicianAvailabilitiesWhereLock", "physician_availabilities")
+	defer span.End()
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	sql := "SELECT COALESCE(physician_availabilities.physician_id, 0) AS physician_id, COALESCE(physician_availabilities.kind, '') AS kind, COALESCE(physician_availabilities.weekday, 0) AS weekday, physician_availabilities.date, COALESCE(physician_availabilities.start_time, '') AS start_time, COALESCE(physician_availabilities.end_time, '') AS end_time, COALESCE(physician_availabilities.note, '') AS note, physician_availabilities.id, physician_availabilities.created_at, physician_availabilities.updated_at FROM physician_availabilities"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	err = dbSelect(ctx, tx, "PhysicianAvailability", &physicianAvailabilities, tx.Rebind(sql+" "+lock), args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotPhysicianAvailabilities(physicianAvailabilities)
+	return physicianAvailabilities, nil
+}
+
+// CreatePhysicianAvailability use a named params to create a single PhysicianAvailability record.
+// A named params is key-value map like map[string]interface{}{"first_name": "John", "age": 23} .
+func CreatePhysicianAvailability(am map[string]interface{}) (int64, error) {
+	return CreatePhysicianAvailabilityContext(context.Background(), am)
+}
+
+// CreatePhysicianAvailabilityContext is same as CreatePhysicianAvailability but with the context ctx.
+func CreatePhysicianAvailabilityContext(ctx context.Context, am map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CreatePhysicianAvailability", "physician_availabilities")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("CreatePhysicianAvailability error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	keys := allKeys(am)
+	sqlFmt := `INSERT INTO physician_availabilities (%s) VALUES (%s)`
+	sql := fmt.Sprintf(sqlFmt, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
+	result, err := dbNamedExec(ctx, DB, "PhysicianAvailability", sql, am)
+	if err != nil {
+		return 0, err
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		logger().Error("Get the last insert id error", "model", "PhysicianAvailability", "error", err)
+		return 0, err
+	}
+	return lastId, nil
+}
+
+// CreatePhysicianAvailabilities creates the PhysicianAvailability records of the slice physician availabilities with multi-row INSERT statements,
+// see BulkInsertChunkSize. The structs are validated and time stamped, and their Id fields
+// are set to the ids of the created records, which are returned too. No callbacks are run.
+func CreatePhysicianAvailabilities(physicianAvailabilities []PhysicianAvailability) ([]int64, error) {
+	return CreatePhysicianAvailabilitiesContext(context.Background(), physicianAvailabilities)
+}
+
+// CreatePhysicianAvailabilitiesContext is same as CreatePhysicianAvailabilities but with the context ctx.
+func CreatePhysicianAvailabilitiesContext(ctx context.Context, physicianAvailabilities []PhysicianAvailability) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreatePhysicianAvailabilities", "physician_availabilities")
+	defer span.End()
+	t := time.Now()
+	rows := make([][]interface{}, len(physicianAvailabilities))
+	for i := range physicianAvailabilities {
+		_physicianAvailability := &physicianAvailabilities[i]
+		ok, err := govalidator.ValidateStruct(_physicianAvailability)
+		if !ok {
+			err = &ValidationError{Model: "PhysicianAvailability", Index: i, Err: err}
+			logger().Warn(err.Error(), "model", "PhysicianAvailability")
+			return nil, err
+		}
+		_physicianAvailability.CreatedAt = t
+		_physicianAvailability.UpdatedAt = t
+		rows[i] = []interface{}{_physicianAvailability.PhysicianId, _physicianAvailability.Kind, _physicianAvailability.Weekday, _physicianAvailability.Date, _physicianAvailability.StartTime, _physicianAvailability.EndTime, _physicianAvailability.Note, _physicianAvailability.CreatedAt, _physicianAvailability.UpdatedAt}
+	}
+	ids, err := bulkInsert(ctx, "PhysicianAvailability", "physician_availabilities", []string{"physician_id", "kind", "weekday", "date", "start_time", "end_time", "note", "created_at", "updated_at"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		physicianAvailabilities[i].Id = id
+		physicianAvailabilities[i].snapshot()
+	}
+	return ids, nil
+}
+
+// CreatePhysicianAvailabilitiesMaps use a slice of named params to create PhysicianAvailability records like CreatePhysicianAvailability does,
+// but with multi-row INSERT statements, see BulkInsertChunkSize. A column missing in some
+// of the maps gets its default value. The ids of the created records are returned in order.
+func CreatePhysicianAvailabilitiesMaps(ams []map[string]interface{}) ([]int64, error) {
+	return CreatePhysicianAvailabilitiesMapsContext(context.Background(), ams)
+}
+
+// CreatePhysicianAvailabilitiesMapsContext is same as CreatePhysicianAvailabilitiesMaps but with the context ctx.
+func CreatePhysicianAvailabilitiesMapsContext(ctx context.Context, ams []map[string]interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreatePhysicianAvailabilitiesMaps", "physician_availabilities")
+	defer span.End()
+	for i, am := range ams {
+		if len(am) == 0 {
+			return nil, fmt.Errorf("CreatePhysicianAvailabilitiesMaps error: %w at index %d", ErrEmptyAttributes, i)
+		}
+	}
+	keys, rows := bulkMapRows(ams)
+	return bulkInsert(ctx, "PhysicianAvailability", "physician_availabilities", keys, rows)
+}
+
+// Create is a method for PhysicianAvailability to create a record, the Id of the object is set to the new id.
+// It runs the BeforeValidate, BeforeCreate, AfterCreate and AfterCommit callbacks if implemented.
+func (_physicianAvailability *PhysicianAvailability) Create() (int64, error) {
+	return _physicianAvailability.CreateContext(context.Background())
+}
+
+// CreateContext is same as Create but with the context ctx.
+func (_physicianAvailability *PhysicianAvailability) CreateContext(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "PhysicianAvailability.Create", "physician_availabilities")
+	defer span.End()
+	if err := runBeforeValidate(_physicianAvailability); err != nil {
+		return 0, err
+	}
+	ok, err := govalidator.ValidateStruct(_physicianAvailability)
+	if !ok {
+		err = &ValidationError{Model: "PhysicianAvailability", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "PhysicianAvailability")
+		return 0, err
+	}
+	if err = runBeforeCreate(_physicianAvailability); err != nil {
+		return 0, err
+	}
+	t := time.Now()
+	_physicianAvailability.CreatedAt = t
+	_physicianAvailability.UpdatedAt = t
+	sql := `INSERT INTO physician_availabilities (physician_id,kind,weekday,date,start_time,end_time,note,created_at,updated_at) VALUES (:physician_id,:kind,:weekday,:date,:start_time,:end_time,:note,:created_at,:updated_at)`
+	result, err := dbNamedExec(ctx, DB, "PhysicianAvailability", sql, _physicianAvailability)
+	if err != nil {
+		return 0, err
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		logger().Error("Get the last insert id error", "model", "PhysicianAvailability", "error", err)
+		return 0, err
+	}
+	_physicianAvailability.Id = lastId
+	_physicianAvailability.savedChanges = diffAttributes(nil, _physicianAvailability.attributes())
+	_physicianAvailability.snapshot()
+	if err = runAfterCreate(ctx, _physicianAvailability); err != nil {
+		return lastId, err
+	}
+	return lastId, nil
+}
+
+// Reload is a method for PhysicianAvailability to reload the attributes of the object from the database.
+// The associations loaded into the object are reset.
+func (_physicianAvailability *PhysicianAvailability) Reload() error {
+	return _physicianAvailability.ReloadContext(context.Background())
+}
+
+// ReloadContext is same as Reload but with the context ctx.
+func (_physicianAvailability *PhysicianAvailability) ReloadContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "PhysicianAvailability.Reload", "physician_availabilities")
+	defer span.End()
+	if _physicianAvailability.Id == 0 {
+		return fmt.Errorf("PhysicianAvailability.Reload error: %w", ErrInvalidID)
+	}
+	physicianAvailability, err := FindPhysicianAvailabilityContext(ctx, _physicianAvailability.Id)
+	if err != nil {
+		return err
+	}
+	*_physicianAvailability = *physicianAvailability
+	return nil
+}
+
+// Destroy is method used for a PhysicianAvailability object to be destroyed.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
+func (_physicianAvailability *PhysicianAvailability) Destroy() error {
+	return _physicianAvailability.DestroyContext(context.Background())
+}
+
+// DestroyContext is same as Destroy but with the context ctx.
+func (_physicianAvailability *PhysicianAvailability) DestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "PhysicianAvailability.Destroy", "physician_availabilities")
+	defer span.End()
+	if _physicianAvailability.Id == 0 {
+		return fmt.Errorf("PhysicianAvailability.Destroy error: %w", ErrInvalidID)
+	}
+	if err := runBeforeDestroy(_physicianAvailability); err != nil {
+		return err
+	}
+	err := DestroyPhysicianAvailabilityContext(ctx, _physicianAvailability.Id)
+	if err != nil {
+		return err
+	}
+	return runAfterDestroy(ctx, _physicianAvailability)
+}
+
+// DestroyPhysicianAvailability will destroy a PhysicianAvailability record specified by the id parameter.
+func DestroyPhysicianAvailability(id int64) error {
+	return DestroyPhysicianAvailabilityContext(context.Background(), id)
+}
+
+// DestroyPhysicianAvailabilityContext is same as DestroyPhysicianAvailability but with the context ctx.
+func DestroyPhysicianAvailabilityContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DestroyPhysicianAvailability", "physician_availabilities")
+	defer span.End()
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(`DELETE FROM physician_availabilities WHERE id = ?`))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(ctx, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DestroyPhysicianAvailabilities will destroy PhysicianAvailability records those specified by the ids parameters.
+func DestroyPhysicianAvailabilities(ids ...int64) (int64, error) {
+	return DestroyPhysicianAvailabilitiesContext(context.Background(), ids...)
+}
+
+// DestroyPhysicianAvailabilitiesContext is same as DestroyPhysicianAvailabilities but with the context ctx.
+func DestroyPhysicianAvailabilitiesContext(ctx context.Context, ids ...int64) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyPhysicianAvailabilities", "physician_availabilities")
+	defer span.End()
+	if len(ids) == 0 {
+		err := fmt.Errorf("DestroyPhysicianAvailabilities error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "PhysicianAvailability")
+		return 0, err
+	}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	sql := fmt.Sprintf(`DELETE FROM physician_availabilities WHERE id IN (?%s)`, idsHolder)
+	idsT := []interface{}{}
+	for _, id := range ids {
+		idsT = append(idsT, interface{}(id))
+	}
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, idsT...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// DestroyPhysicianAvailabilitiesWhere delete records by a where clause restriction.
+// e.g. DestroyPhysicianAvailabilitiesWhere("name = ?", "John")
+// And this func will not call the association dependent action
+func DestroyPhysicianAvailabilitiesWhere(where string, args ...interface{}) (int64, error) {
+	return DestroyPhysicianAvailabilitiesWhereContext(context.Background(), where, args...)
+}
+
+// DestroyPhysicianAvailabilitiesWhereContext is same as DestroyPhysicianAvailabilitiesWhere but with the context ctx.
+func DestroyPhysicianAvailabilitiesWhereContext(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyPhysicianAvailabilitiesWhere", "physician_availabilities")
+	defer span.End()
+	sql := `DELETE FROM physician_availabilities WHERE `
+	if len(where) > 0 {
+		sql = sql + where
+	} else {
+		return 0, fmt.Errorf("DestroyPhysicianAvailabilitiesWhere error: %w", ErrNoWhere)
+	}
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// Save method is used for a PhysicianAvailability object to update an existed record mainly.
+// If no id provided a new record will be created, and if the record of the id doesn't exist
+// it will be created with the id.
+// A loaded object only updates its changed columns, see ChangedFields.
+// The object is reloaded from the database after it's saved.
+// It runs the BeforeValidate, BeforeUpdate, AfterUpdate and AfterCommit callbacks if implemented.
+func (_physicianAvailability *PhysicianAvailability) Save() error {
+	return _physicianAvailability.SaveContext(context.Background())
+}
+
+// SaveContext is same as Save but with the context ctx.
+func (_physicianAvailability *PhysicianAvailability) SaveContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "PhysicianAvailability.Save", "physician_availabilities")
+	defer span.End()
+	if _physicianAvailability.Id == 0 {
+		_, err := _physicianAvailability.CreateContext(ctx)
+		return err
+	}
+	if err := runBeforeValidate(_physicianAvailability); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_physicianAvailability)
+	if !ok {
+		err = &ValidationError{Model: "PhysicianAvailability", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "PhysicianAvailability")
+		return err
+	}
+	if err = runBeforeUpdate(_physicianAvailability); err != nil {
+		return err
+	}
+	old := _physicianAvailability.original
+	if old != nil && !_physicianAvailability.Changed() {
+		// a loaded object without changes has nothing to write
+		_physicianAvailability.savedChanges = nil
+		return runAfterUpdate(ctx, _physicianAvailability)
+	}
+	_physicianAvailability.UpdatedAt = time.Now()
+	if old != nil {
+		// a loaded object only updates its changed columns
+		sqlFmt := `UPDATE physician_availabilities SET %s WHERE id = %v`
+		sqlStr := fmt.Sprintf(sqlFmt, namedSets(append(_physicianAvailability.ChangedFields(), "updated_at")), _physicianAvailability.Id)
+		_, err = dbNamedExec(ctx, DB, "PhysicianAvailability", sqlStr, _physicianAvailability)
+	} else {
+		// an object not loaded updates all its columns, or it's created with the id if the record doesn't exist
+		if _physicianAvailability.CreatedAt.IsZero() {
+			_physicianAvailability.CreatedAt = _physicianAvailability.UpdatedAt
+		}
+		_, err = upsertWithId(ctx, "PhysicianAvailability", "physician_availabilities", _physicianAvailability.Id, _physicianAvailability, []string{"id", "physician_id", "kind", "weekday", "date", "start_time", "end_time", "note", "created_at", "updated_at"}, []string{"physician_id", "kind", "weekday", "date", "start_time", "end_time", "note", "updated_at"})
+	}
+	if err != nil {
+		return err
+	}
+	if err = _physicianAvailability.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_physicianAvailability.savedChanges = diffAttributes(old, _physicianAvailability.attributes())
+	return runAfterUpdate(ctx, _physicianAvailability)
+}
+
+// UpsertPhysicianAvailability use a named params to create a single PhysicianAvailability record, or to update the updateColumns
+// of the existed record instead if it conflicts with the params on the conflictColumns.
+// The id of the created or updated record is returned. With MySQL the conflict is detected
+// on any unique key of the table, the conflictColumns is only used by PostgreSQL and SQLite, so the
+// record conflicting on another unique key than the conflictColumns is updated and its id is returned.
+func UpsertPhysicianAvailability(am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	return UpsertPhysicianAvailabilityContext(context.Background(), am, conflictColumns, updateColumns)
+}
+
+// UpsertPhysicianAvailabilityContext is same as UpsertPhysicianAvailability but with the context ctx.
+func UpsertPhysicianAvailabilityContext(ctx context.Context, am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	ctx, span := startSpan(ctx, "UpsertPhysicianAvailability", "physician_availabilities")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("UpsertPhysicianAvailability error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	return upsert(ctx, "PhysicianAvailability", "physician_availabilities", am, allKeys(am), conflictColumns, updateColumns)
+}
+
+// Upsert is a method for PhysicianAvailability to create a record, or to update the updateColumns of the existed
+// record instead if it conflicts with the object on the conflictColumns, see UpsertPhysicianAvailability.
+// The Id of the object is set to the id of the created or updated record.
+// It runs the BeforeValidate callback if implemented.
+func (_physicianAvailability *PhysicianAvailability) Upsert(conflictColumns, updateColumns []string) error {
+	return _physicianAvailability.UpsertContext(context.Background(), conflictColumns, updateColumns)
+}
+
+// UpsertContext is same as Upsert but with the context ctx.
+func (_physicianAvailability *PhysicianAvailability) UpsertContext(ctx context.Context, conflictColumns, updateColumns []string) error {
+	ctx, span := startSpan(ctx, "PhysicianAvailability.Upsert", "physician_availabilities")
+	defer span.End()
+	if err := runBeforeValidate(_physicianAvailability); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_physicianAvailability)
+	if !ok {
+		err = &ValidationError{Model: "PhysicianAvailability", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "PhysicianAvailability")
+		return err
+	}
+	t := time.Now()
+	if _physicianAvailability.CreatedAt.IsZero() {
+		_physicianAvailability.CreatedAt = t
+	}
+	_physicianAvailability.UpdatedAt = t
+	keys := []string{"physician_id", "kind", "weekday", "date", "start_time", "end_time", "note", "created_at", "updated_at"}
+	if _physicianAvailability.Id != 0 {
+		keys = append([]string{"id"}, keys...)
+	}
+	id, err := upsert(ctx, "PhysicianAvailability", "physician_availabilities", _physicianAvailability, keys, conflictColumns, updateColumns)
+	if err != nil {
+		return err
+	}
+	_physicianAvailability.Id = id
+	_physicianAvailability.snapshot()
+	return nil
+}
+
+// UpdatePhysicianAvailability is used to update a record with a id and map[string]interface{} typed key-value parameters.
+func UpdatePhysicianAvailability(id int64, am map[string]interface{}) error {
+	return UpdatePhysicianAvailabilityContext(context.Background(), id, am)
+}
+
+// UpdatePhysicianAvailabilityContext is same as UpdatePhysicianAvailability but with the context ctx.
+func UpdatePhysicianAvailabilityContext(ctx context.Context, id int64, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "UpdatePhysicianAvailability", "physician_availabilities")
+	defer span.End()
+	if len(am) == 0 {
+		return fmt.Errorf("UpdatePhysicianAvailability error: %w", ErrEmptyAttributes)
+	}
+	am["updated_at"] = time.Now()
+	return updatePhysicianAvailabilityColumns(ctx, id, am)
+}
+
+// updatePhysicianAvailabilityColumns is used to update the columns of a record with a id as they are in the attributes map.
+func updatePhysicianAvailabilityColumns(ctx context.Context, id int64, am map[string]interface{}) error {
+	if len(am) == 0 {
+		return fmt.Errorf("PhysicianAvailability.UpdateColumns error: %w", ErrEmptyAttributes)
+	}
+	keys := allKeys(am)
+	sqlFmt := `UPDATE physician_availabilities SET %s WHERE id = %v`
+	setKeysArr := []string{}
+	for _, v := range keys {
+		s := fmt.Sprintf(" %s = :%s", v, v)
+		setKeysArr = append(setKeysArr, s)
+	}
+	sqlStr := fmt.Sprintf(sqlFmt, strings.Join(setKeysArr, ", "), id)
+	_, err := dbNamedExec(ctx, DB, "PhysicianAvailability", sqlStr, am)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update is a method used to update a PhysicianAvailability record with the map[string]interface{} typed key-value parameters.
+// The object is reloaded from the database after it's updated.
+func (_physicianAvailability *PhysicianAvailability) Update(am map[string]interface{}) error {
+	return _physicianAvailability.UpdateContext(context.Background(), am)
+}
+
+// UpdateContext is same as Update but with the context ctx.
+func (_physicianAvailability *PhysicianAvailability) UpdateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "PhysicianAvailability.Update", "physician_availabilities")
+	defer span.End()
+	if _physicianAvailability.Id == 0 {
+		return fmt.Errorf("PhysicianAvailability.Update error: %w", ErrInvalidID)
+	}
+	if err := runBeforeUpdate(_physicianAvailability); err != nil {
+		return err
+	}
+	old := _physicianAvailability.attributes()
+	err := UpdatePhysicianAvailabilityContext(ctx, _physicianAvailability.Id, am)
+	if err != nil {
+		return err
+	}
+	if err = _physicianAvailability.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_physicianAvailability.savedChanges = diffAttributes(old, _physicianAvailability.attributes())
+	return runAfterUpdate(ctx, _physicianAvailability)
+}
+
+// UpdateAttributes method is supposed to be used to update PhysicianAvailability records as corresponding update_attributes in Ruby on Rails.
+// The attributes are assigned to the object which is then saved, i.e. it's validated,
+// the callbacks are run and the updated_at is stamped, see Save.
+func (_physicianAvailability *PhysicianAvailability) UpdateAttributes(am map[string]interface{}) error {
+	return _physicianAvailability.UpdateAttributesContext(context.Background(), am)
+}
+
+// UpdateAttributesContext is same as UpdateAttributes but with the context ctx.
+func (_physicianAvailability *PhysicianAvailability) UpdateAttributesContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "PhysicianAvailability.UpdateAttributes", "physician_availabilities")
+	defer span.End()
+	if _physicianAvailability.Id == 0 {
+		return fmt.Errorf("PhysicianAvailability.UpdateAttributes error: %w", ErrInvalidID)
+	}
+	if err := assignColumns(_physicianAvailability, am); err != nil {
+		return err
+	}
+	return _physicianAvailability.SaveContext(ctx)
+}
+
+// UpdateColumns method is supposed to be used to update PhysicianAvailability records as corresponding update_columns in Ruby on Rails.
+// The columns are written as they are, skipping the validation, the callbacks and the updated_at
+// stamp, and then assigned to the object. A value which can't be assigned fails before anything is written.
+func (_physicianAvailability *PhysicianAvailability) UpdateColumns(am map[string]interface{}) error {
+	return _physicianAvailability.UpdateColumnsContext(context.Background(), am)
+}
+
+// UpdateColumnsContext is same as UpdateColumns but with the context ctx.
+func (_physicianAvailability *PhysicianAvailability) UpdateColumnsContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "PhysicianAvailability.UpdateColumns", "physician_availabilities")
+	defer span.End()
+	if _physicianAvailability.Id == 0 {
+		return fmt.Errorf("PhysicianAvailability.UpdateColumns error: %w", ErrInvalidID)
+	}
+	assigned := *_physicianAvailability
+	if err := assignColumns(&assigned, am); err != nil {
+		return err
+	}
+	if err := updatePhysicianAvailabilityColumns(ctx, _physicianAvailability.Id, am); err != nil {
+		return err
+	}
+	*_physicianAvailability = assigned
+	markSaved(_physicianAvailability.original, _physicianAvailability.attributes(), allKeys(am))
+	return nil
+}
+
+// UpdatePhysicianAvailabilitiesBySql is used to update PhysicianAvailability records by a SQL clause
+// using the '?' binding syntax.
+func UpdatePhysicianAvailabilitiesBySql(sql string, args ...interface{}) (int64, error) {
+	return UpdatePhysicianAvailabilitiesBySqlContext(context.Background(), sql, args...)
+}
+
+// UpdatePhysicianAvailabilitiesBySqlContext is same as UpdatePhysicianAvailabilitiesBySql but with the context ctx.
+func UpdatePhysicianAvailabilitiesBySqlContext(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "UpdatePhysicianAvailabilitiesBySql", "physician_availabilities")
+	defer span.End()
+	if sql == "" {
+		return 0, fmt.Errorf("UpdatePhysicianAvailabilitiesBySql error: %w", ErrBlankSQL)
+	}
+	stmt, err := prepare(ctx, "PhysicianAvailability", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
@@ -2,21 +2,16 @@
 package models
 
 import (
-	"errors"
+	"context"
 	"fmt"
-	"log"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
 )
 
-// set flags to output more detailed log
-func init() {
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-}
-
 type Picture struct {
 	Id            int64     `json:"id,omitempty" db:"id" valid:"-"`
 	Name          string    `json:"name,omitempty" db:"name" valid:"-"`
@@ -25,6 +20,52 @@ type Picture struct {
 	ImageableType string    `json:"imageable_type,omitempty" db:"imageable_type" valid:"-"`
 	CreatedAt     time.Time `json:"created_at,omitempty" db:"created_at" valid:"-"`
 	UpdatedAt     time.Time `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
+	original      map[string]interface{}
+	savedChanges  map[string]Change
+}
+
+// attributes returns the values of the changeable columns of the Picture object by column names.
+func (_picture *Picture) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"name":           _picture.Name,
+		"url":            _picture.Url,
+		"imageable_id":   _picture.ImageableId,
+		"imageable_type": _picture.ImageableType,
+	}
+}
+
+// snapshot keeps the current attributes of the Picture object as the original ones for the dirty tracking.
+func (_picture *Picture) snapshot() {
+	_picture.original = _picture.attributes()
+}
+
+// snapshotPictures keeps the current attributes of the loaded Picture objects as the original ones.
+func snapshotPictures(_pictures []Picture) {
+	for i := range _pictures {
+		_pictures[i].snapshot()
+	}
+}
+
+// Changed reports whether any attribute of the Picture object is changed since it was loaded
+// or saved. An object which is not loaded from the database is always changed.
+func (_picture *Picture) Changed() bool {
+	return len(_picture.ChangedFields()) > 0
+}
+
+// ChangedFields returns the sorted column names of the changed attributes of the Picture object.
+func (_picture *Picture) ChangedFields() []string {
+	return changedFields(_picture.original, _picture.attributes())
+}
+
+// Changes returns the old and new values of the changed attributes of the Picture object by column names.
+func (_picture *Picture) Changes() map[string]Change {
+	return diffAttributes(_picture.original, _picture.attributes())
+}
+
+// SavedChanges returns the changes written by the last Create, Save, Update or UpdateAttributes
+// of the Picture object, e.g. for an After callback to audit them.
+func (_picture *Picture) SavedChanges() map[string]Change {
+	return _picture.savedChanges
 }
 
 // DataStruct for the pagination
@@ -43,12 +84,19 @@ type PicturePage struct {
 
 // Current get the current page of PicturePage object for pagination.
 func (_p *PicturePage) Current() ([]Picture, error) {
+	return _p.CurrentContext(context.Background())
+}
+
+// CurrentContext is same as Current but with the context ctx.
+func (_p *PicturePage) CurrentContext(ctx context.Context) ([]Picture, error) {
+	ctx, span := startSpan(ctx, "PicturePage.Current", "pictures")
+	defer span.End()
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PicturePage.Current error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -57,7 +105,7 @@ func (_p *PicturePage) Current() ([]Picture, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	pictures, err := FindPicturesWhere(whereStr, whereParams...)
+	pictures, err := FindPicturesWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -69,15 +117,22 @@ func (_p *PicturePage) Current() ([]Picture, error) {
 
 // Previous get the previous page of PicturePage object for pagination.
 func (_p *PicturePage) Previous() ([]Picture, error) {
+	return _p.PreviousContext(context.Background())
+}
+
+// PreviousContext is same as Previous but with the context ctx.
+func (_p *PicturePage) PreviousContext(ctx context.Context) ([]Picture, error) {
+	ctx, span := startSpan(ctx, "PicturePage.Previous", "pictures")
+	defer span.End()
 	if _p.PageNum == 0 {
-		return nil, errors.New("This's the first page, no previous page yet")
+		return nil, fmt.Errorf("PicturePage.Previous error: %w", ErrFirstPage)
 	}
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PicturePage.Previous error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -86,7 +141,7 @@ func (_p *PicturePage) Previous() ([]Picture, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	pictures, err := FindPicturesWhere(whereStr, whereParams...)
+	pictures, err := FindPicturesWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -99,15 +154,22 @@ func (_p *PicturePage) Previous() ([]Picture, error) {
 
 // Next get the next page of PicturePage object for pagination.
 func (_p *PicturePage) Next() ([]Picture, error) {
+	return _p.NextContext(context.Background())
+}
+
+// NextContext is same as Next but with the context ctx.
+func (_p *PicturePage) NextContext(ctx context.Context) ([]Picture, error) {
+	ctx, span := startSpan(ctx, "PicturePage.Next", "pictures")
+	defer span.End()
 	if _p.PageNum == _p.TotalPages-1 {
-		return nil, errors.New("This's the last page, no next page yet")
+		return nil, fmt.Errorf("PicturePage.Next error: %w", ErrLastPage)
 	}
 	if _, exist := _p.Order["id"]; !exist {
-		return nil, errors.New("No id order specified in Order map")
+		return nil, fmt.Errorf("PicturePage.Next error: %w", ErrNoIdOrder)
 	}
-	err := _p.buildPageCount()
+	err := _p.buildPageCount(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("Calculate page count error: %v", err)
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
 	}
 	if _p.orderStr == "" {
 		_p.buildOrder()
@@ -116,7 +178,7 @@ func (_p *PicturePage) Next() ([]Picture, error) {
 	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
 	whereParams := []interface{}{}
 	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
-	pictures, err := FindPicturesWhere(whereStr, whereParams...)
+	pictures, err := FindPicturesWhereContext(ctx, whereStr, whereParams...)
 	if err != nil {
 		return nil, err
 	}
@@ -130,15 +192,22 @@ func (_p *PicturePage) Next() ([]Picture, error) {
 // GetPage is a helper function for the PicturePage object to return a corresponding page due to
 // the parameter passed in, i.e. one of "previous, current or next".
 func (_p *PicturePage) GetPage(direction string) (ps []Picture, err error) {
+	return _p.GetPageContext(context.Background(), direction)
+}
+
+// GetPageContext is same as GetPage but with the context ctx.
+func (_p *PicturePage) GetPageContext(ctx context.Context, direction string) (ps []Picture, err error) {
+	ctx, span := startSpan(ctx, "PicturePage.GetPage", "pictures")
+	defer span.End()
 	switch direction {
 	case "previous":
-		ps, _ = _p.Previous()
+		ps, err = _p.PreviousContext(ctx)
 	case "next":
-		ps, _ = _p.Next()
+		ps, err = _p.NextContext(ctx)
 	case "current":
-		ps, _ = _p.Current()
+		ps, err = _p.CurrentContext(ctx)
 	default:
-		return nil, errors.New("Error: wrong dircetion! None of previous, current or next!")
+		return nil, fmt.Errorf("PicturePage.GetPage error: %w: %q", ErrWrongDirection, direction)
 	}
 	return
 }
@@ -194,8 +263,8 @@ func (_p *PicturePage) buildIdRestrict(direction string) (idStr string, idParams
 }
 
 // buildPageCount calculate the TotalItems/TotalPages for the PicturePage object.
-func (_p *PicturePage) buildPageCount() error {
-	count, err := PictureCountWhere(_p.WhereString, _p.WhereParams...)
+func (_p *PicturePage) buildPageCount(ctx context.Context) error {
+	count, err := PictureCountWhereContext(ctx, _p.WhereString, _p.WhereParams...)
 	if err != nil {
 		return err
 	}
@@ -209,126 +278,195 @@ func (_p *PicturePage) buildPageCount() error {
 
 // FindPicture find a single picture by an ID.
 func FindPicture(id int64) (*Picture, error) {
+	return FindPictureContext(context.Background(), id)
+}
+
+// FindPictureContext is same as FindPicture but with the context ctx.
+func FindPictureContext(ctx context.Context, id int64) (*Picture, error) {
+	ctx, span := startSpan(ctx, "FindPicture", "pictures")
+	defer span.End()
 	if id == 0 {
-		return nil, errors.New("Invalid ID: it can't be zero")
+		return nil, fmt.Errorf("FindPicture error: %w", ErrInvalidID)
 	}
 	_picture := Picture{}
-	err := DB.Get(&_picture, DB.Rebind(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures WHERE pictures.id = ? LIMIT 1`), id)
+	err := dbGet(ctx, DB, "Picture", &_picture, rebind(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures WHERE pictures.id = ? LIMIT 1`), id)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_picture.snapshot()
 	return &_picture, nil
 }
 
 // FirstPicture find the first one picture by ID ASC order.
 func FirstPicture() (*Picture, error) {
+	return FirstPictureContext(context.Background())
+}
+
+// FirstPictureContext is same as FirstPicture but with the context ctx.
+func FirstPictureContext(ctx context.Context) (*Picture, error) {
+	ctx, span := startSpan(ctx, "FirstPicture", "pictures")
+	defer span.End()
 	_picture := Picture{}
-	err := DB.Get(&_picture, DB.Rebind(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures ORDER BY pictures.id ASC LIMIT 1`))
+	err := dbGet(ctx, DB, "Picture", &_picture, rebind(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures ORDER BY pictures.id ASC LIMIT 1`))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_picture.snapshot()
 	return &_picture, nil
 }
 
 // FirstPictures find the first N pictures by ID ASC order.
 func FirstPictures(n uint32) ([]Picture, error) {
+	return FirstPicturesContext(context.Background(), n)
+}
+
+// FirstPicturesContext is same as FirstPictures but with the context ctx.
+func FirstPicturesContext(ctx context.Context, n uint32) ([]Picture, error) {
+	ctx, span := startSpan(ctx, "FirstPictures", "pictures")
+	defer span.End()
 	_pictures := []Picture{}
 	sql := fmt.Sprintf("SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures ORDER BY pictures.id ASC LIMIT %v", n)
-	err := DB.Select(&_pictures, DB.Rebind(sql))
+	err := dbSelect(ctx, DB, "Picture", &_pictures, rebind(sql))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPictures(_pictures)
 	return _pictures, nil
 }
 
 // LastPicture find the last one picture by ID DESC order.
 func LastPicture() (*Picture, error) {
+	return LastPictureContext(context.Background())
+}
+
+// LastPictureContext is same as LastPicture but with the context ctx.
+func LastPictureContext(ctx context.Context) (*Picture, error) {
+	ctx, span := startSpan(ctx, "LastPicture", "pictures")
+	defer span.End()
 	_picture := Picture{}
-	err := DB.Get(&_picture, DB.Rebind(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures ORDER BY pictures.id DESC LIMIT 1`))
+	err := dbGet(ctx, DB, "Picture", &_picture, rebind(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures ORDER BY pictures.id DESC LIMIT 1`))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_picture.snapshot()
 	return &_picture, nil
 }
 
 // LastPictures find the last N pictures by ID DESC order.
 func LastPictures(n uint32) ([]Picture, error) {
+	return LastPicturesContext(context.Background(), n)
+}
+
+// LastPicturesContext is same as LastPictures but with the context ctx.
+func LastPicturesContext(ctx context.Context, n uint32) ([]Picture, error) {
+	ctx, span := startSpan(ctx, "LastPictures", "pictures")
+	defer span.End()
 	_pictures := []Picture{}
 	sql := fmt.Sprintf("SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures ORDER BY pictures.id DESC LIMIT %v", n)
-	err := DB.Select(&_pictures, DB.Rebind(sql))
+	err := dbSelect(ctx, DB, "Picture", &_pictures, rebind(sql))
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPictures(_pictures)
 	return _pictures, nil
 }
 
 // FindPictures find one or more pictures by the given ID(s).
 func FindPictures(ids ...int64) ([]Picture, error) {
+	return FindPicturesContext(context.Background(), ids...)
+}
+
+// FindPicturesContext is same as FindPictures but with the context ctx.
+func FindPicturesContext(ctx context.Context, ids ...int64) ([]Picture, error) {
+	ctx, span := startSpan(ctx, "FindPictures", "pictures")
+	defer span.End()
 	if len(ids) == 0 {
-		msg := "At least one or more ids needed"
-		log.Println(msg)
-		return nil, errors.New(msg)
+		err := fmt.Errorf("FindPictures error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Picture")
+		return nil, err
 	}
 	_pictures := []Picture{}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
-	sql := DB.Rebind(fmt.Sprintf(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures WHERE pictures.id IN (?%s)`, idsHolder))
+	sql := rebind(fmt.Sprintf(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures WHERE pictures.id IN (?%s)`, idsHolder))
 	idsT := []interface{}{}
 	for _, id := range ids {
 		idsT = append(idsT, interface{}(id))
 	}
-	err := DB.Select(&_pictures, sql, idsT...)
+	err := dbSelect(ctx, DB, "Picture", &_pictures, sql, idsT...)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPictures(_pictures)
 	return _pictures, nil
 }
 
 // FindPictureBy find a single picture by a field name and a value.
 func FindPictureBy(field string, val interface{}) (*Picture, error) {
+	return FindPictureByContext(context.Background(), field, val)
+}
+
+// FindPictureByContext is same as FindPictureBy but with the context ctx.
+func FindPictureByContext(ctx context.Context, field string, val interface{}) (*Picture, error) {
+	ctx, span := startSpan(ctx, "FindPictureBy", "pictures")
+	defer span.End()
 	_picture := Picture{}
 	sqlFmt := `SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures WHERE %s = ? LIMIT 1`
 	sqlStr := fmt.Sprintf(sqlFmt, field)
-	err := DB.Get(&_picture, DB.Rebind(sqlStr), val)
+	err := dbGet(ctx, DB, "Picture", &_picture, rebind(sqlStr), val)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	_picture.snapshot()
 	return &_picture, nil
 }
 
 // FindPicturesBy find all pictures by a field name and a value.
 func FindPicturesBy(field string, val interface{}) (_pictures []Picture, err error) {
+	return FindPicturesByContext(context.Background(), field, val)
+}
+
+// FindPicturesByContext is same as FindPicturesBy but with the context ctx.
+func FindPicturesByContext(ctx context.Context, field string, val interface{}) (_pictures []Picture, err error) {
+	ctx, span := startSpan(ctx, "FindPicturesBy", "pictures")
+	defer span.End()
 	sqlFmt := `SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures WHERE %s = ?`
 	sqlStr := fmt.Sprintf(sqlFmt, field)
-	err = DB.Select(&_pictures, DB.Rebind(sqlStr), val)
+	err = dbSelect(ctx, DB, "Picture", &_pictures, rebind(sqlStr), val)
 	if err != nil {
-		log.Printf("Error: %v\n", err)
 		return nil, err
 	}
+	snapshotPictures(_pictures)
 	return _pictures, nil
 }
 
 // AllPictures get all the Picture records.
 func AllPictures() (pictures []Picture, err error) {
-	err = DB.Select(&pictures, "SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures")
+	return AllPicturesContext(context.Background())
+}
+
+// AllPicturesContext is same as AllPictures but with the context ctx.
+func AllPicturesContext(ctx context.Context) (pictures []Picture, err error) {
+	ctx, span := startSpan(ctx, "AllPictures", "pictures")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Picture", &pictures, "SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures")
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPictures(pictures)
 	return pictures, nil
 }
 
 // PictureCount get the count of all the Picture records.
 func PictureCount() (c int64, err error) {
-	err = DB.Get(&c, "SELECT count(*) FROM pictures")
+	return PictureCountContext(context.Background())
+}
+
+// PictureCountContext is same as PictureCount but with the context ctx.
+func PictureCountContext(ctx context.Context) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PictureCount", "pictures")
+	defer span.End()
+	err = dbGet(ctx, DB, "Picture", &c, "SELECT count(*) FROM pictures")
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	return c, nil
@@ -336,18 +474,23 @@ func PictureCount() (c int64, err error) {
 
 // PictureCountWhere get the count of all the Picture records with a where clause.
 func PictureCountWhere(where string, args ...interface{}) (c int64, err error) {
+	return PictureCountWhereContext(context.Background(), where, args...)
+}
+
+// PictureCountWhereContext is same as PictureCountWhere but with the context ctx.
+func PictureCountWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "PictureCountWhere", "pictures")
+	defer span.End()
 	sql := "SELECT count(*) FROM pictures"
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
-	err = stmt.Get(&c, args...)
+	err = stmt.Get(ctx, &c, args...)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	return c, nil
@@ -355,17 +498,23 @@ func PictureCountWhere(where string, args ...interface{}) (c int64, err error) {
 
 // PictureIncludesWhere get the Picture associated models records, currently it's not same as the corresponding "includes" function but "preload" instead in Ruby on Rails. It means that the "sql" should be restricted on Picture model.
 func PictureIncludesWhere(assocs []string, sql string, args ...interface{}) (_pictures []Picture, err error) {
-	_pictures, err = FindPicturesWhere(sql, args...)
+	return PictureIncludesWhereContext(context.Background(), assocs, sql, args...)
+}
+
+// PictureIncludesWhereContext is same as PictureIncludesWhere but with the context ctx.
+func PictureIncludesWhereContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_pictures []Picture, err error) {
+	ctx, span := startSpan(ctx, "PictureIncludesWhere", "pictures")
+	defer span.End()
+	_pictures, err = FindPicturesWhereContext(ctx, sql, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	if len(assocs) == 0 {
-		log.Println("No associated fields ard specified")
+		logger().Debug("No associated fields are specified", "model", "Picture")
 		return _pictures, err
 	}
 	if len(_pictures) <= 0 {
-		return nil, errors.New("No results available")
+		return nil, fmt.Errorf("PictureIncludesWhere error: %w", ErrNotFound)
 	}
 	ids := make([]interface{}, len(_pictures))
 	for _, v := range _pictures {
@@ -376,9 +525,15 @@ func PictureIncludesWhere(assocs []string, sql string, args ...interface{}) (_pi
 
 // PictureIds get all the IDs of Picture records.
 func PictureIds() (ids []int64, err error) {
-	err = DB.Select(&ids, "SELECT id FROM pictures")
+	return PictureIdsContext(context.Background())
+}
+
+// PictureIdsContext is same as PictureIds but with the context ctx.
+func PictureIdsContext(ctx context.Context) (ids []int64, err error) {
+	ctx, span := startSpan(ctx, "PictureIds", "pictures")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Picture", &ids, "SELECT id FROM pictures")
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return ids, nil
@@ -386,24 +541,36 @@ func PictureIds() (ids []int64, err error) {
 
 // PictureIdsWhere get all the IDs of Picture records by where restriction.
 func PictureIdsWhere(where string, args ...interface{}) ([]int64, error) {
-	ids, err := PictureIntCol("id", where, args...)
+	return PictureIdsWhereContext(context.Background(), where, args...)
+}
+
+// PictureIdsWhereContext is same as PictureIdsWhere but with the context ctx.
+func PictureIdsWhereContext(ctx context.Context, where string, args ...interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "PictureIdsWhere", "pictures")
+	defer span.End()
+	ids, err := PictureIntColContext(ctx, "id", where, args...)
 	return ids, err
 }
 
 // PictureIntCol get some int64 typed column of Picture by where restriction.
 func PictureIntCol(col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	return PictureIntColContext(context.Background(), col, where, args...)
+}
+
+// PictureIntColContext is same as PictureIntCol but with the context ctx.
+func PictureIntColContext(ctx context.Context, col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	ctx, span := startSpan(ctx, "PictureIntCol", "pictures")
+	defer span.End()
 	sql := "SELECT " + col + " FROM pictures"
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&intColRecs, args...)
+	err = stmt.Select(ctx, &intColRecs, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return intColRecs, nil
@@ -411,18 +578,23 @@ func PictureIntCol(col, where string, args ...interface{}) (intColRecs []int64,
 
 // PictureStrCol get some string typed column of Picture by where restriction.
 func PictureStrCol(col, where string, args ...interface{}) (strColRecs []string, err error) {
+	return PictureStrColContext(context.Background(), col, where, args...)
+}
+
+// PictureStrColContext is same as PictureStrCol but with the context ctx.
+func PictureStrColContext(ctx context.Context, col, where string, args ...interface{}) (strColRecs []string, err error) {
+	ctx, span := startSpan(ctx, "PictureStrCol", "pictures")
+	defer span.End()
 	sql := "SELECT " + col + " FROM pictures"
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&strColRecs, args...)
+	err = stmt.Select(ctx, &strColRecs, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	return strColRecs, nil
@@ -432,20 +604,26 @@ func PictureStrCol(col, where string, args ...interface{}) (strColRecs []string,
 // with placeholders, eg: FindUsersWhere("first_name = ? AND age > ?", "John", 18)
 // will return those records in the table "users" whose first_name is "John" and age elder than 18.
 func FindPicturesWhere(where string, args ...interface{}) (pictures []Picture, err error) {
+	return FindPicturesWhereContext(context.Background(), where, args...)
+}
+
+// FindPicturesWhereContext is same as FindPicturesWhere but with the context ctx.
+func FindPicturesWhereContext(ctx context.Context, where string, args ...interface{}) (pictures []Picture, err error) {
+	ctx, span := startSpan(ctx, "FindPicturesWhere", "pictures")
+	defer span.End()
 	sql := "SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures"
 	if len(where) > 0 {
 		sql = sql + " WHERE " + where
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&pictures, args...)
+	err = stmt.Select(ctx, &pictures, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPictures(pictures)
 	return pictures, nil
 }
 
@@ -453,17 +631,23 @@ func FindPicturesWhere(where string, args ...interface{}) (pictures []Picture, e
 // with placeholders, eg: FindUserBySql("SELECT * FROM users WHERE first_name = ? AND age > ? ORDER BY DESC LIMIT 1", "John", 18)
 // will return only One record in the table "users" whose first_name is "John" and age elder than 18.
 func FindPictureBySql(sql string, args ...interface{}) (*Picture, error) {
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	return FindPictureBySqlContext(context.Background(), sql, args...)
+}
+
+// FindPictureBySqlContext is same as FindPictureBySql but with the context ctx.
+func FindPictureBySqlContext(ctx context.Context, sql string, args ...interface{}) (*Picture, error) {
+	ctx, span := startSpan(ctx, "FindPictureBySql", "pictures")
+	defer span.End()
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
 	_picture := &Picture{}
-	err = stmt.Get(_picture, args...)
+	err = stmt.Get(ctx, _picture, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	_picture.snapshot()
 	return _picture, nil
 }
 
@@ -471,24 +655,108 @@ func FindPictureBySql(sql string, args ...interface{}) (*Picture, error) {
 // with placeholders, eg: FindUsersBySql("SELECT * FROM users WHERE first_name = ? AND age > ?", "John", 18)
 // will return those records in the table "users" whose first_name is "John" and age elder than 18.
 func FindPicturesBySql(sql string, args ...interface{}) (pictures []Picture, err error) {
-	stmt, err := DB.Preparex(DB.Rebind(sql))
+	return FindPicturesBySqlContext(context.Background(), sql, args...)
+}
+
+// FindPicturesBySqlContext is same as FindPicturesBySql but with the context ctx.
+func FindPicturesBySqlContext(ctx context.Context, sql string, args ...interface{}) (pictures []Picture, err error) {
+	ctx, span := startSpan(ctx, "FindPicturesBySql", "pictures")
+	defer span.End()
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	err = stmt.Select(&pictures, args...)
+	err = stmt.Select(ctx, &pictures, args...)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
+	snapshotPictures(pictures)
+	return pictures, nil
+}
+
+// FindPictureForUpdate find a single picture by an ID in the transaction tx and lock its row
+// FOR UPDATE until the transaction ends.
+func FindPictureForUpdate(tx *sqlx.Tx, id int64) (*Picture, error) {
+	return FindPictureForUpdateContext(context.Background(), tx, id)
+}
+
+// FindPictureForUpdateContext is same as FindPictureForUpdate but with the context ctx.
+func FindPictureForUpdateContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Picture, error) {
+	ctx, span := startSpan(ctx, "FindPictureForUpdate", "pictures")
+	defer span.End()
+	return findPictureLock(ctx, tx, ForUpdate, id)
+}
+
+// FindPictureForShare find a single picture by an ID in the transaction tx and lock its row
+// FOR SHARE until the transaction ends.
+func FindPictureForShare(tx *sqlx.Tx, id int64) (*Picture, error) {
+	return FindPictureForShareContext(context.Background(), tx, id)
+}
+
+// FindPictureForShareContext is same as FindPictureForShare but with the context ctx.
+func FindPictureForShareContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Picture, error) {
+	ctx, span := startSpan(ctx, "FindPictureForShare", "pictures")
+	defer span.End()
+	return findPictureLock(ctx, tx, ForShare, id)
+}
+
+// findPictureLock find a single picture by an ID in the transaction tx with a row lock mode.
+func findPictureLock(ctx context.Context, tx *sqlx.Tx, mode LockMode, id int64) (*Picture, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("Lock Picture error: %w", ErrInvalidID)
+	}
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	_picture := Picture{}
+	err = dbGet(ctx, tx, "Picture", &_picture, tx.Rebind(`SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures WHERE pictures.id = ? LIMIT 1 `+lock), id)
+	if err != nil {
+		return nil, err
+	}
+	_picture.snapshot()
+	return &_picture, nil
+}
+
+// FindPicturesWhereLock is same as FindPicturesWhere but runs in the transaction tx and lock
+// the selected rows with the lock mode, e.g. ForUpdateSkipLocked, until the transaction ends.
+// The where clause should be a condition only, i.e. with no ORDER BY or LIMIT.
+func FindPicturesWhereLock(tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (pictures []Picture, err error) {
+	return FindPicturesWhereLockContext(context.Background(), tx, mode, where, args...)
+}
+
+// FindPicturesWhereLockContext is same as FindPicturesWhereLock but with the context ctx.
+func FindPicturesWhereLockContext(ctx context.Context, tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (pictures []Picture, err error) {
+	ctx, span := startSpan(ctx, "FindPicturesWhereLock", "pictures")
+	defer span.End()
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	sql := "SELECT COALESCE(pictures.name, '') AS name, COALESCE(pictures.url, '') AS url, COALESCE(pictures.imageable_id, 0) AS imageable_id, COALESCE(pictures.imageable_type, '') AS imageable_type, pictures.id, pictures.created_at, pictures.updated_at FROM pictures"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	err = dbSelect(ctx, tx, "Picture", &pictures, tx.Rebind(sql+" "+lock), args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotPictures(pictures)
 	return pictures, nil
 }
 
 // CreatePicture use a named params to create a single Picture record.
 // A named params is key-value map like map[string]interface{}{"first_name": "John", "age": 23} .
 func CreatePicture(am map[string]interface{}) (int64, error) {
+	return CreatePictureContext(context.Background(), am)
+}
+
+// CreatePictureContext is same as CreatePicture but with the context ctx.
+func CreatePictureContext(ctx context.Context, am map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CreatePicture", "pictures")
+	defer span.End()
 	if len(am) == 0 {
-		return 0, fmt.Errorf("Zero key in the attributes map!")
+		return 0, fmt.Errorf("CreatePicture error: %w", ErrEmptyAttributes)
 	}
 	t := time.Now()
 	for _, v := range []string{"created_at", "updated_at"} {
@@ -499,60 +767,176 @@ func CreatePicture(am map[string]interface{}) (int64, error) {
 	keys := allKeys(am)
 	sqlFmt := `INSERT INTO pictures (%s) VALUES (%s)`
 	sql := fmt.Sprintf(sqlFmt, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
-	result, err := DB.NamedExec(sql, am)
+	result, err := dbNamedExec(ctx, DB, "Picture", sql, am)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		log.Println(err)
+		logger().Error("Get the last insert id error", "model", "Picture", "error", err)
 		return 0, err
 	}
 	return lastId, nil
 }
 
-// Create is a method for Picture to create a record.
+// CreatePictures creates the Picture records of the slice pictures with multi-row INSERT statements,
+// see BulkInsertChunkSize. The structs are validated and time stamped, and their Id fields
+// are set to the ids of the created records, which are returned too. No callbacks are run.
+func CreatePictures(pictures []Picture) ([]int64, error) {
+	return CreatePicturesContext(context.Background(), pictures)
+}
+
+// CreatePicturesContext is same as CreatePictures but with the context ctx.
+func CreatePicturesContext(ctx context.Context, pictures []Picture) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreatePictures", "pictures")
+	defer span.End()
+	t := time.Now()
+	rows := make([][]interface{}, len(pictures))
+	for i := range pictures {
+		_picture := &pictures[i]
+		ok, err := govalidator.ValidateStruct(_picture)
+		if !ok {
+			err = &ValidationError{Model: "Picture", Index: i, Err: err}
+			logger().Warn(err.Error(), "model", "Picture")
+			return nil, err
+		}
+		_picture.CreatedAt = t
+		_picture.UpdatedAt = t
+		rows[i] = []interface{}{_picture.Name, _picture.Url, _picture.ImageableId, _picture.ImageableType, _picture.CreatedAt, _picture.UpdatedAt}
+	}
+	ids, err := bulkInsert(ctx, "Picture", "pictures", []string{"name", "url", "imageable_id", "imageable_type", "created_at", "updated_at"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		pictures[i].Id = id
+		pictures[i].snapshot()
+	}
+	return ids, nil
+}
+
+// CreatePicturesMaps use a slice of named params to create Picture records like CreatePicture does,
+// but with multi-row INSERT statements, see BulkInsertChunkSize. A column missing in some
+// of the maps gets its default value. The ids of the created records are returned in order.
+func CreatePicturesMaps(ams []map[string]interface{}) ([]int64, error) {
+	return CreatePicturesMapsContext(context.Background(), ams)
+}
+
+// CreatePicturesMapsContext is same as CreatePicturesMaps but with the context ctx.
+func CreatePicturesMapsContext(ctx context.Context, ams []map[string]interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreatePicturesMaps", "pictures")
+	defer span.End()
+	for i, am := range ams {
+		if len(am) == 0 {
+			return nil, fmt.Errorf("CreatePicturesMaps error: %w at index %d", ErrEmptyAttributes, i)
+		}
+	}
+	keys, rows := bulkMapRows(ams)
+	return bulkInsert(ctx, "Picture", "pictures", keys, rows)
+}
+
+// Create is a method for Picture to create a record, the Id of the object is set to the new id.
+// It runs the BeforeValidate, BeforeCreate, AfterCreate and AfterCommit callbacks if implemented.
 func (_picture *Picture) Create() (int64, error) {
+	return _picture.CreateContext(context.Background())
+}
+
+// CreateContext is same as Create but with the context ctx.
+func (_picture *Picture) CreateContext(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "Picture.Create", "pictures")
+	defer span.End()
+	if err := runBeforeValidate(_picture); err != nil {
+		return 0, err
+	}
 	ok, err := govalidator.ValidateStruct(_picture)
 	if !ok {
-		errMsg := "Validate Picture struct error: Unknown error"
-		if err != nil {
-			errMsg = "Validate Picture struct error: " + err.Error()
-		}
-		log.Println(errMsg)
-		return 0, errors.New(errMsg)
+		err = &ValidationError{Model: "Picture", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Picture")
+		return 0, err
+	}
+	if err = runBeforeCreate(_picture); err != nil {
+		return 0, err
 	}
 	t := time.Now()
 	_picture.CreatedAt = t
 	_picture.UpdatedAt = t
 	sql := `INSERT INTO pictures (name,url,imageable_id,imageable_type,created_at,updated_at) VALUES (:name,:url,:imageable_id,:imageable_type,:created_at,:updated_at)`
-	result, err := DB.NamedExec(sql, _picture)
+	result, err := dbNamedExec(ctx, DB, "Picture", sql, _picture)
 	if err != nil {
-		log.Println(err)
 		return 0, err
 	}
 	lastId, err := result.LastInsertId()
 	if err != nil {
-		log.Println(err)
+		logger().Error("Get the last insert id error", "model", "Picture", "error", err)
 		return 0, err
 	}
+	_picture.Id = lastId
+	_picture.savedChanges = diffAttributes(nil, _picture.attributes())
+	_picture.snapshot()
+	if err = runAfterCreate(ctx, _picture); err != nil {
+		return lastId, err
+	}
 	return lastId, nil
 }
 
+// Reload is a method for Picture to reload the attributes of the object from the database.
+// The associations loaded into the object are reset.
+func (_picture *Picture) Reload() error {
+	return _picture.ReloadContext(context.Background())
+}
+
+// ReloadContext is same as Reload but with the context ctx.
+func (_picture *Picture) ReloadContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Picture.Reload", "pictures")
+	defer span.End()
+	if _picture.Id == 0 {
+		return fmt.Errorf("Picture.Reload error: %w", ErrInvalidID)
+	}
+	picture, err := FindPictureContext(ctx, _picture.Id)
+	if err != nil {
+		return err
+	}
+	*_picture = *picture
+	return nil
+}
+
 // Destroy is method used for a Picture object to be destroyed.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
 func (_picture *Picture) Destroy() error {
+	return _picture.DestroyContext(context.Background())
+}
+
+// DestroyContext is same as Destroy but with the context ctx.
+func (_picture *Picture) DestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Picture.Destroy", "pictures")
+	defer span.End()
 	if _picture.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Picture.Destroy error: %w", ErrInvalidID)
+	}
+	if err := runBeforeDestroy(_picture); err != nil {
+		return err
+	}
+	err := DestroyPictureContext(ctx, _picture.Id)
+	if err != nil {
+		return err
 	}
-	err := DestroyPicture(_picture.Id)
-	return err
+	return runAfterDestroy(ctx, _picture)
 }
 
 // DestroyPicture will destroy a Picture record specified by the id parameter.
 func DestroyPicture(id int64) error {
-	stmt, err := DB.Preparex(DB.Rebind(`DELETE FROM pictures WHERE id = ?`))
-	_, err = stmt.Exec(id)
+	return DestroyPictureContext(context.Background(), id)
+}
+
+// DestroyPictureContext is same as DestroyPicture but with the context ctx.
+func DestroyPictureContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DestroyPicture", "pictures")
+	defer span.End()
+	stmt, err := prepare(ctx, "Picture", rebind(`DELETE FROM pictures WHERE id = ?`))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -561,10 +945,17 @@ func DestroyPicture(id int64) error {
 
 // DestroyPictures will destroy Picture records those specified by the ids parameters.
 func DestroyPictures(ids ...int64) (int64, error) {
+	return DestroyPicturesContext(context.Background(), ids...)
+}
+
+// DestroyPicturesContext is same as DestroyPictures but with the context ctx.
+func DestroyPicturesContext(ctx context.Context, ids ...int64) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyPictures", "pictures")
+	defer span.End()
 	if len(ids) == 0 {
-		msg := "At least one or more ids needed"
-		log.Println(msg)
-		return 0, errors.New(msg)
+		err := fmt.Errorf("DestroyPictures error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Picture")
+		return 0, err
 	}
 	idsHolder := strings.Repeat(",?", len(ids)-1)
 	sql := fmt.Sprintf(`DELETE FROM pictures WHERE id IN (?%s)`, idsHolder)
@@ -572,8 +963,11 @@ func DestroyPictures(ids ...int64) (int64, error) {
 	for _, id := range ids {
 		idsT = append(idsT, interface{}(id))
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(idsT...)
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, idsT...)
 	if err != nil {
 		return 0, err
 	}
@@ -588,14 +982,24 @@ func DestroyPictures(ids ...int64) (int64, error) {
 // e.g. DestroyPicturesWhere("name = ?", "John")
 // And this func will not call the association dependent action
 func DestroyPicturesWhere(where string, args ...interface{}) (int64, error) {
+	return DestroyPicturesWhereContext(context.Background(), where, args...)
+}
+
+// DestroyPicturesWhereContext is same as DestroyPicturesWhere but with the context ctx.
+func DestroyPicturesWhereContext(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyPicturesWhere", "pictures")
+	defer span.End()
 	sql := `DELETE FROM pictures WHERE `
 	if len(where) > 0 {
 		sql = sql + where
 	} else {
-		return 0, errors.New("No WHERE conditions provided")
+		return 0, fmt.Errorf("DestroyPicturesWhere error: %w", ErrNoWhere)
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(args...)
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -607,34 +1011,149 @@ func DestroyPicturesWhere(where string, args ...interface{}) (int64, error) {
 }
 
 // Save method is used for a Picture object to update an existed record mainly.
-// If no id provided a new record will be created. FIXME: A UPSERT action will be implemented further.
+// If no id provided a new record will be created, and if the record of the id doesn't exist
+// it will be created with the id.
+// A loaded object only updates its changed columns, see ChangedFields.
+// The object is reloaded from the database after it's saved.
+// It runs the BeforeValidate, BeforeUpdate, AfterUpdate and AfterCommit callbacks if implemented.
 func (_picture *Picture) Save() error {
+	return _picture.SaveContext(context.Background())
+}
+
+// SaveContext is same as Save but with the context ctx.
+func (_picture *Picture) SaveContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Picture.Save", "pictures")
+	defer span.End()
+	if _picture.Id == 0 {
+		_, err := _picture.CreateContext(ctx)
+		return err
+	}
+	if err := runBeforeValidate(_picture); err != nil {
+		return err
+	}
 	ok, err := govalidator.ValidateStruct(_picture)
 	if !ok {
-		errMsg := "Validate Picture struct error: Unknown error"
-		if err != nil {
-			errMsg = "Validate Picture struct error: " + err.Error()
-		}
-		log.Println(errMsg)
-		return errors.New(errMsg)
+		err = &ValidationError{Model: "Picture", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Picture")
+		return err
 	}
-	if _picture.Id == 0 {
-		_, err = _picture.Create()
+	if err = runBeforeUpdate(_picture); err != nil {
 		return err
 	}
+	old := _picture.original
+	if old != nil && !_picture.Changed() {
+		// a loaded object without changes has nothing to write
+		_picture.savedChanges = nil
+		return runAfterUpdate(ctx, _picture)
+	}
 	_picture.UpdatedAt = time.Now()
-	sqlFmt := `UPDATE pictures SET %s WHERE id = %v`
-	sqlStr := fmt.Sprintf(sqlFmt, "name = :name, url = :url, imageable_id = :imageable_id, imageable_type = :imageable_type, updated_at = :updated_at", _picture.Id)
-	_, err = DB.NamedExec(sqlStr, _picture)
-	return err
+	if old != nil {
+		// a loaded object only updates its changed columns
+		sqlFmt := `UPDATE pictures SET %s WHERE id = %v`
+		sqlStr := fmt.Sprintf(sqlFmt, namedSets(append(_picture.ChangedFields(), "updated_at")), _picture.Id)
+		_, err = dbNamedExec(ctx, DB, "Picture", sqlStr, _picture)
+	} else {
+		// an object not loaded updates all its columns, or it's created with the id if the record doesn't exist
+		if _picture.CreatedAt.IsZero() {
+			_picture.CreatedAt = _picture.UpdatedAt
+		}
+		_, err = upsertWithId(ctx, "Picture", "pictures", _picture.Id, _picture, []string{"id", "name", "url", "imageable_id", "imageable_type", "created_at", "updated_at"}, []string{"name", "url", "imageable_id", "imageable_type", "updated_at"})
+	}
+	if err != nil {
+		return err
+	}
+	if err = _picture.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_picture.savedChanges = diffAttributes(old, _picture.attributes())
+	return runAfterUpdate(ctx, _picture)
+}
+
+// UpsertPicture use a named params to create a single Picture record, or to update the updateColumns
+// of the existed record instead if it conflicts with the params on the conflictColumns.
+// The id of the created or updated record is returned. With MySQL the conflict is detected
+// on any unique key of the table, the conflictColumns is only used by PostgreSQL and SQLite, so the
+// record conflicting on another unique key than the conflictColumns is updated and its id is returned.
+func UpsertPicture(am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	return UpsertPictureContext(context.Background(), am, conflictColumns, updateColumns)
+}
+
+// UpsertPictureContext is same as UpsertPicture but with the context ctx.
+func UpsertPictureContext(ctx context.Context, am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	ctx, span := startSpan(ctx, "UpsertPicture", "pictures")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("UpsertPicture error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	return upsert(ctx, "Picture", "pictures", am, allKeys(am), conflictColumns, updateColumns)
+}
+
+// Upsert is a method for Picture to create a record, or to update the updateColumns of the existed
+// record instead if it conflicts with the object on the conflictColumns, see UpsertPicture.
+// The Id of the object is set to the id of the created or updated record.
+// It runs the BeforeValidate callback if implemented.
+func (_picture *Picture) Upsert(conflictColumns, updateColumns []string) error {
+	return _picture.UpsertContext(context.Background(), conflictColumns, updateColumns)
+}
+
+// UpsertContext is same as Upsert but with the context ctx.
+func (_picture *Picture) UpsertContext(ctx context.Context, conflictColumns, updateColumns []string) error {
+	ctx, span := startSpan(ctx, "Picture.Upsert", "pictures")
+	defer span.End()
+	if err := runBeforeValidate(_picture); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_picture)
+	if !ok {
+		err = &ValidationError{Model: "Picture", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Picture")
+		return err
+	}
+	t := time.Now()
+	if _picture.CreatedAt.IsZero() {
+		_picture.CreatedAt = t
+	}
+	_picture.UpdatedAt = t
+	keys := []string{"name", "url", "imageable_id", "imageable_type", "created_at", "updated_at"}
+	if _picture.Id != 0 {
+		keys = append([]string{"id"}, keys...)
+	}
+	id, err := upsert(ctx, "Picture", "pictures", _picture, keys, conflictColumns, updateColumns)
+	if err != nil {
+		return err
+	}
+	_picture.Id = id
+	_picture.snapshot()
+	return nil
 }
 
 // UpdatePicture is used to update a record with a id and map[string]interface{} typed key-value parameters.
 func UpdatePicture(id int64, am map[string]interface{}) error {
+	return UpdatePictureContext(context.Background(), id, am)
+}
+
+// UpdatePictureContext is same as UpdatePicture but with the context ctx.
+func UpdatePictureContext(ctx context.Context, id int64, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "UpdatePicture", "pictures")
+	defer span.End()
 	if len(am) == 0 {
-		return errors.New("Zero key in the attributes map!")
+		return fmt.Errorf("UpdatePicture error: %w", ErrEmptyAttributes)
 	}
 	am["updated_at"] = time.Now()
+	return updatePictureColumns(ctx, id, am)
+}
+
+// updatePictureColumns is used to update the columns of a record with a id as they are in the attributes map.
+func updatePictureColumns(ctx context.Context, id int64, am map[string]interface{}) error {
+	if len(am) == 0 {
+		return fmt.Errorf("Picture.UpdateColumns error: %w", ErrEmptyAttributes)
+	}
 	keys := allKeys(am)
 	sqlFmt := `UPDATE pictures SET %s WHERE id = %v`
 	setKeysArr := []string{}
@@ -643,49 +1162,105 @@ func UpdatePicture(id int64, am map[string]interface{}) error {
 		setKeysArr = append(setKeysArr, s)
 	}
 	sqlStr := fmt.Sprintf(sqlFmt, strings.Join(setKeysArr, ", "), id)
-	_, err := DB.NamedExec(sqlStr, am)
+	_, err := dbNamedExec(ctx, DB, "Picture", sqlStr, am)
 	if err != nil {
-		log.Println(err)
 		return err
 	}
 	return nil
 }
 
 // Update is a method used to update a Picture record with the map[string]interface{} typed key-value parameters.
+// The object is reloaded from the database after it's updated.
 func (_picture *Picture) Update(am map[string]interface{}) error {
+	return _picture.UpdateContext(context.Background(), am)
+}
+
+// UpdateContext is same as Update but with the context ctx.
+func (_picture *Picture) UpdateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Picture.Update", "pictures")
+	defer span.End()
 	if _picture.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Picture.Update error: %w", ErrInvalidID)
 	}
-	err := UpdatePicture(_picture.Id, am)
-	return err
+	if err := runBeforeUpdate(_picture); err != nil {
+		return err
+	}
+	old := _picture.attributes()
+	err := UpdatePictureContext(ctx, _picture.Id, am)
+	if err != nil {
+		return err
+	}
+	if err = _picture.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_picture.savedChanges = diffAttributes(old, _picture.attributes())
+	return runAfterUpdate(ctx, _picture)
 }
 
 // UpdateAttributes method is supposed to be used to update Picture records as corresponding update_attributes in Ruby on Rails.
+// The attributes are assigned to the object which is then saved, i.e. it's validated,
+// the callbacks are run and the updated_at is stamped, see Save.
 func (_picture *Picture) UpdateAttributes(am map[string]interface{}) error {
+	return _picture.UpdateAttributesContext(context.Background(), am)
+}
+
+// UpdateAttributesContext is same as UpdateAttributes but with the context ctx.
+func (_picture *Picture) UpdateAttributesContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Picture.UpdateAttributes", "pictures")
+	defer span.End()
 	if _picture.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Picture.UpdateAttributes error: %w", ErrInvalidID)
+	}
+	if err := assignColumns(_picture, am); err != nil {
+		return err
 	}
-	err := UpdatePicture(_picture.Id, am)
-	return err
+	return _picture.SaveContext(ctx)
 }
 
 // UpdateColumns method is supposed to be used to update Picture records as corresponding update_columns in Ruby on Rails.
+// The columns are written as they are, skipping the validation, the callbacks and the updated_at
+// stamp, and then assigned to the object. A value which can't be assigned fails before anything is written.
 func (_picture *Picture) UpdateColumns(am map[string]interface{}) error {
+	return _picture.UpdateColumnsContext(context.Background(), am)
+}
+
+// UpdateColumnsContext is same as UpdateColumns but with the context ctx.
+func (_picture *Picture) UpdateColumnsContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Picture.UpdateColumns", "pictures")
+	defer span.End()
 	if _picture.Id == 0 {
-		return errors.New("Invalid Id field: it can't be a zero value")
+		return fmt.Errorf("Picture.UpdateColumns error: %w", ErrInvalidID)
+	}
+	assigned := *_picture
+	if err := assignColumns(&assigned, am); err != nil {
+		return err
 	}
-	err := UpdatePicture(_picture.Id, am)
-	return err
+	if err := updatePictureColumns(ctx, _picture.Id, am); err != nil {
+		return err
+	}
+	*_picture = assigned
+	markSaved(_picture.original, _picture.attributes(), allKeys(am))
+	return nil
 }
 
 // UpdatePicturesBySql is used to update Picture records by a SQL clause
 // using the '?' binding syntax.
 func UpdatePicturesBySql(sql string, args ...interface{}) (int64, error) {
+	return UpdatePicturesBySqlContext(context.Background(), sql, args...)
+}
+
+// UpdatePicturesBySqlContext is same as UpdatePicturesBySql but with the context ctx.
+func UpdatePicturesBySqlContext(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "UpdatePicturesBySql", "pictures")
+	defer span.End()
 	if sql == "" {
-		return 0, errors.New("A blank SQL clause")
+		return 0, fmt.Errorf("UpdatePicturesBySql error: %w", ErrBlankSQL)
+	}
+	stmt, err := prepare(ctx, "Picture", rebind(sql))
+	if err != nil {
+		return 0, err
 	}
-	stmt, err := DB.Preparex(DB.Rebind(sql))
-	result, err := stmt.Exec(args...)
+	result, err := stmt.Exec(ctx, args...)
 	if err != nil {
 		return 0, err
 	}
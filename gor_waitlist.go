@@ -0,0 +1,1295 @@
+// Package models includes the functions on the model Waitlist.
+package models
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
+)
+
+// Waitlist is the model of the table waitlists, a patient waiting for a free slot of a fully booked
+// physician, optionally within the preferred window from PreferredFrom to PreferredUntil. A freed slot
+// is offered to the first matching waitlist, see OfferSlot.
+type Waitlist struct {
+	Id             int64      `json:"id,omitempty" db:"id" valid:"-"`
+	PatientId      int64      `json:"patient_id,omitempty" db:"patient_id" valid:"required"`
+	PhysicianId    int64      `json:"physician_id,omitempty" db:"physician_id" valid:"required"`
+	PreferredFrom  *time.Time `json:"preferred_from,omitempty" db:"preferred_from" valid:"-"`
+	PreferredUntil *time.Time `json:"preferred_until,omitempty" db:"preferred_until" valid:"-"`
+	Status         string     `json:"status,omitempty" db:"status" valid:"in(waiting|offered|booked|withdrawn)"`
+	OfferedStart   *time.Time `json:"offered_start,omitempty" db:"offered_start" valid:"-"`
+	OfferedAt      *time.Time `json:"offered_at,omitempty" db:"offered_at" valid:"-"`
+	AppointmentId  *int64     `json:"appointment_id,omitempty" db:"appointment_id" valid:"-"`
+	CreatedAt      time.Time  `json:"created_at,omitempty" db:"created_at" valid:"-"`
+	UpdatedAt      time.Time  `json:"updated_at,omitempty" db:"updated_at" valid:"-"`
+	original       map[string]interface{}
+	savedChanges   map[string]Change
+}
+
+// attributes returns the values of the changeable columns of the Waitlist object by column names.
+func (_waitlist *Waitlist) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"patient_id":      _waitlist.PatientId,
+		"physician_id":    _waitlist.PhysicianId,
+		"preferred_from":  nullableValue(_waitlist.PreferredFrom),
+		"preferred_until": nullableValue(_waitlist.PreferredUntil),
+		"status":          _waitlist.Status,
+		"offered_start":   nullableValue(_waitlist.OfferedStart),
+		"offered_at":      nullableValue(_waitlist.OfferedAt),
+		"appointment_id":  nullableValue(_waitlist.AppointmentId),
+	}
+}
+
+// snapshot keeps the current attributes of the Waitlist object as the original ones for the dirty tracking.
+func (_waitlist *Waitlist) snapshot() {
+	_waitlist.original = _waitlist.attributes()
+}
+
+// snapshotWaitlists keeps the current attributes of the loaded Waitlist objects as the original ones.
+func snapshotWaitlists(_waitlists []Waitlist) {
+	for i := range _waitlists {
+		_waitlists[i].snapshot()
+	}
+}
+
+// Changed reports whether any attribute of the Waitlist object is changed since it was loaded
+// or saved. An object which is not loaded from the database is always changed.
+func (_waitlist *Waitlist) Changed() bool {
+	return len(_waitlist.ChangedFields()) > 0
+}
+
+// ChangedFields returns the sorted column names of the changed attributes of the Waitlist object.
+func (_waitlist *Waitlist) ChangedFields() []string {
+	return changedFields(_waitlist.original, _waitlist.attributes())
+}
+
+// Changes returns the old and new values of the changed attributes of the Waitlist object by column names.
+func (_waitlist *Waitlist) Changes() map[string]Change {
+	return diffAttributes(_waitlist.original, _waitlist.attributes())
+}
+
+// SavedChanges returns the changes written by the last Create, Save, Update or UpdateAttributes
+// of the Waitlist object, e.g. for an After callback to audit them.
+func (_waitlist *Waitlist) SavedChanges() map[string]Change {
+	return _waitlist.savedChanges
+}
+
+// DataStruct for the pagination
+type WaitlistPage struct {
+	WhereString string
+	WhereParams []interface{}
+	Order       map[string]string
+	FirstId     int64
+	LastId      int64
+	PageNum     int
+	PerPage     int
+	TotalPages  int
+	TotalItems  int64
+	orderStr    string
+}
+
+// Current get the current page of WaitlistPage object for pagination.
+func (_p *WaitlistPage) Current() ([]Waitlist, error) {
+	return _p.CurrentContext(context.Background())
+}
+
+// CurrentContext is same as Current but with the context ctx.
+func (_p *WaitlistPage) CurrentContext(ctx context.Context) ([]Waitlist, error) {
+	ctx, span := startSpan(ctx, "WaitlistPage.Current", "waitlists")
+	defer span.End()
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("WaitlistPage.Current error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("current")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	waitlists, err := FindWaitlistsWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(waitlists) != 0 {
+		_p.FirstId, _p.LastId = waitlists[0].Id, waitlists[len(waitlists)-1].Id
+	}
+	return waitlists, nil
+}
+
+// Previous get the previous page of WaitlistPage object for pagination.
+func (_p *WaitlistPage) Previous() ([]Waitlist, error) {
+	return _p.PreviousContext(context.Background())
+}
+
+// PreviousContext is same as Previous but with the context ctx.
+func (_p *WaitlistPage) PreviousContext(ctx context.Context) ([]Waitlist, error) {
+	ctx, span := startSpan(ctx, "WaitlistPage.Previous", "waitlists")
+	defer span.End()
+	if _p.PageNum == 0 {
+		return nil, fmt.Errorf("WaitlistPage.Previous error: %w", ErrFirstPage)
+	}
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("WaitlistPage.Previous error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("previous")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	waitlists, err := FindWaitlistsWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(waitlists) != 0 {
+		_p.FirstId, _p.LastId = waitlists[0].Id, waitlists[len(waitlists)-1].Id
+	}
+	_p.PageNum -= 1
+	return waitlists, nil
+}
+
+// Next get the next page of WaitlistPage object for pagination.
+func (_p *WaitlistPage) Next() ([]Waitlist, error) {
+	return _p.NextContext(context.Background())
+}
+
+// NextContext is same as Next but with the context ctx.
+func (_p *WaitlistPage) NextContext(ctx context.Context) ([]Waitlist, error) {
+	ctx, span := startSpan(ctx, "WaitlistPage.Next", "waitlists")
+	defer span.End()
+	if _p.PageNum == _p.TotalPages-1 {
+		return nil, fmt.Errorf("WaitlistPage.Next error: %w", ErrLastPage)
+	}
+	if _, exist := _p.Order["id"]; !exist {
+		return nil, fmt.Errorf("WaitlistPage.Next error: %w", ErrNoIdOrder)
+	}
+	err := _p.buildPageCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Calculate page count error: %w", err)
+	}
+	if _p.orderStr == "" {
+		_p.buildOrder()
+	}
+	idStr, idParams := _p.buildIdRestrict("next")
+	whereStr := fmt.Sprintf("%s %s %s LIMIT %v", _p.WhereString, idStr, _p.orderStr, _p.PerPage)
+	whereParams := []interface{}{}
+	whereParams = append(append(whereParams, _p.WhereParams...), idParams...)
+	waitlists, err := FindWaitlistsWhereContext(ctx, whereStr, whereParams...)
+	if err != nil {
+		return nil, err
+	}
+	if len(waitlists) != 0 {
+		_p.FirstId, _p.LastId = waitlists[0].Id, waitlists[len(waitlists)-1].Id
+	}
+	_p.PageNum += 1
+	return waitlists, nil
+}
+
+// GetPage is a helper function for the WaitlistPage object to return a corresponding page due to
+// the parameter passed in, i.e. one of "previous, current or next".
+func (_p *WaitlistPage) GetPage(direction string) (ps []Waitlist, err error) {
+	return _p.GetPageContext(context.Background(), direction)
+}
+
+// GetPageContext is same as GetPage but with the context ctx.
+func (_p *WaitlistPage) GetPageContext(ctx context.Context, direction string) (ps []Waitlist, err error) {
+	ctx, span := startSpan(ctx, "WaitlistPage.GetPage", "waitlists")
+	defer span.End()
+	switch direction {
+	case "previous":
+		ps, err = _p.PreviousContext(ctx)
+	case "next":
+		ps, err = _p.NextContext(ctx)
+	case "current":
+		ps, err = _p.CurrentContext(ctx)
+	default:
+		return nil, fmt.Errorf("WaitlistPage.GetPage error: %w: %q", ErrWrongDirection, direction)
+	}
+	return
+}
+
+// buildOrder is for WaitlistPage object to build a SQL ORDER BY clause.
+func (_p *WaitlistPage) buildOrder() {
+	tempList := []string{}
+	for k, v := range _p.Order {
+		tempList = append(tempList, fmt.Sprintf("%v %v", k, v))
+	}
+	_p.orderStr = " ORDER BY " + strings.Join(tempList, ", ")
+}
+
+// buildIdRestrict is for WaitlistPage object to build a SQL clause for ID restriction,
+// implementing a simple keyset style pagination.
+func (_p *WaitlistPage) buildIdRestrict(direction string) (idStr string, idParams []interface{}) {
+	switch direction {
+	case "previous":
+		if strings.ToLower(_p.Order["id"]) == "desc" {
+			idStr += "id > ? "
+			idParams = append(idParams, _p.FirstId)
+		} else {
+			idStr += "id < ? "
+			idParams = append(idParams, _p.FirstId)
+		}
+	case "current":
+		// trick to make Where function work
+		if _p.PageNum == 0 && _p.FirstId == 0 && _p.LastId == 0 {
+			idStr += "id > ? "
+			idParams = append(idParams, 0)
+		} else {
+			if strings.ToLower(_p.Order["id"]) == "desc" {
+				idStr += "id <= ? AND id >= ? "
+				idParams = append(idParams, _p.FirstId, _p.LastId)
+			} else {
+				idStr += "id >= ? AND id <= ? "
+				idParams = append(idParams, _p.FirstId, _p.LastId)
+			}
+		}
+	case "next":
+		if strings.ToLower(_p.Order["id"]) == "desc" {
+			idStr += "id < ? "
+			idParams = append(idParams, _p.LastId)
+		} else {
+			idStr += "id > ? "
+			idParams = append(idParams, _p.LastId)
+		}
+	}
+	if _p.WhereString != "" {
+		idStr = " AND " + idStr
+	}
+	return
+}
+
+// buildPageCount calculate the TotalItems/TotalPages for the WaitlistPage object.
+func (_p *WaitlistPage) buildPageCount(ctx context.Context) error {
+	count, err := WaitlistCountWhereContext(ctx, _p.WhereString, _p.WhereParams...)
+	if err != nil {
+		return err
+	}
+	_p.TotalItems = count
+	if _p.PerPage == 0 {
+		_p.PerPage = 10
+	}
+	_p.TotalPages = int(math.Ceil(float64(_p.TotalItems) / float64(_p.PerPage)))
+	return nil
+}
+
+// FindWaitlist find a single waitlist by an ID.
+func FindWaitlist(id int64) (*Waitlist, error) {
+	return FindWaitlistContext(context.Background(), id)
+}
+
+// FindWaitlistContext is same as FindWaitlist but with the context ctx.
+func FindWaitlistContext(ctx context.Context, id int64) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "FindWaitlist", "waitlists")
+	defer span.End()
+	if id == 0 {
+		return nil, fmt.Errorf("FindWaitlist error: %w", ErrInvalidID)
+	}
+	_waitlist := Waitlist{}
+	err := dbGet(ctx, DB, "Waitlist", &_waitlist, rebind(`SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists WHERE waitlists.id = ? LIMIT 1`), id)
+	if err != nil {
+		return nil, err
+	}
+	_waitlist.snapshot()
+	return &_waitlist, nil
+}
+
+// FirstWaitlist find the first one waitlist by ID ASC order.
+func FirstWaitlist() (*Waitlist, error) {
+	return FirstWaitlistContext(context.Background())
+}
+
+// FirstWaitlistContext is same as FirstWaitlist but with the context ctx.
+func FirstWaitlistContext(ctx context.Context) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "FirstWaitlist", "waitlists")
+	defer span.End()
+	_waitlist := Waitlist{}
+	err := dbGet(ctx, DB, "Waitlist", &_waitlist, rebind(`SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists ORDER BY waitlists.id ASC LIMIT 1`))
+	if err != nil {
+		return nil, err
+	}
+	_waitlist.snapshot()
+	return &_waitlist, nil
+}
+
+// FirstWaitlists find the first N waitlists by ID ASC order.
+func FirstWaitlists(n uint32) ([]Waitlist, error) {
+	return FirstWaitlistsContext(context.Background(), n)
+}
+
+// FirstWaitlistsContext is same as FirstWaitlists but with the context ctx.
+func FirstWaitlistsContext(ctx context.Context, n uint32) ([]Waitlist, error) {
+	ctx, span := startSpan(ctx, "FirstWaitlists", "waitlists")
+	defer span.End()
+	_waitlists := []Waitlist{}
+	sql := fmt.Sprintf("SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists ORDER BY waitlists.id ASC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "Waitlist", &_waitlists, rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	snapshotWaitlists(_waitlists)
+	return _waitlists, nil
+}
+
+// LastWaitlist find the last one waitlist by ID DESC order.
+func LastWaitlist() (*Waitlist, error) {
+	return LastWaitlistContext(context.Background())
+}
+
+// LastWaitlistContext is same as LastWaitlist but with the context ctx.
+func LastWaitlistContext(ctx context.Context) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "LastWaitlist", "waitlists")
+	defer span.End()
+	_waitlist := Waitlist{}
+	err := dbGet(ctx, DB, "Waitlist", &_waitlist, rebind(`SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists ORDER BY waitlists.id DESC LIMIT 1`))
+	if err != nil {
+		return nil, err
+	}
+	_waitlist.snapshot()
+	return &_waitlist, nil
+}
+
+// LastWaitlists find the last N waitlists by ID DESC order.
+func LastWaitlists(n uint32) ([]Waitlist, error) {
+	return LastWaitlistsContext(context.Background(), n)
+}
+
+// LastWaitlistsContext is same as LastWaitlists but with the context ctx.
+func LastWaitlistsContext(ctx context.Context, n uint32) ([]Waitlist, error) {
+	ctx, span := startSpan(ctx, "LastWaitlists", "waitlists")
+	defer span.End()
+	_waitlists := []Waitlist{}
+	sql := fmt.Sprintf("SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists ORDER BY waitlists.id DESC LIMIT %v", n)
+	err := dbSelect(ctx, DB, "Waitlist", &_waitlists, rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	snapshotWaitlists(_waitlists)
+	return _waitlists, nil
+}
+
+// FindWaitlists find one or more waitlists by the given ID(s).
+func FindWaitlists(ids ...int64) ([]Waitlist, error) {
+	return FindWaitlistsContext(context.Background(), ids...)
+}
+
+// FindWaitlistsContext is same as FindWaitlists but with the context ctx.
+func FindWaitlistsContext(ctx context.Context, ids ...int64) ([]Waitlist, error) {
+	ctx, span := startSpan(ctx, "FindWaitlists", "waitlists")
+	defer span.End()
+	if len(ids) == 0 {
+		err := fmt.Errorf("FindWaitlists error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Waitlist")
+		return nil, err
+	}
+	_waitlists := []Waitlist{}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	sql := rebind(fmt.Sprintf(`SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists WHERE waitlists.id IN (?%s)`, idsHolder))
+	idsT := []interface{}{}
+	for _, id := range ids {
+		idsT = append(idsT, interface{}(id))
+	}
+	err := dbSelect(ctx, DB, "Waitlist", &_waitlists, sql, idsT...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotWaitlists(_waitlists)
+	return _waitlists, nil
+}
+
+// FindWaitlistBy find a single waitlist by a field name and a value.
+func FindWaitlistBy(field string, val interface{}) (*Waitlist, error) {
+	return FindWaitlistByContext(context.Background(), field, val)
+}
+
+// FindWaitlistByContext is same as FindWaitlistBy but with the context ctx.
+func FindWaitlistByContext(ctx context.Context, field string, val interface{}) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "FindWaitlistBy", "waitlists")
+	defer span.End()
+	_waitlist := Waitlist{}
+	sqlFmt := `SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists WHERE %s = ? LIMIT 1`
+	sqlStr := fmt.Sprintf(sqlFmt, field)
+	err := dbGet(ctx, DB, "Waitlist", &_waitlist, rebind(sqlStr), val)
+	if err != nil {
+		return nil, err
+	}
+	_waitlist.snapshot()
+	return &_waitlist, nil
+}
+
+// FindWaitlistsBy find all waitlists by a field name and a value.
+func FindWaitlistsBy(field string, val interface{}) (_waitlists []Waitlist, err error) {
+	return FindWaitlistsByContext(context.Background(), field, val)
+}
+
+// FindWaitlistsByContext is same as FindWaitlistsBy but with the context ctx.
+func FindWaitlistsByContext(ctx context.Context, field string, val interface{}) (_waitlists []Waitlist, err error) {
+	ctx, span := startSpan(ctx, "FindWaitlistsBy", "waitlists")
+	defer span.End()
+	sqlFmt := `SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists WHERE %s = ?`
+	sqlStr := fmt.Sprintf(sqlFmt, field)
+	err = dbSelect(ctx, DB, "Waitlist", &_waitlists, rebind(sqlStr), val)
+	if err != nil {
+		return nil, err
+	}
+	snapshotWaitlists(_waitlists)
+	return _waitlists, nil
+}
+
+// AllWaitlists get all the Waitlist records.
+func AllWaitlists() (waitlists []Waitlist, err error) {
+	return AllWaitlistsContext(context.Background())
+}
+
+// AllWaitlistsContext is same as AllWaitlists but with the context ctx.
+func AllWaitlistsContext(ctx context.Context) (waitlists []Waitlist, err error) {
+	ctx, span := startSpan(ctx, "AllWaitlists", "waitlists")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Waitlist", &waitlists, "SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists")
+	if err != nil {
+		return nil, err
+	}
+	snapshotWaitlists(waitlists)
+	return waitlists, nil
+}
+
+// WaitlistCount get the count of all the Waitlist records.
+func WaitlistCount() (c int64, err error) {
+	return WaitlistCountContext(context.Background())
+}
+
+// WaitlistCountContext is same as WaitlistCount but with the context ctx.
+func WaitlistCountContext(ctx context.Context) (c int64, err error) {
+	ctx, span := startSpan(ctx, "WaitlistCount", "waitlists")
+	defer span.End()
+	err = dbGet(ctx, DB, "Waitlist", &c, "SELECT count(*) FROM waitlists")
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+// WaitlistCountWhere get the count of all the Waitlist records with a where clause.
+func WaitlistCountWhere(where string, args ...interface{}) (c int64, err error) {
+	return WaitlistCountWhereContext(context.Background(), where, args...)
+}
+
+// WaitlistCountWhereContext is same as WaitlistCountWhere but with the context ctx.
+func WaitlistCountWhereContext(ctx context.Context, where string, args ...interface{}) (c int64, err error) {
+	ctx, span := startSpan(ctx, "WaitlistCountWhere", "waitlists")
+	defer span.End()
+	sql := "SELECT count(*) FROM waitlists"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	err = stmt.Get(ctx, &c, args...)
+	if err != nil {
+		return 0, err
+	}
+	return c, nil
+}
+
+// WaitlistIncludesWhere get the Waitlist associated models records, currently it's not same as the corresponding "includes" function but "preload" instead in Ruby on Rails. It means that the "sql" should be restricted on Waitlist model.
+func WaitlistIncludesWhere(assocs []string, sql string, args ...interface{}) (_waitlists []Waitlist, err error) {
+	return WaitlistIncludesWhereContext(context.Background(), assocs, sql, args...)
+}
+
+// WaitlistIncludesWhereContext is same as WaitlistIncludesWhere but with the context ctx.
+func WaitlistIncludesWhereContext(ctx context.Context, assocs []string, sql string, args ...interface{}) (_waitlists []Waitlist, err error) {
+	ctx, span := startSpan(ctx, "WaitlistIncludesWhere", "waitlists")
+	defer span.End()
+	_waitlists, err = FindWaitlistsWhereContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(assocs) == 0 {
+		logger().Debug("No associated fields are specified", "model", "Waitlist")
+		return _waitlists, err
+	}
+	if len(_waitlists) <= 0 {
+		return nil, fmt.Errorf("WaitlistIncludesWhere error: %w", ErrNotFound)
+	}
+	ids := make([]interface{}, len(_waitlists))
+	for _, v := range _waitlists {
+		ids = append(ids, interface{}(v.Id))
+	}
+	return _waitlists, nil
+}
+
+// WaitlistIds get all the IDs of Waitlist records.
+func WaitlistIds() (ids []int64, err error) {
+	return WaitlistIdsContext(context.Background())
+}
+
+// WaitlistIdsContext is same as WaitlistIds but with the context ctx.
+func WaitlistIdsContext(ctx context.Context) (ids []int64, err error) {
+	ctx, span := startSpan(ctx, "WaitlistIds", "waitlists")
+	defer span.End()
+	err = dbSelect(ctx, DB, "Waitlist", &ids, "SELECT id FROM waitlists")
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// WaitlistIdsWhere get all the IDs of Waitlist records by where restriction.
+func WaitlistIdsWhere(where string, args ...interface{}) ([]int64, error) {
+	return WaitlistIdsWhereContext(context.Background(), where, args...)
+}
+
+// WaitlistIdsWhereContext is same as WaitlistIdsWhere but with the context ctx.
+func WaitlistIdsWhereContext(ctx context.Context, where string, args ...interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "WaitlistIdsWhere", "waitlists")
+	defer span.End()
+	ids, err := WaitlistIntColContext(ctx, "id", where, args...)
+	return ids, err
+}
+
+// WaitlistIntCol get some int64 typed column of Waitlist by where restriction.
+func WaitlistIntCol(col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	return WaitlistIntColContext(context.Background(), col, where, args...)
+}
+
+// WaitlistIntColContext is same as WaitlistIntCol but with the context ctx.
+func WaitlistIntColContext(ctx context.Context, col, where string, args ...interface{}) (intColRecs []int64, err error) {
+	ctx, span := startSpan(ctx, "WaitlistIntCol", "waitlists")
+	defer span.End()
+	sql := "SELECT " + col + " FROM waitlists"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &intColRecs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return intColRecs, nil
+}
+
+// WaitlistStrCol get some string typed column of Waitlist by where restriction.
+func WaitlistStrCol(col, where string, args ...interface{}) (strColRecs []string, err error) {
+	return WaitlistStrColContext(context.Background(), col, where, args...)
+}
+
+// WaitlistStrColContext is same as WaitlistStrCol but with the context ctx.
+func WaitlistStrColContext(ctx context.Context, col, where string, args ...interface{}) (strColRecs []string, err error) {
+	ctx, span := startSpan(ctx, "WaitlistStrCol", "waitlists")
+	defer span.End()
+	sql := "SELECT " + col + " FROM waitlists"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &strColRecs, args...)
+	if err != nil {
+		return nil, err
+	}
+	return strColRecs, nil
+}
+
+// FindWaitlistsWhere query use a partial SQL clause that usually following after WHERE
+// with placeholders, eg: FindUsersWhere("first_name = ? AND age > ?", "John", 18)
+// will return those records in the table "users" whose first_name is "John" and age elder than 18.
+func FindWaitlistsWhere(where string, args ...interface{}) (waitlists []Waitlist, err error) {
+	return FindWaitlistsWhereContext(context.Background(), where, args...)
+}
+
+// FindWaitlistsWhereContext is same as FindWaitlistsWhere but with the context ctx.
+func FindWaitlistsWhereContext(ctx context.Context, where string, args ...interface{}) (waitlists []Waitlist, err error) {
+	ctx, span := startSpan(ctx, "FindWaitlistsWhere", "waitlists")
+	defer span.End()
+	sql := "SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &waitlists, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotWaitlists(waitlists)
+	return waitlists, nil
+}
+
+// FindWaitlistBySql query use a complete SQL clause
+// with placeholders, eg: FindUserBySql("SELECT * FROM users WHERE first_name = ? AND age > ? ORDER BY DESC LIMIT 1", "John", 18)
+// will return only One record in the table "users" whose first_name is "John" and age elder than 18.
+func FindWaitlistBySql(sql string, args ...interface{}) (*Waitlist, error) {
+	return FindWaitlistBySqlContext(context.Background(), sql, args...)
+}
+
+// FindWaitlistBySqlContext is same as FindWaitlistBySql but with the context ctx.
+func FindWaitlistBySqlContext(ctx context.Context, sql string, args ...interface{}) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "FindWaitlistBySql", "waitlists")
+	defer span.End()
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	_waitlist := &Waitlist{}
+	err = stmt.Get(ctx, _waitlist, args...)
+	if err != nil {
+		return nil, err
+	}
+	_waitlist.snapshot()
+	return _waitlist, nil
+}
+
+// FindWaitlistsBySql query use a complete SQL clause
+// with placeholders, eg: FindUsersBySql("SELECT * FROM users WHERE first_name = ? AND age > ?", "John", 18)
+// will return those records in the table "users" whose first_name is "John" and age elder than 18.
+func FindWaitlistsBySql(sql string, args ...interface{}) (waitlists []Waitlist, err error) {
+	return FindWaitlistsBySqlContext(context.Background(), sql, args...)
+}
+
+// FindWaitlistsBySqlContext is same as FindWaitlistsBySql but with the context ctx.
+func FindWaitlistsBySqlContext(ctx context.Context, sql string, args ...interface{}) (waitlists []Waitlist, err error) {
+	ctx, span := startSpan(ctx, "FindWaitlistsBySql", "waitlists")
+	defer span.End()
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return nil, err
+	}
+	err = stmt.Select(ctx, &waitlists, args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotWaitlists(waitlists)
+	return waitlists, nil
+}
+
+// FindWaitlistForUpdate find a single waitlist by an ID in the transaction tx and lock its row
+// FOR UPDATE until the transaction ends.
+func FindWaitlistForUpdate(tx *sqlx.Tx, id int64) (*Waitlist, error) {
+	return FindWaitlistForUpdateContext(context.Background(), tx, id)
+}
+
+// FindWaitlistForUpdateContext is same as FindWaitlistForUpdate but with the context ctx.
+func FindWaitlistForUpdateContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "FindWaitlistForUpdate", "waitlists")
+	defer span.End()
+	return findWaitlistLock(ctx, tx, ForUpdate, id)
+}
+
+// FindWaitlistForShare find a single waitlist by an ID in the transaction tx and lock its row
+// FOR SHARE until the transaction ends.
+func FindWaitlistForShare(tx *sqlx.Tx, id int64) (*Waitlist, error) {
+	return FindWaitlistForShareContext(context.Background(), tx, id)
+}
+
+// FindWaitlistForShareContext is same as FindWaitlistForShare but with the context ctx.
+func FindWaitlistForShareContext(ctx context.Context, tx *sqlx.Tx, id int64) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "FindWaitlistForShare", "waitlists")
+	defer span.End()
+	return findWaitlistLock(ctx, tx, ForShare, id)
+}
+
+// findWaitlistLock find a single waitlist by an ID in the transaction tx with a row lock mode.
+func findWaitlistLock(ctx context.Context, tx *sqlx.Tx, mode LockMode, id int64) (*Waitlist, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("Lock Waitlist error: %w", ErrInvalidID)
+	}
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	_waitlist := Waitlist{}
+	err = dbGet(ctx, tx, "Waitlist", &_waitlist, tx.Rebind(`SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists WHERE waitlists.id = ? LIMIT 1 `+lock), id)
+	if err != nil {
+		return nil, err
+	}
+	_waitlist.snapshot()
+	return &_waitlist, nil
+}
+
+// FindWaitlistsWhereLock is same as FindWaitlistsWhere but runs in the transaction tx and lock
+// the selected rows with the lock mode, e.g. ForUpdateSkipLocked, until the transaction ends.
+// The where clause should be a condition only, i.e. with no ORDER BY or LIMIT.
+func FindWaitlistsWhereLock(tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (waitlists []Waitlist, err error) {
+	return FindWaitlistsWhereLockContext(context.Background(), tx, mode, where, args...)
+}
+
+// FindWaitlistsWhereLockContext is same as FindWaitlistsWhereLock but with the context ctx.
+func FindWaitlistsWhereLockContext(ctx context.Context, tx *sqlx.Tx, mode LockMode, where string, args ...interface{}) (waitlists []Waitlist, err error) {
+	ctx, span := startSpan(ctx, "FindWaitlistsWhereLock", "waitlists")
+	defer span.End()
+	lock, err := lockClause(tx, mode)
+	if err != nil {
+		return nil, err
+	}
+	sql := "SELECT COALESCE(waitlists.patient_id, 0) AS patient_id, COALESCE(waitlists.physician_id, 0) AS physician_id, waitlists.preferred_from, waitlists.preferred_until, waitlists.status, waitlists.offered_start, waitlists.offered_at, waitlists.appointment_id, waitlists.id, waitlists.created_at, waitlists.updated_at FROM waitlists"
+	if len(where) > 0 {
+		sql = sql + " WHERE " + where
+	}
+	err = dbSelect(ctx, tx, "Waitlist", &waitlists, tx.Rebind(sql+" "+lock), args...)
+	if err != nil {
+		return nil, err
+	}
+	snapshotWaitlists(waitlists)
+	return waitlists, nil
+}
+
+// CreateWaitlist use a named params to create a single Waitlist record.
+// A named params is key-value map like map[string]interface{}{"first_name": "John", "age": 23} .
+func CreateWaitlist(am map[string]interface{}) (int64, error) {
+	return CreateWaitlistContext(context.Background(), am)
+}
+
+// CreateWaitlistContext is same as CreateWaitlist but with the context ctx.
+func CreateWaitlistContext(ctx context.Context, am map[string]interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "CreateWaitlist", "waitlists")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("CreateWaitlist error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	keys := allKeys(am)
+	sqlFmt := `INSERT INTO waitlists (%s) VALUES (%s)`
+	sql := fmt.Sprintf(sqlFmt, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
+	result, err := dbNamedExec(ctx, DB, "Waitlist", sql, am)
+	if err != nil {
+		return 0, err
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		logger().Error("Get the last insert id error", "model", "Waitlist", "error", err)
+		return 0, err
+	}
+	return lastId, nil
+}
+
+// CreateWaitlists creates the Waitlist records of the slice waitlists with multi-row INSERT statements,
+// see BulkInsertChunkSize. The structs are validated and time stamped, and their Id fields
+// are set to the ids of the created records, which are returned too. No callbacks are run.
+func CreateWaitlists(waitlists []Waitlist) ([]int64, error) {
+	return CreateWaitlistsContext(context.Background(), waitlists)
+}
+
+// CreateWaitlistsContext is same as CreateWaitlists but with the context ctx.
+func CreateWaitlistsContext(ctx context.Context, waitlists []Waitlist) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreateWaitlists", "waitlists")
+	defer span.End()
+	t := time.Now()
+	rows := make([][]interface{}, len(waitlists))
+	for i := range waitlists {
+		_waitlist := &waitlists[i]
+		if _waitlist.Status == "" {
+			_waitlist.Status = WaitlistWaiting
+		}
+		ok, err := govalidator.ValidateStruct(_waitlist)
+		if !ok {
+			err = &ValidationError{Model: "Waitlist", Index: i, Err: err}
+			logger().Warn(err.Error(), "model", "Waitlist")
+			return nil, err
+		}
+		_waitlist.CreatedAt = t
+		_waitlist.UpdatedAt = t
+		rows[i] = []interface{}{_waitlist.PatientId, _waitlist.PhysicianId, _waitlist.PreferredFrom, _waitlist.PreferredUntil, _waitlist.Status, _waitlist.OfferedStart, _waitlist.OfferedAt, _waitlist.AppointmentId, _waitlist.CreatedAt, _waitlist.UpdatedAt}
+	}
+	ids, err := bulkInsert(ctx, "Waitlist", "waitlists", []string{"patient_id", "physician_id", "preferred_from", "preferred_until", "status", "offered_start", "offered_at", "appointment_id", "created_at", "updated_at"}, rows)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		waitlists[i].Id = id
+		waitlists[i].snapshot()
+	}
+	return ids, nil
+}
+
+// CreateWaitlistsMaps use a slice of named params to create Waitlist records like CreateWaitlist does,
+// but with multi-row INSERT statements, see BulkInsertChunkSize. A column missing in some
+// of the maps gets its default value. The ids of the created records are returned in order.
+func CreateWaitlistsMaps(ams []map[string]interface{}) ([]int64, error) {
+	return CreateWaitlistsMapsContext(context.Background(), ams)
+}
+
+// CreateWaitlistsMapsContext is same as CreateWaitlistsMaps but with the context ctx.
+func CreateWaitlistsMapsContext(ctx context.Context, ams []map[string]interface{}) ([]int64, error) {
+	ctx, span := startSpan(ctx, "CreateWaitlistsMaps", "waitlists")
+	defer span.End()
+	for i, am := range ams {
+		if len(am) == 0 {
+			return nil, fmt.Errorf("CreateWaitlistsMaps error: %w at index %d", ErrEmptyAttributes, i)
+		}
+	}
+	keys, rows := bulkMapRows(ams)
+	return bulkInsert(ctx, "Waitlist", "waitlists", keys, rows)
+}
+
+// Create is a method for Waitlist to create a record, the Id of the object is set to the new id.
+// It runs the BeforeValidate, BeforeCreate, AfterCreate and AfterCommit callbacks if implemented.
+func (_waitlist *Waitlist) Create() (int64, error) {
+	return _waitlist.CreateContext(context.Background())
+}
+
+// CreateContext is same as Create but with the context ctx.
+func (_waitlist *Waitlist) CreateContext(ctx context.Context) (int64, error) {
+	ctx, span := startSpan(ctx, "Waitlist.Create", "waitlists")
+	defer span.End()
+	if _waitlist.Status == "" {
+		_waitlist.Status = WaitlistWaiting
+	}
+	if err := runBeforeValidate(_waitlist); err != nil {
+		return 0, err
+	}
+	ok, err := govalidator.ValidateStruct(_waitlist)
+	if !ok {
+		err = &ValidationError{Model: "Waitlist", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Waitlist")
+		return 0, err
+	}
+	if err = runBeforeCreate(_waitlist); err != nil {
+		return 0, err
+	}
+	t := time.Now()
+	_waitlist.CreatedAt = t
+	_waitlist.UpdatedAt = t
+	sql := `INSERT INTO waitlists (patient_id,physician_id,preferred_from,preferred_until,status,offered_start,offered_at,appointment_id,created_at,updated_at) VALUES (:patient_id,:physician_id,:preferred_from,:preferred_until,:status,:offered_start,:offered_at,:appointment_id,:created_at,:updated_at)`
+	result, err := dbNamedExec(ctx, DB, "Waitlist", sql, _waitlist)
+	if err != nil {
+		return 0, err
+	}
+	lastId, err := result.LastInsertId()
+	if err != nil {
+		logger().Error("Get the last insert id error", "model", "Waitlist", "error", err)
+		return 0, err
+	}
+	_waitlist.Id = lastId
+	_waitlist.savedChanges = diffAttributes(nil, _waitlist.attributes())
+	_waitlist.snapshot()
+	if err = runAfterCreate(ctx, _waitlist); err != nil {
+		return lastId, err
+	}
+	return lastId, nil
+}
+
+// Reload is a method for Waitlist to reload the attributes of the object from the database.
+// The associations loaded into the object are reset.
+func (_waitlist *Waitlist) Reload() error {
+	return _waitlist.ReloadContext(context.Background())
+}
+
+// ReloadContext is same as Reload but with the context ctx.
+func (_waitlist *Waitlist) ReloadContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Waitlist.Reload", "waitlists")
+	defer span.End()
+	if _waitlist.Id == 0 {
+		return fmt.Errorf("Waitlist.Reload error: %w", ErrInvalidID)
+	}
+	waitlist, err := FindWaitlistContext(ctx, _waitlist.Id)
+	if err != nil {
+		return err
+	}
+	*_waitlist = *waitlist
+	return nil
+}
+
+// Destroy is method used for a Waitlist object to be destroyed.
+// It runs the BeforeDestroy, AfterDestroy and AfterCommit callbacks if implemented.
+func (_waitlist *Waitlist) Destroy() error {
+	return _waitlist.DestroyContext(context.Background())
+}
+
+// DestroyContext is same as Destroy but with the context ctx.
+func (_waitlist *Waitlist) DestroyContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Waitlist.Destroy", "waitlists")
+	defer span.End()
+	if _waitlist.Id == 0 {
+		return fmt.Errorf("Waitlist.Destroy error: %w", ErrInvalidID)
+	}
+	if err := runBeforeDestroy(_waitlist); err != nil {
+		return err
+	}
+	err := DestroyWaitlistContext(ctx, _waitlist.Id)
+	if err != nil {
+		return err
+	}
+	return runAfterDestroy(ctx, _waitlist)
+}
+
+// DestroyWaitlist will destroy a Waitlist record specified by the id parameter.
+func DestroyWaitlist(id int64) error {
+	return DestroyWaitlistContext(context.Background(), id)
+}
+
+// DestroyWaitlistContext is same as DestroyWaitlist but with the context ctx.
+func DestroyWaitlistContext(ctx context.Context, id int64) error {
+	ctx, span := startSpan(ctx, "DestroyWaitlist", "waitlists")
+	defer span.End()
+	stmt, err := prepare(ctx, "Waitlist", rebind(`DELETE FROM waitlists WHERE id = ?`))
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec(ctx, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DestroyWaitlists will destroy Waitlist records those specified by the ids parameters.
+func DestroyWaitlists(ids ...int64) (int64, error) {
+	return DestroyWaitlistsContext(context.Background(), ids...)
+}
+
+// DestroyWaitlistsContext is same as DestroyWaitlists but with the context ctx.
+func DestroyWaitlistsContext(ctx context.Context, ids ...int64) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyWaitlists", "waitlists")
+	defer span.End()
+	if len(ids) == 0 {
+		err := fmt.Errorf("DestroyWaitlists error: %w", ErrNoIDs)
+		logger().Warn(err.Error(), "model", "Waitlist")
+		return 0, err
+	}
+	idsHolder := strings.Repeat(",?", len(ids)-1)
+	sql := fmt.Sprintf(`DELETE FROM waitlists WHERE id IN (?%s)`, idsHolder)
+	idsT := []interface{}{}
+	for _, id := range ids {
+		idsT = append(idsT, interface{}(id))
+	}
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, idsT...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// DestroyWaitlistsWhere delete records by a where clause restriction.
+// e.g. DestroyWaitlistsWhere("name = ?", "John")
+// And this func will not call the association dependent action
+func DestroyWaitlistsWhere(where string, args ...interface{}) (int64, error) {
+	return DestroyWaitlistsWhereContext(context.Background(), where, args...)
+}
+
+// DestroyWaitlistsWhereContext is same as DestroyWaitlistsWhere but with the context ctx.
+func DestroyWaitlistsWhereContext(ctx context.Context, where string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "DestroyWaitlistsWhere", "waitlists")
+	defer span.End()
+	sql := `DELETE FROM waitlists WHERE `
+	if len(where) > 0 {
+		sql = sql + where
+	} else {
+		return 0, fmt.Errorf("DestroyWaitlistsWhere error: %w", ErrNoWhere)
+	}
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
+
+// Save method is used for a Waitlist object to update an existed record mainly.
+// If no id provided a new record will be created, and if the record of the id doesn't exist
+// it will be created with the id.
+// A loaded object only updates its changed columns, see ChangedFields.
+// The object is reloaded from the database after it's saved.
+// It runs the BeforeValidate, BeforeUpdate, AfterUpdate and AfterCommit callbacks if implemented.
+func (_waitlist *Waitlist) Save() error {
+	return _waitlist.SaveContext(context.Background())
+}
+
+// SaveContext is same as Save but with the context ctx.
+func (_waitlist *Waitlist) SaveContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Waitlist.Save", "waitlists")
+	defer span.End()
+	if _waitlist.Id == 0 {
+		_, err := _waitlist.CreateContext(ctx)
+		return err
+	}
+	if _waitlist.Status == "" {
+		_waitlist.Status = WaitlistWaiting
+	}
+	if err := runBeforeValidate(_waitlist); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_waitlist)
+	if !ok {
+		err = &ValidationError{Model: "Waitlist", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Waitlist")
+		return err
+	}
+	if err = runBeforeUpdate(_waitlist); err != nil {
+		return err
+	}
+	old := _waitlist.original
+	if old != nil && !_waitlist.Changed() {
+		// a loaded object without changes has nothing to write
+		_waitlist.savedChanges = nil
+		return runAfterUpdate(ctx, _waitlist)
+	}
+	_waitlist.UpdatedAt = time.Now()
+	if old != nil {
+		// a loaded object only updates its changed columns
+		sqlFmt := `UPDATE waitlists SET %s WHERE id = %v`
+		sqlStr := fmt.Sprintf(sqlFmt, namedSets(append(_waitlist.ChangedFields(), "updated_at")), _waitlist.Id)
+		_, err = dbNamedExec(ctx, DB, "Waitlist", sqlStr, _waitlist)
+	} else {
+		// an object not loaded updates all its columns, or it's created with the id if the record doesn't exist
+		if _waitlist.CreatedAt.IsZero() {
+			_waitlist.CreatedAt = _waitlist.UpdatedAt
+		}
+		_, err = upsertWithId(ctx, "Waitlist", "waitlists", _waitlist.Id, _waitlist, []string{"id", "patient_id", "physician_id", "preferred_from", "preferred_until", "status", "offered_start", "offered_at", "appointment_id", "created_at", "updated_at"}, []string{"patient_id", "physician_id", "preferred_from", "preferred_until", "status", "offered_start", "offered_at", "appointment_id", "updated_at"})
+	}
+	if err != nil {
+		return err
+	}
+	if err = _waitlist.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_waitlist.savedChanges = diffAttributes(old, _waitlist.attributes())
+	return runAfterUpdate(ctx, _waitlist)
+}
+
+// UpsertWaitlist use a named params to create a single Waitlist record, or to update the updateColumns
+// of the existed record instead if it conflicts with the params on the conflictColumns.
+// The id of the created or updated record is returned. With MySQL the conflict is detected
+// on any unique key of the table, the conflictColumns is only used by PostgreSQL and SQLite, so the
+// record conflicting on another unique key than the conflictColumns is updated and its id is returned.
+func UpsertWaitlist(am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	return UpsertWaitlistContext(context.Background(), am, conflictColumns, updateColumns)
+}
+
+// UpsertWaitlistContext is same as UpsertWaitlist but with the context ctx.
+func UpsertWaitlistContext(ctx context.Context, am map[string]interface{}, conflictColumns, updateColumns []string) (int64, error) {
+	ctx, span := startSpan(ctx, "UpsertWaitlist", "waitlists")
+	defer span.End()
+	if len(am) == 0 {
+		return 0, fmt.Errorf("UpsertWaitlist error: %w", ErrEmptyAttributes)
+	}
+	t := time.Now()
+	for _, v := range []string{"created_at", "updated_at"} {
+		if am[v] == nil {
+			am[v] = t
+		}
+	}
+	return upsert(ctx, "Waitlist", "waitlists", am, allKeys(am), conflictColumns, updateColumns)
+}
+
+// Upsert is a method for Waitlist to create a record, or to update the updateColumns of the existed
+// record instead if it conflicts with the object on the conflictColumns, see UpsertWaitlist.
+// The Id of the object is set to the id of the created or updated record.
+// It runs the BeforeValidate callback if implemented.
+func (_waitlist *Waitlist) Upsert(conflictColumns, updateColumns []string) error {
+	return _waitlist.UpsertContext(context.Background(), conflictColumns, updateColumns)
+}
+
+// UpsertContext is same as Upsert but with the context ctx.
+func (_waitlist *Waitlist) UpsertContext(ctx context.Context, conflictColumns, updateColumns []string) error {
+	ctx, span := startSpan(ctx, "Waitlist.Upsert", "waitlists")
+	defer span.End()
+	if _waitlist.Status == "" {
+		_waitlist.Status = WaitlistWaiting
+	}
+	if err := runBeforeValidate(_waitlist); err != nil {
+		return err
+	}
+	ok, err := govalidator.ValidateStruct(_waitlist)
+	if !ok {
+		err = &ValidationError{Model: "Waitlist", Index: -1, Err: err}
+		logger().Warn(err.Error(), "model", "Waitlist")
+		return err
+	}
+	t := time.Now()
+	if _waitlist.CreatedAt.IsZero() {
+		_waitlist.CreatedAt = t
+	}
+	_waitlist.UpdatedAt = t
+	keys := []string{"patient_id", "physician_id", "preferred_from", "preferred_until", "status", "offered_start", "offered_at", "appointment_id", "created_at", "updated_at"}
+	if _waitlist.Id != 0 {
+		keys = append([]string{"id"}, keys...)
+	}
+	id, err := upsert(ctx, "Waitlist", "waitlists", _waitlist, keys, conflictColumns, updateColumns)
+	if err != nil {
+		return err
+	}
+	_waitlist.Id = id
+	_waitlist.snapshot()
+	return nil
+}
+
+// UpdateWaitlist is used to update a record with a id and map[string]interface{} typed key-value parameters.
+func UpdateWaitlist(id int64, am map[string]interface{}) error {
+	return UpdateWaitlistContext(context.Background(), id, am)
+}
+
+// UpdateWaitlistContext is same as UpdateWaitlist but with the context ctx.
+func UpdateWaitlistContext(ctx context.Context, id int64, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "UpdateWaitlist", "waitlists")
+	defer span.End()
+	if len(am) == 0 {
+		return fmt.Errorf("UpdateWaitlist error: %w", ErrEmptyAttributes)
+	}
+	am["updated_at"] = time.Now()
+	return updateWaitlistColumns(ctx, id, am)
+}
+
+// updateWaitlistColumns is used to update the columns of a record with a id as they are in the attributes map.
+func updateWaitlistColumns(ctx context.Context, id int64, am map[string]interface{}) error {
+	if len(am) == 0 {
+		return fmt.Errorf("Waitlist.UpdateColumns error: %w", ErrEmptyAttributes)
+	}
+	keys := allKeys(am)
+	sqlFmt := `UPDATE waitlists SET %s WHERE id = %v`
+	setKeysArr := []string{}
+	for _, v := range keys {
+		s := fmt.Sprintf(" %s = :%s", v, v)
+		setKeysArr = append(setKeysArr, s)
+	}
+	sqlStr := fmt.Sprintf(sqlFmt, strings.Join(setKeysArr, ", "), id)
+	_, err := dbNamedExec(ctx, DB, "Waitlist", sqlStr, am)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update is a method used to update a Waitlist record with the map[string]interface{} typed key-value parameters.
+// The object is reloaded from the database after it's updated.
+func (_waitlist *Waitlist) Update(am map[string]interface{}) error {
+	return _waitlist.UpdateContext(context.Background(), am)
+}
+
+// UpdateContext is same as Update but with the context ctx.
+func (_waitlist *Waitlist) UpdateContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Waitlist.Update", "waitlists")
+	defer span.End()
+	if _waitlist.Id == 0 {
+		return fmt.Errorf("Waitlist.Update error: %w", ErrInvalidID)
+	}
+	if err := runBeforeUpdate(_waitlist); err != nil {
+		return err
+	}
+	old := _waitlist.attributes()
+	err := UpdateWaitlistContext(ctx, _waitlist.Id, am)
+	if err != nil {
+		return err
+	}
+	if err = _waitlist.ReloadContext(ctx); err != nil {
+		return err
+	}
+	_waitlist.savedChanges = diffAttributes(old, _waitlist.attributes())
+	return runAfterUpdate(ctx, _waitlist)
+}
+
+// UpdateAttributes method is supposed to be used to update Waitlist records as corresponding update_attributes in Ruby on Rails.
+// The attributes are assigned to the object which is then saved, i.e. it's validated,
+// the callbacks are run and the updated_at is stamped, see Save.
+func (_waitlist *Waitlist) UpdateAttributes(am map[string]interface{}) error {
+	return _waitlist.UpdateAttributesContext(context.Background(), am)
+}
+
+// UpdateAttributesContext is same as UpdateAttributes but with the context ctx.
+func (_waitlist *Waitlist) UpdateAttributesContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Waitlist.UpdateAttributes", "waitlists")
+	defer span.End()
+	if _waitlist.Id == 0 {
+		return fmt.Errorf("Waitlist.UpdateAttributes error: %w", ErrInvalidID)
+	}
+	if err := assignColumns(_waitlist, am); err != nil {
+		return err
+	}
+	return _waitlist.SaveContext(ctx)
+}
+
+// UpdateColumns method is supposed to be used to update Waitlist records as corresponding update_columns in Ruby on Rails.
+// The columns are written as they are, skipping the validation, the callbacks and the updated_at
+// stamp, and then assigned to the object. A value which can't be assigned fails before anything is written.
+func (_waitlist *Waitlist) UpdateColumns(am map[string]interface{}) error {
+	return _waitlist.UpdateColumnsContext(context.Background(), am)
+}
+
+// UpdateColumnsContext is same as UpdateColumns but with the context ctx.
+func (_waitlist *Waitlist) UpdateColumnsContext(ctx context.Context, am map[string]interface{}) error {
+	ctx, span := startSpan(ctx, "Waitlist.UpdateColumns", "waitlists")
+	defer span.End()
+	if _waitlist.Id == 0 {
+		return fmt.Errorf("Waitlist.UpdateColumns error: %w", ErrInvalidID)
+	}
+	assigned := *_waitlist
+	if err := assignColumns(&assigned, am); err != nil {
+		return err
+	}
+	if err := updateWaitlistColumns(ctx, _waitlist.Id, am); err != nil {
+		return err
+	}
+	*_waitlist = assigned
+	markSaved(_waitlist.original, _waitlist.attributes(), allKeys(am))
+	return nil
+}
+
+// UpdateWaitlistsBySql is used to update Waitlist records by a SQL clause
+// using the '?' binding syntax.
+func UpdateWaitlistsBySql(sql string, args ...interface{}) (int64, error) {
+	return UpdateWaitlistsBySqlContext(context.Background(), sql, args...)
+}
+
+// UpdateWaitlistsBySqlContext is same as UpdateWaitlistsBySql but with the context ctx.
+func UpdateWaitlistsBySqlContext(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	ctx, span := startSpan(ctx, "UpdateWaitlistsBySql", "waitlists")
+	defer span.End()
+	if sql == "" {
+		return 0, fmt.Errorf("UpdateWaitlistsBySql error: %w", ErrBlankSQL)
+	}
+	stmt, err := prepare(ctx, "Waitlist", rebind(sql))
+	if err != nil {
+		return 0, err
+	}
+	result, err := stmt.Exec(ctx, args...)
+	if err != nil {
+		return 0, err
+	}
+	cnt, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return cnt, nil
+}
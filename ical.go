@@ -0,0 +1,383 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ICalDomain is the domain part of the UIDs of the exported appointments, i.e. "appointment-<id>@<ICalDomain>",
+// it should be unique to the app so the UIDs are globally unique as RFC 5545 asks.
+var ICalDomain = "gor-models-sample"
+
+// icalTimeFormat is the UTC DATE-TIME format of RFC 5545.
+const icalTimeFormat = "20060102T150405Z"
+
+// PhysicianICalendar renders the appointments of the physician of physicianId as an RFC 5545 calendar,
+// e.g. to be served as a .ics feed. The events are named after the patients.
+func PhysicianICalendar(physicianId int64) ([]byte, error) {
+	return PhysicianICalendarContext(context.Background(), physicianId)
+}
+
+// PhysicianICalendarContext is same as PhysicianICalendar but with the context ctx.
+func PhysicianICalendarContext(ctx context.Context, physicianId int64) ([]byte, error) {
+	ctx, span := startSpan(ctx, "PhysicianICalendar", "appointments")
+	defer span.End()
+	physician, err := FindPhysicianContext(ctx, physicianId)
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := FindAppointmentsWhereContext(ctx, "physician_id = ? AND appointment_date IS NOT NULL ORDER BY appointment_date ASC", physicianId)
+	if err != nil {
+		return nil, err
+	}
+	if err = includeAppointmentsAssocs(ctx, appointments, []string{"physician", "patient"}, false); err != nil {
+		return nil, err
+	}
+	return renderICalendar(physician.Name, appointments, func(a Appointment) string {
+		return "Appointment with " + a.Patient.Name
+	}), nil
+}
+
+// PatientICalendar renders the appointments of the patient of patientId as an RFC 5545 calendar,
+// e.g. to be served as a .ics feed. The events are named after the physicians.
+func PatientICalendar(patientId int64) ([]byte, error) {
+	return PatientICalendarContext(context.Background(), patientId)
+}
+
+// PatientICalendarContext is same as PatientICalendar but with the context ctx.
+func PatientICalendarContext(ctx context.Context, patientId int64) ([]byte, error) {
+	ctx, span := startSpan(ctx, "PatientICalendar", "appointments")
+	defer span.End()
+	patient, err := FindPatientContext(ctx, patientId)
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := FindAppointmentsWhereContext(ctx, "patient_id = ? AND appointment_date IS NOT NULL ORDER BY appointment_date ASC", patientId)
+	if err != nil {
+		return nil, err
+	}
+	if err = includeAppointmentsAssocs(ctx, appointments, []string{"physician", "patient"}, false); err != nil {
+		return nil, err
+	}
+	return renderICalendar(patient.Name, appointments, func(a Appointment) string {
+		return "Appointment with " + a.Physician.Name
+	}), nil
+}
+
+// renderICalendar renders the appointments as the VEVENTs of a calendar named name, summary names the events.
+func renderICalendar(name string, appointments []Appointment, summary func(Appointment) string) []byte {
+	var b bytes.Buffer
+	writeICalLine(&b, "BEGIN", "VCALENDAR")
+	writeICalLine(&b, "VERSION", "2.0")
+	writeICalLine(&b, "PRODID", "-//go-on-rails//gor_models_sample//EN")
+	writeICalLine(&b, "CALSCALE", "GREGORIAN")
+	writeICalLine(&b, "METHOD", "PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME", escapeICalText(name))
+	stamp := time.Now().UTC().Format(icalTimeFormat)
+	for _, v := range appointments {
+		start := v.AppointmentDate.UTC()
+		writeICalLine(&b, "BEGIN", "VEVENT")
+		writeICalLine(&b, "UID", v.icalUid())
+		writeICalLine(&b, "DTSTAMP", stamp)
+		writeICalLine(&b, "LAST-MODIFIED", v.UpdatedAt.UTC().Format(icalTimeFormat))
+		writeICalLine(&b, "SEQUENCE", strconv.FormatInt(v.LockVersion, 10))
+		writeICalLine(&b, "DTSTART", start.Format(icalTimeFormat))
+		writeICalLine(&b, "DTEND", start.Add(DefaultAppointmentLength).Format(icalTimeFormat))
+		writeICalLine(&b, "SUMMARY", escapeICalText(summary(v)))
+		writeICalLine(&b, "STATUS", icalStatus(v.Status))
+		if v.CancellationReason != nil {
+			writeICalLine(&b, "DESCRIPTION", escapeICalText(*v.CancellationReason))
+		}
+		writeICalLine(&b, "END", "VEVENT")
+	}
+	writeICalLine(&b, "END", "VCALENDAR")
+	return b.Bytes()
+}
+
+// icalUid returns the UID of the Appointment in a calendar: the UID it's imported with,
+// or one made of its id otherwise.
+func (_appointment *Appointment) icalUid() string {
+	if _appointment.IcalUid != nil {
+		return *_appointment.IcalUid
+	}
+	return fmt.Sprintf("appointment-%d@%s", _appointment.Id, ICalDomain)
+}
+
+// icalStatus maps the status of an Appointment to the STATUS of a VEVENT.
+func icalStatus(status string) string {
+	switch status {
+	case AppointmentScheduled:
+		return "TENTATIVE"
+	case AppointmentCancelled, AppointmentNoShow:
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
+
+// writeICalLine writes a content line, folded into lines of at most 75 octets as RFC 5545 asks.
+func writeICalLine(b *bytes.Buffer, name, value string) {
+	line := name + ":" + value
+	n := 0
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	b.WriteString("\r\n")
+}
+
+// escapeICalText escapes a TEXT value of RFC 5545.
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icalEvent is a VEVENT parsed by parseICalendar.
+type icalEvent struct {
+	uid       string
+	start     time.Time
+	allDay    bool
+	cancelled bool
+}
+
+// ImportICalendar imports the VEVENTs of the RFC 5545 calendar read from r as appointments of the physician
+// of physicianId. The import is idempotent: an event is matched to an appointment by its UID, i.e. the one of an
+// exported appointment or the UID it's imported with before, and the matched appointment is moved or cancelled
+// as the event is, while the other events are created as new appointments. The all day events are skipped, and
+// so are the events of the deleted appointments and the cancelled events of the appointments which can't be
+// cancelled anymore, e.g. a checked in one, see CanTransition.
+// The import is done in a transaction, so nothing is written if any event fails to be imported, e.g. it overlaps
+// another appointment of the physician, see ConflictError. The created or changed appointments are returned.
+func ImportICalendar(r io.Reader, physicianId int64) ([]Appointment, error) {
+	return ImportICalendarContext(context.Background(), r, physicianId)
+}
+
+// ImportICalendarContext is same as ImportICalendar but with the context ctx.
+func ImportICalendarContext(ctx context.Context, r io.Reader, physicianId int64) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "ImportICalendar", "appointments")
+	defer span.End()
+	physician, err := FindPhysicianContext(ctx, physicianId)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := physician.Location()
+	if err != nil {
+		return nil, err
+	}
+	events, err := parseICalendar(r, loc)
+	if err != nil {
+		return nil, err
+	}
+	type change struct {
+		event    icalEvent
+		existing *Appointment
+	}
+	var appointments []Appointment
+	err = inTransaction(ctx, func(ctx context.Context) error {
+		// the transaction may be retried, see WithTxContext
+		appointments = []Appointment{}
+		changes := []change{}
+		matchedIds := []int64{}
+		times := []time.Time{}
+		for _, ev := range events {
+			if ev.allDay {
+				continue
+			}
+			existing, err := findICalAppointment(ctx, physicianId, ev.uid)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				if existing.DeletedAt != nil {
+					// the appointment is deleted, the calendar doesn't bring it back
+					continue
+				}
+				switch existing.Status {
+				case AppointmentCompleted, AppointmentCancelled, AppointmentNoShow:
+					// the appointment is over, the calendar can't change it anymore
+					continue
+				}
+				if ev.cancelled && !CanTransition(existing.Status, AppointmentCancelled) {
+					logger().Warn("Skip the cancelled event of an appointment which can't be cancelled", "uid", ev.uid, "id", existing.Id, "status", existing.Status)
+					continue
+				}
+				if !ev.cancelled && existing.AppointmentDate != nil && existing.AppointmentDate.Equal(ev.start) {
+					continue
+				}
+				matchedIds = append(matchedIds, existing.Id)
+			} else if ev.cancelled {
+				continue
+			}
+			if !ev.cancelled {
+				times = append(times, ev.start)
+			}
+			changes = append(changes, change{event: ev, existing: existing})
+		}
+		// the matched appointments are moved or cancelled, so they are no conflicts
+		if err := checkConflicts(ctx, physicianId, times, matchedIds...); err != nil {
+			return err
+		}
+		if err := checkOverlaps(times); err != nil {
+			return err
+		}
+		for _, v := range changes {
+			var err error
+			switch {
+			case v.existing != nil && v.event.cancelled:
+				err = v.existing.CancelContext(ctx, "Cancelled in the imported calendar")
+			case v.existing != nil:
+				start := v.event.start
+				v.existing.AppointmentDate = &start
+				err = v.existing.SaveContext(ctx)
+			default:
+				start, uid := v.event.start, v.event.uid
+				v.existing = &Appointment{AppointmentDate: &start, PhysicianId: &physicianId, IcalUid: &uid}
+				_, err = v.existing.CreateContext(ctx)
+			}
+			if err != nil {
+				return err
+			}
+			appointments = append(appointments, *v.existing)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// findICalAppointment finds the appointment of the physician with the UID uid of a VEVENT, the deleted ones
+// included, or returns nil. It's matched by the UID it's imported with, or by its id if uid is the one made of
+// its id, see icalUid.
+func findICalAppointment(ctx context.Context, physicianId int64, uid string) (*Appointment, error) {
+	appointments, err := FindAppointmentsWithDeletedWhereContext(ctx, "physician_id = ? AND ical_uid = ?", physicianId, uid)
+	if err != nil || len(appointments) > 0 {
+		return firstAppointment(appointments), err
+	}
+	if s := strings.TrimPrefix(uid, "appointment-"); s != uid && strings.HasSuffix(s, "@"+ICalDomain) {
+		if id, err := strconv.ParseInt(strings.TrimSuffix(s, "@"+ICalDomain), 10, 64); err == nil {
+			appointments, err = FindAppointmentsWithDeletedWhereContext(ctx, "physician_id = ? AND id = ? AND ical_uid IS NULL", physicianId, id)
+			return firstAppointment(appointments), err
+		}
+	}
+	return nil, nil
+}
+
+// firstAppointment returns the first of the appointments, or nil if there are none.
+func firstAppointment(appointments []Appointment) *Appointment {
+	if len(appointments) == 0 {
+		return nil
+	}
+	return &appointments[0]
+}
+
+// checkOverlaps returns a ConflictError if any of the appointments at the times would overlap each other.
+func checkOverlaps(times []time.Time) error {
+	sorted := append([]time.Time{}, times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	conflicts := []time.Time{}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Before(sorted[i-1].Add(DefaultAppointmentLength)) {
+			conflicts = append(conflicts, sorted[i])
+		}
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Times: conflicts}
+	}
+	return nil
+}
+
+// parseICalendar parses the VEVENTs of an RFC 5545 calendar, the DTSTARTs without a time zone are in loc.
+func parseICalendar(r io.Reader, loc *time.Location) ([]icalEvent, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	// unfold the folded lines
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+	events := []icalEvent{}
+	var ev *icalEvent
+	for _, line := range strings.Split(text, "\n") {
+		name, params, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			ev = &icalEvent{}
+		case name == "END" && value == "VEVENT" && ev != nil:
+			if ev.uid == "" {
+				return nil, errors.New("Invalid iCalendar: a VEVENT has no UID")
+			}
+			if ev.start.IsZero() {
+				return nil, fmt.Errorf("Invalid iCalendar: the VEVENT %s has no DTSTART", ev.uid)
+			}
+			events = append(events, *ev)
+			ev = nil
+		case ev == nil:
+			continue
+		case name == "UID":
+			ev.uid = value
+		case name == "DTSTART":
+			ev.start, ev.allDay, err = parseICalTime(params, value, loc)
+			if err != nil {
+				return nil, err
+			}
+		case name == "STATUS":
+			ev.cancelled = strings.EqualFold(value, "CANCELLED")
+		}
+	}
+	return events, nil
+}
+
+// splitICalLine splits a content line into its upper cased name, its parameters and its value.
+func splitICalLine(line string) (name string, params map[string]string, value string) {
+	quoted, colon := false, -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, ""
+	}
+	parts := strings.Split(line[:colon], ";")
+	params = map[string]string{}
+	for _, v := range parts[1:] {
+		if kv := strings.SplitN(v, "=", 2); len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, strings.TrimSpace(line[colon+1:])
+}
+
+// parseICalTime parses a DATE or DATE-TIME value with its parameters, a floating time is in loc.
+func parseICalTime(params map[string]string, value string, loc *time.Location) (t time.Time, allDay bool, err error) {
+	if params["VALUE"] == "DATE" || len(value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse(icalTimeFormat, value)
+		return t, false, err
+	}
+	if tzid := params["TZID"]; tzid != "" {
+		if loc, err = time.LoadLocation(tzid); err != nil {
+			return t, false, err
+		}
+	}
+	t, err = time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
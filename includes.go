@@ -0,0 +1,30 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// includesErrors collects the errors of loading the associations of a model by an IncludesWhere function.
+// If partial, it goes on loading the other associations after an error, see PhysicianIncludesWherePartial.
+type includesErrors struct {
+	model   string
+	partial bool
+	errs    []error
+}
+
+// add records the error err of loading the association assoc. It returns the error to fail with at once,
+// or nil to go on loading the other associations if partial.
+func (_e *includesErrors) add(assoc string, err error) error {
+	err = fmt.Errorf("Include %s of %s error: %w", assoc, _e.model, err)
+	_e.errs = append(_e.errs, err)
+	if _e.partial {
+		return nil
+	}
+	return err
+}
+
+// err returns the recorded errors joined, or nil if there are none.
+func (_e *includesErrors) err() error {
+	return errors.Join(_e.errs...)
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// SlowQueryThreshold is the duration a query takes at least to be logged as a slow one, at the warn
+// level with the function of the package it's issued by, e.g. PhysicianGetPatients. 0 turns it off.
+var SlowQueryThreshold time.Duration
+
+// Metrics receives the measurements of the queries, e.g. to be bound to a Prometheus counter
+// and histogram vectors labeled by the model and the operation. The operation of a query is
+// its SQL verb, e.g. "SELECT" or "INSERT".
+type Metrics interface {
+	// IncQuery counts a query, failed tells if it returned an error.
+	IncQuery(model, operation string, failed bool)
+	// ObserveQueryDuration records the duration of a query.
+	ObserveQueryDuration(model, operation string, d time.Duration)
+	// IncSlowQuery counts a query slower than SlowQueryThreshold.
+	IncSlowQuery(model, operation string)
+}
+
+// QueryMetrics receives the measurements of all the queries of the package if it's not nil.
+var QueryMetrics Metrics
+
+// packagePath is the import path of the package, to tell its functions in a call stack.
+var packagePath = strings.TrimSuffix(runtime.FuncForPC(reflect.ValueOf(queryOperation).Pointer()).Name(), ".queryOperation")
+
+// observeQuery measures the query of the model with its args issued at start and finished with err,
+// and logs and traces it with the rows it read or affected, see Logger and TracerProvider.
+func observeQuery(ctx context.Context, model, query string, args []interface{}, start time.Time, rows int64, err error) {
+	d := time.Since(start)
+	traceQuery(ctx, query, rows, err)
+	op := queryOperation(query)
+	slow := SlowQueryThreshold > 0 && d >= SlowQueryThreshold
+	if QueryMetrics != nil {
+		QueryMetrics.IncQuery(model, op, err != nil)
+		QueryMetrics.ObserveQueryDuration(model, op, d)
+		if slow {
+			QueryMetrics.IncSlowQuery(model, op)
+		}
+	}
+	switch {
+	case err != nil:
+		logQuery(ctx, slog.LevelError, "Query error", model, query, args, d, slog.Any("error", err))
+	case slow:
+		logQuery(ctx, slog.LevelWarn, "Slow query", model, query, args, d, slog.String("caller", queryCaller()))
+	default:
+		logQuery(ctx, slog.LevelDebug, "Query", model, query, args, d)
+	}
+}
+
+// observeFailedQuery observes the query of the model issued at start and failed with err before it's run,
+// e.g. by its prepare, in a span of its own like runQuery does.
+func observeFailedQuery(ctx context.Context, model, query string, args []interface{}, start time.Time, err error) {
+	ctx, span := startQuerySpan(ctx, queryOperation(query), start)
+	defer span.End()
+	observeQuery(ctx, model, query, args, start, 0, err)
+}
+
+// queryOperation returns the SQL verb of the query in upper case, e.g. "SELECT".
+func queryOperation(query string) string {
+	query = strings.TrimLeft(query, " \t\r\n(")
+	if i := strings.IndexAny(query, " \t\r\n("); i >= 0 {
+		query = query[:i]
+	}
+	return strings.ToUpper(query)
+}
+
+// queryCaller returns the function of the package the running query is issued by, i.e. the
+// outermost one of the package in the call stack before it's left, e.g. "PhysicianGetPatients"
+// when it calls FindPatientsBySql, or "(*Appointment).Save".
+func queryCaller() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	caller := ""
+	for {
+		frame, more := frames.Next()
+		name, ok := strings.CutPrefix(frame.Function, packagePath+".")
+		if !ok {
+			break
+		}
+		caller = name
+		if !more {
+			break
+		}
+	}
+	return caller
+}
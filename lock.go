@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// LockMode is a row locking clause of the SELECT statements issued by the
+// locking finders, e.g. FindPhysicianForUpdate or FindPhysiciansWhereLock.
+type LockMode int
+
+const (
+	// ForUpdate locks the selected rows exclusively, waiting for other locks.
+	ForUpdate LockMode = iota
+	// ForUpdateNoWait is same as ForUpdate but fails at once if a row is locked already.
+	ForUpdateNoWait
+	// ForUpdateSkipLocked is same as ForUpdate but skips the rows locked already.
+	ForUpdateSkipLocked
+	// ForShare locks the selected rows in shared mode, waiting for other exclusive locks.
+	ForShare
+	// ForShareNoWait is same as ForShare but fails at once if a row is locked already.
+	ForShareNoWait
+	// ForShareSkipLocked is same as ForShare but skips the rows locked already.
+	ForShareSkipLocked
+)
+
+var lockClauses = map[LockMode]string{
+	ForUpdate:           "FOR UPDATE",
+	ForUpdateNoWait:     "FOR UPDATE NOWAIT",
+	ForUpdateSkipLocked: "FOR UPDATE SKIP LOCKED",
+	ForShare:            "FOR SHARE",
+	ForShareNoWait:      "FOR SHARE NOWAIT",
+	ForShareSkipLocked:  "FOR SHARE SKIP LOCKED",
+}
+
+// lockClause returns the SQL clause of the lock mode for the driver of tx.
+// Row locks only make sense inside a transaction, so a nil tx is an error.
+// MySQL (8.0 or later) and PostgreSQL support all the lock modes.
+func lockClause(tx *sqlx.Tx, mode LockMode) (string, error) {
+	if tx == nil {
+		return "", ErrNoTransaction
+	}
+	clause, ok := lockClauses[mode]
+	if !ok {
+		return "", fmt.Errorf("Unknown lock mode: %d", mode)
+	}
+	switch tx.DriverName() {
+	case "mysql", "postgres", "pgx":
+		return clause, nil
+	default:
+		return "", fmt.Errorf("Row locking is not supported by the %s driver", tx.DriverName())
+	}
+}
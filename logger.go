@@ -0,0 +1,55 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Logger is the logger of the models package. Each query is logged at the debug level with
+// its model, SQL text, args and duration as the attributes, a failed one at the error level
+// with its error too, and a slow one at the warn level with its caller, see SlowQueryThreshold.
+// slog.Default() is used if it's nil, so set it to configure the logging of the package apart
+// from the app, e.g. to a logger with a discarding handler to silence it.
+var Logger *slog.Logger
+
+// RedactArgs turns the args of a query into the ones logged. The default one only keeps the
+// types of the args, so no personal data of the patients ends up in the logs.
+// Set it to nil to log the args as they are.
+var RedactArgs = redactArgs
+
+// logger returns the Logger of the package, or slog.Default() if it's nil.
+func logger() *slog.Logger {
+	if Logger != nil {
+		return Logger
+	}
+	return slog.Default()
+}
+
+// redactArgs replaces each of the args by its type, e.g. "string" or "time.Time".
+func redactArgs(args []interface{}) []interface{} {
+	redacted := make([]interface{}, len(args))
+	for i, v := range args {
+		redacted[i] = fmt.Sprintf("%T", v)
+	}
+	return redacted
+}
+
+// logQuery logs the query of the model with its args and duration d at the level, see Logger.
+func logQuery(ctx context.Context, level slog.Level, msg, model, query string, args []interface{}, d time.Duration, extra ...slog.Attr) {
+	l := logger()
+	if !l.Enabled(ctx, level) {
+		return
+	}
+	if RedactArgs != nil {
+		args = RedactArgs(args)
+	}
+	attrs := []slog.Attr{
+		slog.String("model", model),
+		slog.String("query", query),
+		slog.Any("args", args),
+		slog.Duration("duration", d),
+	}
+	l.LogAttrs(ctx, level, msg, append(attrs, extra...)...)
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Notifier delivers the reminders of ReminderScheduler, e.g. by email.
+type Notifier interface {
+	Notify(r Reminder) error
+}
+
+// reminderText returns the subject and the body of the message of the reminder r.
+func reminderText(r Reminder) (subject, body string) {
+	subject = fmt.Sprintf("Reminder: appointment on %s", r.At.Format("Mon, Jan 2 at 15:04"))
+	body = fmt.Sprintf("Dear %s,\r\n\r\nthis is a reminder of your appointment with %s on %s.\r\n",
+		r.Appointment.Patient.Name, r.Appointment.Physician.Name, r.At.Format("Monday, January 2, 2006 at 15:04 MST"))
+	return subject, body
+}
+
+// LogNotifier writes the reminders to W, e.g. os.Stdout or a file, or logs them to Logger at the info level if W is nil.
+// It's meant for the development.
+type LogNotifier struct {
+	W io.Writer
+}
+
+// Notify writes the reminder r.
+func (_n *LogNotifier) Notify(r Reminder) error {
+	subject, body := reminderText(r)
+	if _n.W == nil {
+		logger().Info("Reminder", "appointment_id", r.Appointment.Id, "lead", r.Lead, "subject", subject, "body", body)
+		return nil
+	}
+	msg := fmt.Sprintf("Reminder of appointment %d (%s before): %s\n%s", r.Appointment.Id, r.Lead, subject, body)
+	_, err := io.WriteString(_n.W, msg)
+	return err
+}
+
+// SMTPNotifier sends the reminders by email through the SMTP server at Addr, e.g. "localhost:25".
+// The server is used as net/smtp.SendMail does, so a local SMTP stand-in works for testing.
+type SMTPNotifier struct {
+	Addr string
+	// Auth is the authentication of the server, nil for none.
+	Auth smtp.Auth
+	// From is the sender address.
+	From string
+	// Recipient returns the email address of the patient of the reminder, the patients
+	// have no email column, so it's up to the app where the address comes from.
+	Recipient func(r Reminder) (string, error)
+}
+
+// Notify sends the reminder r by email.
+func (_n *SMTPNotifier) Notify(r Reminder) error {
+	if _n.Recipient == nil {
+		return fmt.Errorf("SMTPNotifier.Notify error: %w", ErrNoRecipient)
+	}
+	to, err := _n.Recipient(r)
+	if err != nil {
+		return err
+	}
+	if strings.ContainsAny(to, "\r\n") {
+		return fmt.Errorf("Invalid recipient address: %q", to)
+	}
+	subject, body := reminderText(r)
+	header := []string{
+		"From: " + _n.From,
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("UTF-8", subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	msg := strings.Join(header, "\r\n") + "\r\n\r\n" + body
+	return smtp.SendMail(_n.Addr, _n.Auth, _n.From, []string{to}, []byte(msg))
+}
+
+// NotifyOffer writes the offer o of a freed slot to a waitlisted patient, so LogNotifier
+// can be the WaitlistNotifier too.
+func (_n *LogNotifier) NotifyOffer(o WaitlistOffer) error {
+	if _n.W == nil {
+		logger().Info("Waitlist offer", "waitlist_id", o.Waitlist.Id, "start", o.Slot.Start,
+			"physician_id", o.Waitlist.PhysicianId, "patient_id", o.Waitlist.PatientId)
+		return nil
+	}
+	msg := fmt.Sprintf("Offer of waitlist %d: the slot on %s with physician %d for patient %d\n",
+		o.Waitlist.Id, o.Slot.Start.Format(time.RFC3339), o.Waitlist.PhysicianId, o.Waitlist.PatientId)
+	_, err := io.WriteString(_n.W, msg)
+	return err
+}
@@ -0,0 +1,202 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// The functions below run the queries of the models on DB or a transaction, every query
+// of the package goes through them so it's passed to the QueryHooks, and logged and measured
+// with the model it's issued for, see runQuery. The queries on DB are retried on the transient
+// errors, see QueryRetry, and they run in the transaction ctx is bound to if any, see TxContext.
+
+// dbGet is same as sqlx.GetContext on q, i.e. DB or a transaction, for the model.
+func dbGet(ctx context.Context, q sqlx.QueryerContext, model string, dest interface{}, query string, args ...interface{}) error {
+	if tx := ctxTx(ctx); tx != nil && !isTx(q) {
+		q = tx
+	}
+	if !isOpen(q) {
+		return ErrNotOpen
+	}
+	_, err := runQuery(ctx, model, query, args, !isTx(q), func(query string, args []interface{}) (sql.Result, int64, error) {
+		err := sqlx.GetContext(ctx, q, dest, query, args...)
+		return nil, getRows(err), notFound(model, err)
+	})
+	return err
+}
+
+// dbSelect is same as sqlx.SelectContext on q, i.e. DB or a transaction, for the model.
+func dbSelect(ctx context.Context, q sqlx.QueryerContext, model string, dest interface{}, query string, args ...interface{}) error {
+	if tx := ctxTx(ctx); tx != nil && !isTx(q) {
+		q = tx
+	}
+	if !isOpen(q) {
+		return ErrNotOpen
+	}
+	n := selectRows(dest)
+	_, err := runQuery(ctx, model, query, args, !isTx(q), func(query string, args []interface{}) (sql.Result, int64, error) {
+		truncateSlice(dest, n)
+		err := sqlx.SelectContext(ctx, q, dest, query, args...)
+		return nil, selectRows(dest), err
+	})
+	return err
+}
+
+// dbExec is same as ExecContext on e, i.e. DB or a transaction, for the model.
+func dbExec(ctx context.Context, e sqlx.ExecerContext, model string, query string, args ...interface{}) (sql.Result, error) {
+	if tx := ctxTx(ctx); tx != nil && !isTx(e) {
+		e = tx
+	}
+	if !isOpen(e) {
+		return nil, ErrNotOpen
+	}
+	return runQuery(ctx, model, query, args, !isTx(e), func(query string, args []interface{}) (sql.Result, int64, error) {
+		result, err := e.ExecContext(ctx, query, args...)
+		return result, execRows(result, err), err
+	})
+}
+
+// dbNamedExec is same as sqlx.NamedExecContext on e, i.e. DB or a transaction, for the model.
+// The query is bound first, so it's hooked and logged with the positional args.
+func dbNamedExec(ctx context.Context, e sqlx.ExtContext, model string, query string, arg interface{}) (sql.Result, error) {
+	if tx := ctxTx(ctx); tx != nil && !isTx(e) {
+		e = tx
+	}
+	if !isOpen(e) {
+		return nil, ErrNotOpen
+	}
+	start := time.Now()
+	bound, args, err := e.BindNamed(query, arg)
+	if err != nil {
+		observeFailedQuery(ctx, model, query, nil, start, err)
+		return nil, err
+	}
+	return dbExec(ctx, e, model, bound, args...)
+}
+
+// modelStmt is a prepared statement of a model acquired from the statement cache, its queries are
+// run like dbGet's. It's run once, as its statement is released to the cache after that.
+type modelStmt struct {
+	*cachedStmt
+	model string
+}
+
+// prepare gets the prepared statement of the query of the model on DB from the statement cache,
+// it's prepared if it's not cached, see StmtCacheSize.
+func prepare(ctx context.Context, model string, query string) (*modelStmt, error) {
+	if DB == nil {
+		return nil, ErrNotOpen
+	}
+	start := time.Now()
+	stmt, err := stmts.acquire(ctx, query)
+	if err != nil {
+		observeFailedQuery(ctx, model, query, nil, start, err)
+		return nil, err
+	}
+	return &modelStmt{cachedStmt: stmt, model: model}, nil
+}
+
+// run runs the statement with the args by fn through runQuery and releases it. If a QueryHook
+// rewrites the query, the statement of the rewritten one is run instead. The statement runs in
+// the transaction ctx is bound to if any, see TxContext.
+func (_s *modelStmt) run(ctx context.Context, args []interface{}, fn func(stmt *sqlx.Stmt, args []interface{}) (sql.Result, int64, error)) (sql.Result, error) {
+	defer stmts.release(_s.cachedStmt)
+	tx := ctxTx(ctx)
+	return runQuery(ctx, _s.model, _s.query, args, tx == nil, func(query string, args []interface{}) (sql.Result, int64, error) {
+		stmt := _s.Stmt
+		if query != _s.query {
+			s, err := stmts.acquire(ctx, query)
+			if err != nil {
+				return nil, 0, err
+			}
+			defer stmts.release(s)
+			stmt = s.Stmt
+		}
+		if tx != nil {
+			stmt = tx.StmtxContext(ctx, stmt)
+			defer stmt.Close()
+		}
+		return fn(stmt, args)
+	})
+}
+
+// Get is same as sqlx.Stmt.GetContext but run through runQuery.
+func (_s *modelStmt) Get(ctx context.Context, dest interface{}, args ...interface{}) error {
+	_, err := _s.run(ctx, args, func(stmt *sqlx.Stmt, args []interface{}) (sql.Result, int64, error) {
+		err := stmt.GetContext(ctx, dest, args...)
+		return nil, getRows(err), notFound(_s.model, err)
+	})
+	return err
+}
+
+// Select is same as sqlx.Stmt.SelectContext but run through runQuery.
+func (_s *modelStmt) Select(ctx context.Context, dest interface{}, args ...interface{}) error {
+	n := selectRows(dest)
+	_, err := _s.run(ctx, args, func(stmt *sqlx.Stmt, args []interface{}) (sql.Result, int64, error) {
+		truncateSlice(dest, n)
+		err := stmt.SelectContext(ctx, dest, args...)
+		return nil, selectRows(dest), err
+	})
+	return err
+}
+
+// Exec is same as sqlx.Stmt.ExecContext but run through runQuery.
+func (_s *modelStmt) Exec(ctx context.Context, args ...interface{}) (sql.Result, error) {
+	return _s.run(ctx, args, func(stmt *sqlx.Stmt, args []interface{}) (sql.Result, int64, error) {
+		result, err := stmt.ExecContext(ctx, args...)
+		return result, execRows(result, err), err
+	})
+}
+
+// isTx reports whether q is a transaction, whose queries aren't retried by themselves.
+func isTx(q interface{}) bool {
+	_, ok := q.(*sqlx.Tx)
+	return ok
+}
+
+// isOpen reports whether q, DB or a transaction, can run a query, i.e. it's a transaction or DB is open.
+func isOpen(q interface{}) bool {
+	return isTx(q) || DB != nil
+}
+
+// truncateSlice truncates dest, a pointer to a slice, to the length n it had before a Select, as the
+// Select appends to it, so a retried one doesn't keep the rows read by the failed attempt.
+func truncateSlice(dest interface{}, n int64) {
+	v := reflect.Indirect(reflect.ValueOf(dest))
+	if v.Kind() == reflect.Slice && v.CanSet() && int64(v.Len()) > n {
+		v.SetLen(int(n))
+	}
+}
+
+// getRows returns the number of the rows read by a Get finished with err.
+func getRows(err error) int64 {
+	if err != nil {
+		return 0
+	}
+	return 1
+}
+
+// selectRows returns the number of the rows read by a Select into dest, a pointer to a slice.
+func selectRows(dest interface{}) int64 {
+	v := reflect.Indirect(reflect.ValueOf(dest))
+	if v.Kind() != reflect.Slice {
+		return 0
+	}
+	return int64(v.Len())
+}
+
+// execRows returns the number of the rows affected by an Exec with the result.
+func execRows(result sql.Result, err error) int64 {
+	if err != nil {
+		return 0
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return n
+}
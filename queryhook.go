@@ -0,0 +1,124 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+)
+
+// QueryEvent is a query of a model passed to the QueryHooks.
+type QueryEvent struct {
+	// Operation is the SQL verb of the query, e.g. "SELECT", "INSERT", "UPDATE" or "DELETE".
+	Operation string
+	// Model is the model the query is issued for, e.g. "Patient".
+	Model string
+	// Query is the SQL text of the query and Args are its args, as they are run.
+	Query string
+	Args  []interface{}
+	// Result is the result of an Exec, nil for a SELECT. It's set for AfterQuery.
+	Result sql.Result
+	// Rows is the number of the rows read by a SELECT or affected otherwise. It's set for AfterQuery.
+	Rows int64
+	// Err is the error of the query. It's set for AfterQuery.
+	Err error
+	// Duration is how long the query takes. It's set for AfterQuery.
+	Duration time.Duration
+}
+
+// QueryHook intercepts the queries of the models, i.e. every query of the Find, Create, Update,
+// Destroy and other functions of the package, e.g. to scope them to a tenant or to assert them in tests.
+type QueryHook interface {
+	// BeforeQuery is called before the query is run. It may change the Query and the Args of e,
+	// which are then run, or return an error to abort the query with it.
+	BeforeQuery(ctx context.Context, e *QueryEvent) error
+	// AfterQuery is called after the query is run or aborted. It may change the Err of e,
+	// which is then returned by the query. It's only called if the BeforeQuery of the hook is
+	// called, i.e. not for the hooks after the one aborting the query.
+	AfterQuery(ctx context.Context, e *QueryEvent)
+}
+
+var (
+	queryHooksMu sync.RWMutex
+	queryHooks   []QueryHook
+)
+
+// AddQueryHook registers the hook h for all the queries of the package. The BeforeQuery of the
+// hooks are called in the order they are added, and the AfterQuery in the reverse order.
+// The hooks are global, see ContextWithQueryHooks for the ones of the queries of a context only,
+// e.g. of a parallel test.
+func AddQueryHook(h QueryHook) {
+	queryHooksMu.Lock()
+	defer queryHooksMu.Unlock()
+	queryHooks = append(queryHooks[:len(queryHooks):len(queryHooks)], h)
+}
+
+// ResetQueryHooks removes all the hooks added by AddQueryHook.
+func ResetQueryHooks() {
+	queryHooksMu.Lock()
+	defer queryHooksMu.Unlock()
+	queryHooks = nil
+}
+
+// queryHooksKey is the context key of the hooks of ContextWithQueryHooks.
+type queryHooksKey struct{}
+
+// ContextWithQueryHooks returns a copy of ctx with the hooks added for the queries run with it, e.g. by
+// FindPatientContext, after the hooks of AddQueryHook and the ones of ctx. Unlike AddQueryHook they
+// don't affect the queries of other contexts, so e.g. parallel tests can assert their own queries:
+//
+//	ctx := models.ContextWithQueryHooks(context.Background(), recorder)
+//	patient, err := models.FindPatientContext(ctx, id)
+func ContextWithQueryHooks(ctx context.Context, hooks ...QueryHook) context.Context {
+	parent := contextQueryHooks(ctx)
+	return context.WithValue(ctx, queryHooksKey{}, append(parent[:len(parent):len(parent)], hooks...))
+}
+
+// contextQueryHooks returns the hooks added to ctx by ContextWithQueryHooks.
+func contextQueryHooks(ctx context.Context) []QueryHook {
+	hooks, _ := ctx.Value(queryHooksKey{}).([]QueryHook)
+	return hooks
+}
+
+// runQuery runs the query of the model with its args by run through the QueryHooks, the global ones
+// and the ones of ctx, in a span of its own, and observes it, see observeQuery. run gets the query and
+// the args the hooks leave, and returns the result and the number of the rows read or affected. run is
+// retried by QueryRetry if retry is set, i.e. the query isn't in a transaction, and the hooks and the
+// observation see the last attempt only.
+func runQuery(ctx context.Context, model, query string, args []interface{}, retry bool,
+	run func(query string, args []interface{}) (sql.Result, int64, error)) (sql.Result, error) {
+	queryHooksMu.RLock()
+	hooks := queryHooks
+	queryHooksMu.RUnlock()
+	if ctxHooks := contextQueryHooks(ctx); len(ctxHooks) > 0 {
+		hooks = append(hooks[:len(hooks):len(hooks)], ctxHooks...)
+	}
+	start := time.Now()
+	e := &QueryEvent{Operation: queryOperation(query), Model: model, Query: query, Args: args}
+	ctx, span := startQuerySpan(ctx, e.Operation, start)
+	defer span.End()
+	// called is the number of the hooks whose BeforeQuery is called, only their AfterQuery is called
+	called := 0
+	for _, h := range hooks {
+		called++
+		if e.Err = h.BeforeQuery(ctx, e); e.Err != nil {
+			break
+		}
+	}
+	if e.Err == nil {
+		policy := QueryRetry
+		if !retry {
+			policy.MaxAttempts = 1
+		}
+		e.Err = policy.do(ctx, queryRetryable(e.Operation), func() (err error) {
+			e.Result, e.Rows, err = run(e.Query, e.Args)
+			return err
+		})
+	}
+	e.Duration = time.Since(start)
+	for i := called - 1; i >= 0; i-- {
+		hooks[i].AfterQuery(ctx, e)
+	}
+	observeQuery(ctx, model, e.Query, e.Args, start, e.Rows, e.Err)
+	return e.Result, e.Err
+}
@@ -0,0 +1,446 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The frequencies of AppointmentSeries.
+const (
+	FrequencyDaily   = "daily"
+	FrequencyWeekly  = "weekly"
+	FrequencyMonthly = "monthly"
+)
+
+// MaxSeriesOccurrences is the most occurrences an AppointmentSeries may have,
+// an Appointment is created for each of them.
+var MaxSeriesOccurrences = 520
+
+// rruleWeekdays are the weekdays of the ByDay of AppointmentSeries as in an iCalendar RRULE.
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Occurrences returns the start times of the occurrences of the AppointmentSeries without the
+// Exdates, in the time zone of its physician. The occurrences keep the wall clock time of
+// StartsAt there, e.g. a weekly appointment stays at 10:00 across the DST transitions.
+func (_appointmentSeries *AppointmentSeries) Occurrences() ([]time.Time, error) {
+	return _appointmentSeries.OccurrencesContext(context.Background())
+}
+
+// OccurrencesContext is same as Occurrences but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) OccurrencesContext(ctx context.Context) ([]time.Time, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Occurrences", "appointment_series")
+	defer span.End()
+	all, err := _appointmentSeries.recurrences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exdates, err := _appointmentSeries.exdates()
+	if err != nil {
+		return nil, err
+	}
+	occurrences := []time.Time{}
+	for _, t := range all {
+		if !containsTime(exdates, t) {
+			occurrences = append(occurrences, t)
+		}
+	}
+	return occurrences, nil
+}
+
+// recurrences returns the start times of the occurrences of the AppointmentSeries including the
+// Exdates, the Count of an iCalendar RRULE counts them too.
+func (_appointmentSeries *AppointmentSeries) recurrences(ctx context.Context) ([]time.Time, error) {
+	if _appointmentSeries.Count <= 0 && _appointmentSeries.Until == nil {
+		err := errors.New("An appointment series needs a Count or an Until")
+		return nil, &ValidationError{Model: "AppointmentSeries", Index: -1, Err: err}
+	}
+	physician, err := FindPhysicianContext(ctx, _appointmentSeries.PhysicianId)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := physician.Location()
+	if err != nil {
+		return nil, err
+	}
+	days, err := _appointmentSeries.byDay()
+	if err != nil {
+		return nil, err
+	}
+	interval := int(_appointmentSeries.Interval)
+	if interval <= 0 {
+		interval = 1
+	}
+	start := _appointmentSeries.StartsAt.In(loc)
+	y, m, d := start.Date()
+	hour, minute, sec := start.Clock()
+	at := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, hour, minute, sec, 0, loc)
+	}
+	recurrences := []time.Time{}
+	for i := 0; ; i++ {
+		var candidates []time.Time
+		switch _appointmentSeries.Frequency {
+		case FrequencyDaily:
+			candidates = []time.Time{at(y, m, d+i*interval)}
+		case FrequencyWeekly:
+			if len(days) == 0 {
+				candidates = []time.Time{at(y, m, d+7*i*interval)}
+				break
+			}
+			// the weeks start on Monday, i.e. WKST=MO
+			monday := d - (int(start.Weekday())+6)%7 + 7*i*interval
+			for _, wd := range days {
+				if t := at(y, m, monday+(int(wd)+6)%7); !t.Before(start) {
+					candidates = append(candidates, t)
+				}
+			}
+		case FrequencyMonthly:
+			// a month without the day of StartsAt is skipped, as in RFC 5545
+			if t := at(y, m+time.Month(i*interval), d); t.Day() == d {
+				candidates = []time.Time{t}
+			}
+		default:
+			return nil, fmt.Errorf("Invalid frequency of the appointment series: %q", _appointmentSeries.Frequency)
+		}
+		for _, t := range candidates {
+			if _appointmentSeries.Until != nil && t.After(*_appointmentSeries.Until) {
+				return recurrences, nil
+			}
+			if _appointmentSeries.Count > 0 && int64(len(recurrences)) >= _appointmentSeries.Count {
+				return recurrences, nil
+			}
+			if len(recurrences) >= MaxSeriesOccurrences {
+				return nil, fmt.Errorf("The appointment series has more than %d occurrences", MaxSeriesOccurrences)
+			}
+			recurrences = append(recurrences, t)
+		}
+	}
+}
+
+// byDay parses the ByDay of the AppointmentSeries, e.g. "MO,TH", into weekdays ordered from Monday on.
+func (_appointmentSeries *AppointmentSeries) byDay() ([]time.Weekday, error) {
+	days := []time.Weekday{}
+	for _, v := range strings.Split(_appointmentSeries.ByDay, ",") {
+		v = strings.ToUpper(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		wd, ok := rruleWeekdays[v]
+		if !ok {
+			return nil, fmt.Errorf("Invalid weekday in by_day: %q", v)
+		}
+		days = append(days, wd)
+	}
+	sort.Slice(days, func(i, j int) bool { return (days[i]+6)%7 < (days[j]+6)%7 })
+	return days, nil
+}
+
+// exdates parses the Exdates of the AppointmentSeries, the RFC 3339 times separated by commas.
+func (_appointmentSeries *AppointmentSeries) exdates() ([]time.Time, error) {
+	exdates := []time.Time{}
+	for _, v := range strings.Split(_appointmentSeries.Exdates, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid time in exdates: %v", err)
+		}
+		exdates = append(exdates, t)
+	}
+	return exdates, nil
+}
+
+// containsTime reports whether the time t is one of the times.
+func containsTime(times []time.Time, t time.Time) bool {
+	for _, v := range times {
+		if v.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Materialize creates the Appointments of the occurrences of the AppointmentSeries which are not created
+// yet, so it can be called again after the series is changed. The occurrences overlapping another
+// appointment of the physician are conflicts, and no appointment is created if there is any, see
+// ConflictError. It's done in a transaction, so the conflicts can't be booked meanwhile, see checkConflicts.
+// The created appointments are returned.
+func (_appointmentSeries *AppointmentSeries) Materialize() ([]Appointment, error) {
+	return _appointmentSeries.MaterializeContext(context.Background())
+}
+
+// MaterializeContext is same as Materialize but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) MaterializeContext(ctx context.Context) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Materialize", "appointment_series")
+	defer span.End()
+	if _appointmentSeries.Id == 0 {
+		return nil, fmt.Errorf("AppointmentSeries.Materialize error: %w", ErrInvalidID)
+	}
+	var appointments []Appointment
+	err := inTransaction(ctx, func(ctx context.Context) (err error) {
+		appointments, err = _appointmentSeries.materialize(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// materialize creates the appointments of the pending occurrences of the AppointmentSeries, see Materialize.
+// It should be run in a transaction bound to ctx, so the conflicts are checked under the locks of checkConflicts.
+func (_appointmentSeries *AppointmentSeries) materialize(ctx context.Context) ([]Appointment, error) {
+	occurrences, err := _appointmentSeries.OccurrencesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := AppointmentSeriesGetAppointmentsContext(ctx, _appointmentSeries.Id)
+	if err != nil {
+		return nil, err
+	}
+	existingIds := make([]int64, len(existing))
+	existingTimes := make([]time.Time, 0, len(existing))
+	for i, v := range existing {
+		existingIds[i] = v.Id
+		if v.AppointmentDate != nil {
+			existingTimes = append(existingTimes, *v.AppointmentDate)
+		}
+	}
+	pending := []time.Time{}
+	for _, t := range occurrences {
+		if !containsTime(existingTimes, t) {
+			pending = append(pending, t)
+		}
+	}
+	if err = checkConflicts(ctx, _appointmentSeries.PhysicianId, pending, existingIds...); err != nil {
+		return nil, err
+	}
+	appointments := make([]Appointment, len(pending))
+	for i := range pending {
+		physicianId, patientId, seriesId := _appointmentSeries.PhysicianId, _appointmentSeries.PatientId, _appointmentSeries.Id
+		appointments[i] = Appointment{AppointmentDate: &pending[i], PhysicianId: &physicianId, PatientId: &patientId, SeriesId: &seriesId}
+	}
+	if len(appointments) == 0 {
+		return appointments, nil
+	}
+	if _, err = CreateAppointmentsContext(ctx, appointments); err != nil {
+		return nil, err
+	}
+	return appointments, nil
+}
+
+// checkConflicts returns a ConflictError if any of the appointments at the times would overlap an
+// appointment of the physician other than the ones of the excludeIds. If ctx is bound to a transaction,
+// the physician and its conflicting appointments are locked, so no conflicting appointment can be booked
+// by another transaction until it ends, see lockConflictingAppointments.
+func checkConflicts(ctx context.Context, physicianId int64, times []time.Time, excludeIds ...int64) error {
+	if len(times) == 0 {
+		return nil
+	}
+	first, last := times[0], times[0]
+	for _, t := range times {
+		if t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	others, locked, err := lockConflictingAppointments(ctx, physicianId, first, last.Add(DefaultAppointmentLength), excludeIds...)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		others, err = FindConflictingAppointmentsContext(ctx, physicianId, first, last.Add(DefaultAppointmentLength), excludeIds...)
+		if err != nil {
+			return err
+		}
+	}
+	busy := make([]Slot, len(others))
+	for i, v := range others {
+		busy[i] = Slot{Start: *v.AppointmentDate, End: v.AppointmentDate.Add(DefaultAppointmentLength)}
+	}
+	conflicts := []time.Time{}
+	for _, t := range times {
+		if overlapsAny(Slot{Start: t, End: t.Add(DefaultAppointmentLength)}, busy) {
+			conflicts = append(conflicts, t)
+		}
+	}
+	if len(conflicts) > 0 {
+		return &ConflictError{Times: conflicts}
+	}
+	return nil
+}
+
+// lockConflictingAppointments is same as FindConflictingAppointments but locks the row of the physician
+// and the rows of the found appointments for update in the transaction ctx is bound to. Locking the physician
+// serializes the bookings of it, as a row lock can't stop a new appointment from being inserted into the range.
+// The appointments are found by a locking read, so they're the latest committed ones rather than the ones of
+// the snapshot of the transaction. It reports false and finds nothing if there is no transaction or its
+// driver doesn't support row locks, e.g. sqlite3.
+func lockConflictingAppointments(ctx context.Context, physicianId int64, start, end time.Time, excludeIds ...int64) ([]Appointment, bool, error) {
+	tx := ctxTx(ctx)
+	if tx == nil {
+		return nil, false, nil
+	}
+	if _, err := lockClause(tx, ForUpdate); err != nil {
+		return nil, false, nil
+	}
+	if _, err := FindPhysicianForUpdateContext(ctx, tx, physicianId); err != nil {
+		return nil, true, err
+	}
+	where, args := conflictsWhere(physicianId, start, end, excludeIds...)
+	appointments, err := FindAppointmentsWhereLockContext(ctx, tx, ForUpdate, where, args...)
+	return appointments, true, err
+}
+
+// Skip cancels the single occurrence of the AppointmentSeries at t: it's added to the Exdates
+// and its appointment is destroyed if it's created.
+func (_appointmentSeries *AppointmentSeries) Skip(t time.Time) error {
+	return _appointmentSeries.SkipContext(context.Background(), t)
+}
+
+// SkipContext is same as Skip but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) SkipContext(ctx context.Context, t time.Time) error {
+	ctx, span := startSpan(ctx, "AppointmentSeries.Skip", "appointment_series")
+	defer span.End()
+	saved := *_appointmentSeries
+	err := inTransaction(ctx, func(ctx context.Context) error {
+		// the transaction may be retried, see WithTxContext
+		*_appointmentSeries = saved
+		exdates, err := _appointmentSeries.exdates()
+		if err != nil {
+			return err
+		}
+		if !containsTime(exdates, t) {
+			_appointmentSeries.Exdates = formatExdates(append(exdates, t))
+			if err = _appointmentSeries.SaveContext(ctx); err != nil {
+				return err
+			}
+		}
+		ids, err := AppointmentIdsWhereContext(ctx, "series_id = ? AND appointment_date = ?", _appointmentSeries.Id, t.In(StorageLocation))
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		_, err = DestroyAppointmentsContext(ctx, ids...)
+		return err
+	})
+	if err != nil {
+		*_appointmentSeries = saved
+	}
+	return err
+}
+
+// formatExdates formats the times for the Exdates of AppointmentSeries.
+func formatExdates(times []time.Time) string {
+	exdates := make([]string, len(times))
+	for i, t := range times {
+		exdates[i] = t.UTC().Format(time.RFC3339)
+	}
+	return strings.Join(exdates, ",")
+}
+
+// UpdateFollowing changes the "this and following" occurrences of the AppointmentSeries, i.e. the ones
+// from the first occurrence at or after from on, by the columns of am, e.g. a new starts_at or physician_id.
+// The series is split: it's ended before that occurrence and a new series with the changes takes over the
+// rest. The appointments of the changed occurrences are created again, after the conflicts are checked.
+// It's all done in a transaction, so nothing is changed if any step fails, and the AppointmentSeries is
+// left as it's before then. The series of the changed occurrences is returned, which is the series itself if from is at or before
+// its first occurrence. The series should be loaded from the database, e.g. by FindAppointmentSeries.
+func (_appointmentSeries *AppointmentSeries) UpdateFollowing(from time.Time, am map[string]interface{}) (*AppointmentSeries, error) {
+	return _appointmentSeries.UpdateFollowingContext(context.Background(), from, am)
+}
+
+// UpdateFollowingContext is same as UpdateFollowing but with the context ctx.
+func (_appointmentSeries *AppointmentSeries) UpdateFollowingContext(ctx context.Context, from time.Time, am map[string]interface{}) (*AppointmentSeries, error) {
+	ctx, span := startSpan(ctx, "AppointmentSeries.UpdateFollowing", "appointment_series")
+	defer span.End()
+	if _appointmentSeries.Id == 0 {
+		return nil, fmt.Errorf("AppointmentSeries.UpdateFollowing error: %w", ErrInvalidID)
+	}
+	saved := *_appointmentSeries
+	var next *AppointmentSeries
+	err := inTransaction(ctx, func(ctx context.Context) error {
+		// the transaction may be retried, see WithTxContext
+		*_appointmentSeries = saved
+		all, err := _appointmentSeries.recurrences(ctx)
+		if err != nil {
+			return err
+		}
+		i := sort.Search(len(all), func(i int) bool { return !all[i].Before(from) })
+		if i == len(all) {
+			return fmt.Errorf("No occurrence of the appointment series at or after %s", from.Format(time.RFC3339))
+		}
+		following, err := AppointmentIdsWhereContext(ctx, "series_id = ? AND appointment_date >= ?", _appointmentSeries.Id, all[i].In(StorageLocation))
+		if err != nil {
+			return err
+		}
+		next = _appointmentSeries
+		if i > 0 {
+			next = &AppointmentSeries{
+				PhysicianId: _appointmentSeries.PhysicianId,
+				PatientId:   _appointmentSeries.PatientId,
+				StartsAt:    all[i],
+				Frequency:   _appointmentSeries.Frequency,
+				Interval:    _appointmentSeries.Interval,
+				Until:       _appointmentSeries.Until,
+				ByDay:       _appointmentSeries.ByDay,
+				Exdates:     _appointmentSeries.Exdates,
+			}
+			if _appointmentSeries.Count > 0 {
+				next.Count = _appointmentSeries.Count - int64(i)
+			}
+		}
+		if err = assignColumns(next, am); err != nil {
+			return err
+		}
+		occurrences, err := next.OccurrencesContext(ctx)
+		if err != nil {
+			return err
+		}
+		// the following appointments are replaced, so they are no conflicts
+		if err = checkConflicts(ctx, next.PhysicianId, occurrences, following...); err != nil {
+			return err
+		}
+		if len(following) > 0 {
+			if _, err = DestroyAppointmentsContext(ctx, following...); err != nil {
+				return err
+			}
+		}
+		if i > 0 {
+			until := all[i].Add(-time.Second)
+			_appointmentSeries.Until = &until
+			if _appointmentSeries.Count > 0 {
+				_appointmentSeries.Count = int64(i)
+			}
+			if err = _appointmentSeries.SaveContext(ctx); err != nil {
+				return err
+			}
+			if _, err = next.CreateContext(ctx); err != nil {
+				return err
+			}
+		} else if err = next.SaveContext(ctx); err != nil {
+			return err
+		}
+		_, err = next.materialize(ctx)
+		return err
+	})
+	if err != nil {
+		*_appointmentSeries = saved
+		return nil, err
+	}
+	return next, nil
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Reminder is the reminder of an upcoming appointment to be delivered by a Notifier.
+type Reminder struct {
+	// Appointment is the appointment with its Physician and Patient loaded.
+	Appointment Appointment
+	// At is the time of the appointment in the time zone of the physician.
+	At time.Time
+	// Lead is how long before the appointment the reminder is due.
+	Lead time.Duration
+}
+
+// ReminderScheduler sends the reminders of the upcoming appointments through the Notifier,
+// e.g. 24 hours and 2 hours before each one. The sent reminders are recorded as
+// AppointmentReminder records, so each one is sent once even with several schedulers.
+type ReminderScheduler struct {
+	// Leads are how long before the appointments the reminders are due, e.g. 24 * time.Hour.
+	Leads []time.Duration
+	// Notifier delivers the reminders.
+	Notifier Notifier
+	// Interval is how often Run scans the appointments, a minute by default.
+	Interval time.Duration
+	// Now returns the current time, time.Now by default.
+	Now func() time.Time
+}
+
+// Run scans the appointments every Interval and sends the due reminders until ctx is done.
+// The error of each scan is passed to onError if it's not nil.
+func (_s *ReminderScheduler) Run(ctx context.Context, onError func(error)) error {
+	interval := _s.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := _s.RunOnceContext(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce sends the reminders due now and returns how many are sent. The scheduled or confirmed
+// appointments within a lead from now get the reminder of that lead, but only of the shortest lead
+// they are within, e.g. an appointment booked an hour ahead only gets the 2 hours reminder and not
+// the 24 hours one too. A reminder failed to be sent is tried again by the next run.
+func (_s *ReminderScheduler) RunOnce() (int, error) {
+	return _s.RunOnceContext(context.Background())
+}
+
+// RunOnceContext is same as RunOnce but with the context ctx.
+func (_s *ReminderScheduler) RunOnceContext(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "ReminderScheduler.RunOnce", "appointments")
+	defer span.End()
+	if _s.Notifier == nil {
+		return 0, fmt.Errorf("ReminderScheduler.RunOnce error: %w", ErrNoNotifier)
+	}
+	now := time.Now()
+	if _s.Now != nil {
+		now = _s.Now()
+	}
+	leads := append([]time.Duration{}, _s.Leads...)
+	sort.Slice(leads, func(i, j int) bool { return leads[i] < leads[j] })
+	sent := 0
+	errs := []error{}
+	var shorter time.Duration
+	for _, lead := range leads {
+		appointments, err := FindAppointmentsWithStatusWhereContext(ctx, []string{AppointmentScheduled, AppointmentConfirmed},
+			"appointment_date > ? AND appointment_date <= ? AND id NOT IN (SELECT appointment_id FROM appointment_reminders WHERE lead_minutes = ?)",
+			now.Add(shorter).In(StorageLocation), now.Add(lead).In(StorageLocation), int64(lead/time.Minute))
+		if err != nil {
+			return sent, err
+		}
+		if err = includeAppointmentsAssocs(ctx, appointments, []string{"physician", "patient"}, false); err != nil {
+			return sent, err
+		}
+		for _, v := range appointments {
+			ok, err := sendReminder(ctx, _s.Notifier, v, lead, now)
+			if err != nil {
+				errs = append(errs, err)
+			} else if ok {
+				sent++
+			}
+		}
+		shorter = lead
+	}
+	return sent, errors.Join(errs...)
+}
+
+// sendReminder claims the reminder of the appointment with the lead by recording it and then sends it.
+// The record is removed if it's failed to be sent. It reports false if the reminder is claimed already.
+func sendReminder(ctx context.Context, notifier Notifier, appointment Appointment, lead time.Duration, now time.Time) (bool, error) {
+	id, err := CreateAppointmentReminderContext(ctx, map[string]interface{}{
+		"appointment_id": appointment.Id,
+		"lead_minutes":   int64(lead / time.Minute),
+		"sent_at":        now,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			// a duplicate entry: it's sent by another scheduler
+			return false, nil
+		}
+		return false, err
+	}
+	loc, err := appointment.Physician.Location()
+	if err != nil {
+		loc = ClinicLocation
+	}
+	r := Reminder{Appointment: appointment, At: appointment.AppointmentDate.In(loc), Lead: lead}
+	if err = notifier.Notify(r); err != nil {
+		if dErr := DestroyAppointmentReminderContext(ctx, id); dErr != nil {
+			return false, errors.Join(err, dErr)
+		}
+		return false, fmt.Errorf("Send reminder of appointment %d error: %w", appointment.Id, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+)
+
+// fakeNotifier records the reminders it's notified of, and fails them with err if it's set.
+type fakeNotifier struct {
+	reminders []Reminder
+	err       error
+}
+
+func (_n *fakeNotifier) Notify(r Reminder) error {
+	if _n.err != nil {
+		return _n.err
+	}
+	_n.reminders = append(_n.reminders, r)
+	return nil
+}
+
+var reminderNow = time.Date(2026, 3, 2, 8, 0, 0, 0, time.UTC)
+
+// expectDueAppointment expects the scan of the appointments due within the 2 hours lead, which
+// returns one appointment an hour from reminderNow, and the loading of its physician and patient.
+func expectDueAppointment(mock sqlmock.Sqlmock) {
+	mock.ExpectPrepare("SELECT .* FROM .*appointments.* lead_minutes = ").ExpectQuery().
+		WithArgs(AppointmentScheduled, AppointmentConfirmed, reminderNow, reminderNow.Add(2*time.Hour), int64(120)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "appointment_date", "physician_id", "patient_id", "status"}).
+			AddRow(1, reminderNow.Add(time.Hour), 2, 3, AppointmentScheduled))
+	mock.ExpectQuery("SELECT .* FROM physicians").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "time_zone"}).AddRow(2, "Dr. Smith", "Asia/Tokyo"))
+	mock.ExpectQuery("SELECT .* FROM .*patients").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "John"))
+}
+
+func newReminderScheduler(notifier Notifier) *ReminderScheduler {
+	return &ReminderScheduler{
+		Leads:    []time.Duration{2 * time.Hour},
+		Notifier: notifier,
+		Now:      func() time.Time { return reminderNow },
+	}
+}
+
+func TestReminderSchedulerRunOnce(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	expectDueAppointment(mock)
+	mock.ExpectExec("INSERT INTO appointment_reminders").WillReturnResult(sqlmock.NewResult(7, 1))
+	notifier := &fakeNotifier{}
+	sent, err := newReminderScheduler(notifier).RunOnce()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 || len(notifier.reminders) != 1 {
+		t.Fatalf("RunOnce sent %d reminders, notified %d, want 1", sent, len(notifier.reminders))
+	}
+	r := notifier.reminders[0]
+	if r.Lead != 2*time.Hour || r.Appointment.Id != 1 || r.Appointment.Patient.Name != "John" {
+		t.Errorf("RunOnce reminder = %+v", r)
+	}
+	if name, _ := r.At.Zone(); name != "JST" {
+		t.Errorf("RunOnce reminder is at %v, want in the time zone of the physician", r.At)
+	}
+}
+
+func TestReminderSchedulerRunOnceSentAlready(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	expectDueAppointment(mock)
+	mock.ExpectExec("INSERT INTO appointment_reminders").WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	notifier := &fakeNotifier{}
+	sent, err := newReminderScheduler(notifier).RunOnce()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 0 || len(notifier.reminders) != 0 {
+		t.Errorf("RunOnce sent %d reminders, notified %d, want 0", sent, len(notifier.reminders))
+	}
+}
+
+func TestReminderSchedulerRunOnceNotifyError(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	expectDueAppointment(mock)
+	mock.ExpectExec("INSERT INTO appointment_reminders").WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectPrepare("DELETE FROM appointment_reminders WHERE id = ").
+		ExpectExec().WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+	failed := errors.New("failed")
+	sent, err := newReminderScheduler(&fakeNotifier{err: failed}).RunOnce()
+	if !errors.Is(err, failed) {
+		t.Errorf("RunOnce error = %v, want %v", err, failed)
+	}
+	if sent != 0 {
+		t.Errorf("RunOnce sent %d reminders, want 0", sent)
+	}
+}
+
+func TestReminderSchedulerRunOnceNoNotifier(t *testing.T) {
+	newMockDB(t, "mysql")
+	if _, err := newReminderScheduler(nil).RunOnce(); !errors.Is(err, ErrNoNotifier) {
+		t.Errorf("RunOnce error = %v, want ErrNoNotifier", err)
+	}
+}
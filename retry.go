@@ -0,0 +1,86 @@
+package models
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// RetryPolicy is how the queries and the transactions failed with a transient error are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the max number of times a query or a transaction is tried, 1 or less for no retry.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, it's doubled for each next retry up to MaxDelay.
+	// The delay is jittered, i.e. a random one between 0 and it is waited actually.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// QueryRetry is the RetryPolicy of the package.
+//
+// A query on DB is retried if it's failed with a deadlock (MySQL error 1213) or a lock wait timeout
+// (1205), as the statement is rolled back by them. A SELECT is retried on a connection error too, but
+// the other statements are not, as they might be applied already, e.g. an INSERT would be doubled.
+// A query in a transaction is never retried by itself, the whole transaction of WithTx is retried instead.
+var QueryRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: 20 * time.Millisecond, MaxDelay: time.Second}
+
+// isLockError reports whether err is a deadlock or a lock wait timeout.
+func isLockError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && (mysqlErr.Number == 1213 || mysqlErr.Number == 1205)
+}
+
+// isConnError reports whether err is an error of the connection to the database.
+func isConnError(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.As(err, &netErr)
+}
+
+// queryRetryable returns whether an error of a query of the operation, e.g. "SELECT", is retryable.
+func queryRetryable(operation string) func(error) bool {
+	return func(err error) bool {
+		return isLockError(err) || (operation == "SELECT" && isConnError(err))
+	}
+}
+
+// delay returns the jittered delay before the retry after the attempt.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// do runs fn until it succeeds, its error isn't retryable or it's tried MaxAttempts times,
+// and returns its last error. It stops waiting for the next retry if ctx is done.
+func (p RetryPolicy) do(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !retryable(err) {
+			return err
+		}
+		d := p.delay(attempt)
+		logger().Warn("Retry after a transient error", "attempt", attempt, "delay", d, "error", err)
+		timer := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
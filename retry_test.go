@@ -0,0 +1,139 @@
+package models
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+var errDeadlock = &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+// retryWithoutDelay sets QueryRetry to 3 attempts without a delay for the test.
+func retryWithoutDelay(t *testing.T) {
+	saved := QueryRetry
+	QueryRetry = RetryPolicy{MaxAttempts: 3}
+	t.Cleanup(func() { QueryRetry = saved })
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 25 * time.Millisecond}
+	for attempt, max := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 5: 25 * time.Millisecond} {
+		if d := p.delay(attempt); d < 0 || d > max {
+			t.Errorf("delay(%d) = %v, want between 0 and %v", attempt, d, max)
+		}
+	}
+}
+
+func TestRetryQueryOnDeadlock(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	retryWithoutDelay(t)
+	mock.ExpectQuery("SELECT .* FROM .*patients").WillReturnError(errDeadlock)
+	mock.ExpectQuery("SELECT .* FROM .*patients").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+	_patient, err := FindPatient(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _patient.Name != "John" {
+		t.Errorf("FindPatient = %+v", _patient)
+	}
+}
+
+func TestRetryQueryGivesUp(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	retryWithoutDelay(t)
+	for i := 0; i < 3; i++ {
+		mock.ExpectQuery("SELECT .* FROM .*patients").WillReturnError(errDeadlock)
+	}
+	if _, err := FindPatient(1); !isLockError(err) {
+		t.Errorf("FindPatient error = %v, want the deadlock", err)
+	}
+}
+
+func TestRetrySelectOnConnError(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	retryWithoutDelay(t)
+	mock.ExpectQuery("SELECT .* FROM .*patients").WillReturnError(io.ErrUnexpectedEOF)
+	mock.ExpectQuery("SELECT .* FROM .*patients").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+	if _, err := FindPatient(1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryNoInsertOnConnError(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	retryWithoutDelay(t)
+	mock.ExpectExec("INSERT INTO patients").WillReturnError(io.ErrUnexpectedEOF)
+	_patient := &Patient{Name: "John"}
+	if _, err := _patient.Create(); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Create error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestRetryTransactionOnDeadlock(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	retryWithoutDelay(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE patients").WillReturnError(errDeadlock)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE patients").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	calls := 0
+	err := WithTx(func(tx *sqlx.Tx) error {
+		calls++
+		_, err := tx.Exec("UPDATE patients SET name = ? WHERE id = ?", "John", 1)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("WithTx called fn %d times, want 2", calls)
+	}
+}
+
+func TestRetryTransactionNotOnOtherError(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	retryWithoutDelay(t)
+	failed := errors.New("failed")
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	calls := 0
+	err := WithTx(func(tx *sqlx.Tx) error {
+		calls++
+		return failed
+	})
+	if !errors.Is(err, failed) || calls != 1 {
+		t.Errorf("WithTx error = %v after %d calls, want %v after 1", err, calls, failed)
+	}
+}
+
+func TestRetryBulkInsertIds(t *testing.T) {
+	mock := newMockDB(t, "sqlite3")
+	retryWithoutDelay(t)
+	saved := BulkInsertChunkSize
+	BulkInsertChunkSize = 1
+	t.Cleanup(func() { BulkInsertChunkSize = saved })
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO patients .* RETURNING id").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("INSERT INTO patients .* RETURNING id").WillReturnError(errDeadlock)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO patients .* RETURNING id").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery("INSERT INTO patients .* RETURNING id").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+	mock.ExpectCommit()
+	ids, err := CreatePatients([]Patient{{Name: "John"}, {Name: "Jane"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 5 || ids[1] != 6 {
+		t.Errorf("CreatePatients ids = %v, want [5 6] of the retried transaction", ids)
+	}
+}
@@ -0,0 +1,234 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The kinds of PhysicianAvailability.
+const (
+	AvailabilityWeekly = "weekly"
+	AvailabilityExtra  = "extra"
+	AvailabilityClosed = "closed"
+)
+
+// DefaultAppointmentLength is the length of an appointment. The appointments have a start
+// time only, it's used to tell whether an appointment overlaps a slot or another one.
+var DefaultAppointmentLength = 30 * time.Minute
+
+// Slot is the time range [Start, End) of an appointment.
+type Slot struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// overlaps reports whether the Slot and the Slot o have any time in common.
+func (s Slot) overlaps(o Slot) bool {
+	return s.Start.Before(o.End) && o.Start.Before(s.End)
+}
+
+// FindConflictingAppointments finds the appointments of the physician of physicianId which overlap the
+// time range [start, end), ordered by appointment_date, see DefaultAppointmentLength. The cancelled
+// and no-show appointments don't take up time, and the appointments of the excludeIds are skipped,
+// e.g. the one being moved.
+func FindConflictingAppointments(physicianId int64, start, end time.Time, excludeIds ...int64) ([]Appointment, error) {
+	return FindConflictingAppointmentsContext(context.Background(), physicianId, start, end, excludeIds...)
+}
+
+// FindConflictingAppointmentsContext is same as FindConflictingAppointments but with the context ctx.
+func FindConflictingAppointmentsContext(ctx context.Context, physicianId int64, start, end time.Time, excludeIds ...int64) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "FindConflictingAppointments", "appointments")
+	defer span.End()
+	where, args := conflictsWhere(physicianId, start, end, excludeIds...)
+	return FindAppointmentsWhereContext(ctx, where+" ORDER BY appointment_date ASC", args...)
+}
+
+// conflictsWhere returns the where clause and its args of FindConflictingAppointments.
+func conflictsWhere(physicianId int64, start, end time.Time, excludeIds ...int64) (string, []interface{}) {
+	where := "physician_id = ? AND appointment_date > ? AND appointment_date < ? AND status NOT IN (?, ?)"
+	args := []interface{}{physicianId, start.Add(-DefaultAppointmentLength).In(StorageLocation), end.In(StorageLocation), AppointmentCancelled, AppointmentNoShow}
+	if len(excludeIds) > 0 {
+		where += fmt.Sprintf(" AND id NOT IN (?%s)", strings.Repeat(",?", len(excludeIds)-1))
+		for _, id := range excludeIds {
+			args = append(args, id)
+		}
+	}
+	return where, args
+}
+
+// FindOpenSlots finds the free slots of slotLength in the time range [from, to) for the physician of
+// physicianId. The slots are cut from the working hours of the physician on each calendar day in the
+// time zone of the physician, see PhysicianAvailability, and the ones overlapping an appointment are
+// left out. The slots are ordered and in the time zone of the physician.
+func FindOpenSlots(physicianId int64, from, to time.Time, slotLength time.Duration) ([]Slot, error) {
+	return FindOpenSlotsContext(context.Background(), physicianId, from, to, slotLength)
+}
+
+// FindOpenSlotsContext is same as FindOpenSlots but with the context ctx.
+func FindOpenSlotsContext(ctx context.Context, physicianId int64, from, to time.Time, slotLength time.Duration) ([]Slot, error) {
+	ctx, span := startSpan(ctx, "FindOpenSlots", "appointments")
+	defer span.End()
+	if slotLength <= 0 {
+		return nil, fmt.Errorf("FindOpenSlots error: %w", ErrInvalidSlotLength)
+	}
+	physician, err := FindPhysicianContext(ctx, physicianId)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := physician.Location()
+	if err != nil {
+		return nil, err
+	}
+	availabilities, err := FindPhysicianAvailabilitiesByContext(ctx, "physician_id", physicianId)
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := FindConflictingAppointmentsContext(ctx, physicianId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	busy := make([]Slot, 0, len(appointments))
+	for _, v := range appointments {
+		busy = append(busy, Slot{Start: *v.AppointmentDate, End: v.AppointmentDate.Add(DefaultAppointmentLength)})
+	}
+	slots := []Slot{}
+	for day, _ := DayRange(from, loc); day.Before(to); _, day = DayRange(day, loc) {
+		hours, err := workingHours(availabilities, day, loc)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hours {
+			for t := h.Start; !t.Add(slotLength).After(h.End); t = t.Add(slotLength) {
+				slot := Slot{Start: t, End: t.Add(slotLength)}
+				if slot.Start.Before(from) || slot.End.After(to) || overlapsAny(slot, busy) {
+					continue
+				}
+				slots = append(slots, slot)
+			}
+		}
+	}
+	return slots, nil
+}
+
+// overlapsAny reports whether the slot overlaps any of the slots.
+func overlapsAny(slot Slot, slots []Slot) bool {
+	for _, v := range slots {
+		if slot.overlaps(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// workingHours returns the ordered working hours in loc on the calendar day that day falls on in loc,
+// i.e. the weekly and extra hours of the availabilities without the closed ones.
+func workingHours(availabilities []PhysicianAvailability, day time.Time, loc *time.Location) ([]Slot, error) {
+	local := day.In(loc)
+	open, closed := []Slot{}, []Slot{}
+	for _, v := range availabilities {
+		var onDay bool
+		switch v.Kind {
+		case AvailabilityWeekly:
+			onDay = v.Weekday == int64(local.Weekday())
+		case AvailabilityExtra, AvailabilityClosed:
+			onDay = v.Date != nil && sameDate(*v.Date, local)
+		}
+		if !onDay {
+			continue
+		}
+		if v.Kind == AvailabilityClosed && v.StartTime == "" && v.EndTime == "" {
+			start, end := DayRange(local, loc)
+			closed = append(closed, Slot{Start: start.In(loc), End: end.In(loc)})
+			continue
+		}
+		hours, err := v.hoursOn(local, loc)
+		if err != nil {
+			return nil, err
+		}
+		if v.Kind == AvailabilityClosed {
+			closed = append(closed, hours)
+		} else {
+			open = append(open, hours)
+		}
+	}
+	return subtractSlots(mergeSlots(open), closed), nil
+}
+
+// hoursOn returns the StartTime to EndTime range of the PhysicianAvailability on the calendar day of
+// day in loc. The wall clock times are resolved by time.Date, so they're right across DST transitions.
+func (_physicianAvailability *PhysicianAvailability) hoursOn(day time.Time, loc *time.Location) (Slot, error) {
+	start, err := parseClock(_physicianAvailability.StartTime)
+	if err != nil {
+		return Slot{}, err
+	}
+	end, err := parseClock(_physicianAvailability.EndTime)
+	if err != nil {
+		return Slot{}, err
+	}
+	y, m, d := day.Date()
+	hours := Slot{
+		Start: time.Date(y, m, d, start.Hour(), start.Minute(), start.Second(), 0, loc),
+		End:   time.Date(y, m, d, end.Hour(), end.Minute(), end.Second(), 0, loc),
+	}
+	if !hours.Start.Before(hours.End) {
+		return Slot{}, fmt.Errorf("Invalid working hours of physician availability %d: %s-%s", _physicianAvailability.Id, _physicianAvailability.StartTime, _physicianAvailability.EndTime)
+	}
+	return hours, nil
+}
+
+// parseClock parses a time of day like "09:00" or "09:00:00", the latter is what MySQL returns for a TIME column.
+func parseClock(s string) (time.Time, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid time of day: %q", s)
+}
+
+// sameDate reports whether the calendar date of date, e.g. a DATE column, is the date of day.
+func sameDate(date, day time.Time) bool {
+	y1, m1, d1 := date.Date()
+	y2, m2, d2 := day.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+// mergeSlots returns the ordered union of the slots.
+func mergeSlots(slots []Slot) []Slot {
+	sort.Slice(slots, func(i, j int) bool { return slots[i].Start.Before(slots[j].Start) })
+	merged := []Slot{}
+	for _, v := range slots {
+		if n := len(merged); n > 0 && !v.Start.After(merged[n-1].End) {
+			if v.End.After(merged[n-1].End) {
+				merged[n-1].End = v.End
+			}
+			continue
+		}
+		merged = append(merged, v)
+	}
+	return merged
+}
+
+// subtractSlots returns the parts of the ordered slots which are not covered by any of the removed ones.
+func subtractSlots(slots, removed []Slot) []Slot {
+	for _, r := range removed {
+		rest := []Slot{}
+		for _, v := range slots {
+			if !v.overlaps(r) {
+				rest = append(rest, v)
+				continue
+			}
+			if v.Start.Before(r.Start) {
+				rest = append(rest, Slot{Start: v.Start, End: r.Start})
+			}
+			if r.End.Before(v.End) {
+				rest = append(rest, Slot{Start: r.End, End: v.End})
+			}
+		}
+		slots = rest
+	}
+	return slots
+}
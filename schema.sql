@@ -0,0 +1,96 @@
+-- The tables and columns the models need beyond the ones of the Rails app they're generated from,
+-- i.e. physicians, patients, appointments and pictures. Apply it once to the database as a migration.
+-- It's in the MySQL syntax, the default driver, see Open.
+
+-- soft delete, see Patient.Destroy
+ALTER TABLE patients
+  ADD COLUMN deleted_at DATETIME NULL,
+  ADD INDEX index_patients_on_deleted_at (deleted_at);
+
+-- the IANA time zone of the working hours, see Physician.Location
+ALTER TABLE physicians
+  ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE TABLE appointment_series (
+  id BIGINT NOT NULL AUTO_INCREMENT,
+  physician_id BIGINT NOT NULL,
+  patient_id BIGINT NOT NULL,
+  starts_at DATETIME NOT NULL,
+  frequency VARCHAR(16) NOT NULL,
+  interval_count BIGINT NOT NULL DEFAULT 1,
+  count BIGINT NOT NULL DEFAULT 0,
+  repeat_until DATETIME NULL,
+  by_day VARCHAR(32) NOT NULL DEFAULT '',
+  exdates TEXT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  PRIMARY KEY (id),
+  KEY index_appointment_series_on_physician_id (physician_id),
+  KEY index_appointment_series_on_patient_id (patient_id)
+);
+
+-- the nullable columns are the pointer fields of Appointment, the status workflow
+-- is in status.go, ical_uid is the UID of an imported event, see ImportICalendar
+ALTER TABLE appointments
+  MODIFY COLUMN appointment_date DATETIME NULL,
+  MODIFY COLUMN physician_id BIGINT NULL,
+  MODIFY COLUMN patient_id BIGINT NULL,
+  ADD COLUMN series_id BIGINT NULL,
+  ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'scheduled',
+  ADD COLUMN cancellation_reason VARCHAR(255) NULL,
+  ADD COLUMN confirmed_at DATETIME NULL,
+  ADD COLUMN checked_in_at DATETIME NULL,
+  ADD COLUMN completed_at DATETIME NULL,
+  ADD COLUMN cancelled_at DATETIME NULL,
+  ADD COLUMN no_show_at DATETIME NULL,
+  ADD COLUMN ical_uid VARCHAR(255) NULL,
+  ADD COLUMN deleted_at DATETIME NULL,
+  ADD COLUMN lock_version BIGINT NOT NULL DEFAULT 0,
+  ADD INDEX index_appointments_on_physician_id_and_appointment_date (physician_id, appointment_date),
+  ADD INDEX index_appointments_on_series_id (series_id),
+  ADD INDEX index_appointments_on_deleted_at (deleted_at),
+  ADD UNIQUE KEY index_appointments_on_physician_id_and_ical_uid (physician_id, ical_uid);
+
+CREATE TABLE physician_availabilities (
+  id BIGINT NOT NULL AUTO_INCREMENT,
+  physician_id BIGINT NOT NULL,
+  kind VARCHAR(16) NOT NULL,
+  weekday BIGINT NOT NULL DEFAULT 0,
+  date DATE NULL,
+  start_time VARCHAR(8) NOT NULL DEFAULT '',
+  end_time VARCHAR(8) NOT NULL DEFAULT '',
+  note VARCHAR(255) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  PRIMARY KEY (id),
+  KEY index_physician_availabilities_on_physician_id (physician_id)
+);
+
+-- the unique key makes a reminder sent only once, see ReminderScheduler
+CREATE TABLE appointment_reminders (
+  id BIGINT NOT NULL AUTO_INCREMENT,
+  appointment_id BIGINT NOT NULL,
+  lead_minutes BIGINT NOT NULL,
+  sent_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  PRIMARY KEY (id),
+  UNIQUE KEY index_appointment_reminders_on_appointment_id_and_lead_minutes (appointment_id, lead_minutes)
+);
+
+CREATE TABLE waitlists (
+  id BIGINT NOT NULL AUTO_INCREMENT,
+  patient_id BIGINT NOT NULL,
+  physician_id BIGINT NOT NULL,
+  preferred_from DATETIME NULL,
+  preferred_until DATETIME NULL,
+  status VARCHAR(16) NOT NULL DEFAULT 'waiting',
+  offered_start DATETIME NULL,
+  offered_at DATETIME NULL,
+  appointment_id BIGINT NULL,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  PRIMARY KEY (id),
+  KEY index_waitlists_on_physician_id_and_status (physician_id, status),
+  KEY index_waitlists_on_patient_id (patient_id)
+);
@@ -0,0 +1,213 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The statuses of Appointment.
+const (
+	AppointmentScheduled = "scheduled"
+	AppointmentConfirmed = "confirmed"
+	AppointmentCheckedIn = "checked_in"
+	AppointmentCompleted = "completed"
+	AppointmentCancelled = "cancelled"
+	AppointmentNoShow    = "no_show"
+)
+
+// appointmentTransitions are the statuses an Appointment may change to from each status,
+// the completed, cancelled and no-show ones are final.
+var appointmentTransitions = map[string][]string{
+	AppointmentScheduled: {AppointmentConfirmed, AppointmentCheckedIn, AppointmentCancelled, AppointmentNoShow},
+	AppointmentConfirmed: {AppointmentCheckedIn, AppointmentCancelled, AppointmentNoShow},
+	AppointmentCheckedIn: {AppointmentCompleted},
+}
+
+// CanTransition reports whether the status of an Appointment may change from the status from to the status to.
+func CanTransition(from, to string) bool {
+	for _, v := range appointmentTransitions[from] {
+		if v == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStatusChange returns ErrInvalidTransition if the Status of the Appointment object is changed from
+// the stored one in a way CanTransition doesn't allow, and stamps the time of an allowed change on the field
+// of the new status if it's not stamped yet, see statusStamp. The stored status is the loaded one, or it's
+// queried if the object isn't loaded. A record not stored yet has no status to check.
+func (_appointment *Appointment) checkStatusChange(ctx context.Context) error {
+	from, ok := _appointment.original["status"].(string)
+	if !ok {
+		statuses, err := AppointmentStrColContext(ctx, "status", "id = ?", _appointment.Id)
+		if err != nil || len(statuses) == 0 {
+			return err
+		}
+		from = statuses[0]
+	}
+	if from == _appointment.Status {
+		return nil
+	}
+	if !CanTransition(from, _appointment.Status) {
+		return fmt.Errorf("%w of appointment %d: %s to %s", ErrInvalidTransition, _appointment.Id, from, _appointment.Status)
+	}
+	if _, stamp := _appointment.statusStamp(_appointment.Status); stamp != nil && *stamp == nil {
+		t := time.Now()
+		*stamp = &t
+	}
+	return nil
+}
+
+// statusStamp returns the column and the field of the Appointment stamped with the time it changes to
+// the status, e.g. confirmed_at for confirmed, or nil for the status without one.
+func (_appointment *Appointment) statusStamp(status string) (string, **time.Time) {
+	switch status {
+	case AppointmentConfirmed:
+		return "confirmed_at", &_appointment.ConfirmedAt
+	case AppointmentCheckedIn:
+		return "checked_in_at", &_appointment.CheckedInAt
+	case AppointmentCompleted:
+		return "completed_at", &_appointment.CompletedAt
+	case AppointmentCancelled:
+		return "cancelled_at", &_appointment.CancelledAt
+	case AppointmentNoShow:
+		return "no_show_at", &_appointment.NoShowAt
+	}
+	return "", nil
+}
+
+// transition changes the Status of the Appointment to the status to, stamps the time of the change
+// on the stamp field and saves the object. The object is left unchanged if it's not saved.
+func (_appointment *Appointment) transition(ctx context.Context, to string, stamp **time.Time) error {
+	if !CanTransition(_appointment.Status, to) {
+		return fmt.Errorf("%w of appointment %d: %s to %s", ErrInvalidTransition, _appointment.Id, _appointment.Status, to)
+	}
+	saved := *_appointment
+	t := time.Now()
+	_appointment.Status = to
+	*stamp = &t
+	if err := _appointment.SaveContext(ctx); err != nil {
+		*_appointment = saved
+		return err
+	}
+	return nil
+}
+
+// Confirm changes the status of the Appointment to confirmed.
+func (_appointment *Appointment) Confirm() error {
+	return _appointment.ConfirmContext(context.Background())
+}
+
+// ConfirmContext is same as Confirm but with the context ctx.
+func (_appointment *Appointment) ConfirmContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.Confirm", "appointments")
+	defer span.End()
+	return _appointment.transition(ctx, AppointmentConfirmed, &_appointment.ConfirmedAt)
+}
+
+// CheckIn changes the status of the Appointment to checked_in when the patient arrives.
+func (_appointment *Appointment) CheckIn() error {
+	return _appointment.CheckInContext(context.Background())
+}
+
+// CheckInContext is same as CheckIn but with the context ctx.
+func (_appointment *Appointment) CheckInContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.CheckIn", "appointments")
+	defer span.End()
+	return _appointment.transition(ctx, AppointmentCheckedIn, &_appointment.CheckedInAt)
+}
+
+// Complete changes the status of a checked in Appointment to completed.
+func (_appointment *Appointment) Complete() error {
+	return _appointment.CompleteContext(context.Background())
+}
+
+// CompleteContext is same as Complete but with the context ctx.
+func (_appointment *Appointment) CompleteContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.Complete", "appointments")
+	defer span.End()
+	return _appointment.transition(ctx, AppointmentCompleted, &_appointment.CompletedAt)
+}
+
+// Cancel changes the status of the Appointment to cancelled for the reason.
+// The freed slot is offered to the waitlisted patients, see OfferSlot.
+func (_appointment *Appointment) Cancel(reason string) error {
+	return _appointment.CancelContext(context.Background(), reason)
+}
+
+// CancelContext is same as Cancel but with the context ctx.
+func (_appointment *Appointment) CancelContext(ctx context.Context, reason string) error {
+	ctx, span := startSpan(ctx, "Appointment.Cancel", "appointments")
+	defer span.End()
+	old := _appointment.CancellationReason
+	_appointment.CancellationReason = &reason
+	if err := _appointment.transition(ctx, AppointmentCancelled, &_appointment.CancelledAt); err != nil {
+		_appointment.CancellationReason = old
+		return err
+	}
+	return nil
+}
+
+// MarkNoShow changes the status of the Appointment to no_show when the patient didn't come.
+func (_appointment *Appointment) MarkNoShow() error {
+	return _appointment.MarkNoShowContext(context.Background())
+}
+
+// MarkNoShowContext is same as MarkNoShow but with the context ctx.
+func (_appointment *Appointment) MarkNoShowContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Appointment.MarkNoShow", "appointments")
+	defer span.End()
+	return _appointment.transition(ctx, AppointmentNoShow, &_appointment.NoShowAt)
+}
+
+// FindAppointmentsWithStatus finds the appointments in any of the statuses.
+func FindAppointmentsWithStatus(statuses ...string) ([]Appointment, error) {
+	return FindAppointmentsWithStatusContext(context.Background(), statuses...)
+}
+
+// FindAppointmentsWithStatusContext is same as FindAppointmentsWithStatus but with the context ctx.
+func FindAppointmentsWithStatusContext(ctx context.Context, statuses ...string) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsWithStatus", "appointments")
+	defer span.End()
+	return FindAppointmentsWithStatusWhereContext(ctx, statuses, "")
+}
+
+// FindAppointmentsWithStatusWhere is same as FindAppointmentsWhere but only the appointments
+// in any of the statuses are queried. The where clause should be a condition only.
+func FindAppointmentsWithStatusWhere(statuses []string, where string, args ...interface{}) ([]Appointment, error) {
+	return FindAppointmentsWithStatusWhereContext(context.Background(), statuses, where, args...)
+}
+
+// FindAppointmentsWithStatusWhereContext is same as FindAppointmentsWithStatusWhere but with the context ctx.
+func FindAppointmentsWithStatusWhereContext(ctx context.Context, statuses []string, where string, args ...interface{}) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsWithStatusWhere", "appointments")
+	defer span.End()
+	if len(statuses) == 0 {
+		return []Appointment{}, nil
+	}
+	sql := fmt.Sprintf("status IN (?%s)", strings.Repeat(",?", len(statuses)-1))
+	params := make([]interface{}, 0, len(statuses)+len(args))
+	for _, v := range statuses {
+		params = append(params, v)
+	}
+	if len(where) > 0 {
+		sql += " AND (" + where + ")"
+		params = append(params, args...)
+	}
+	return FindAppointmentsWhereContext(ctx, sql, params...)
+}
+
+// AppointmentCountWithStatus gets the count of the appointments in the status.
+func AppointmentCountWithStatus(status string) (int64, error) {
+	return AppointmentCountWithStatusContext(context.Background(), status)
+}
+
+// AppointmentCountWithStatusContext is same as AppointmentCountWithStatus but with the context ctx.
+func AppointmentCountWithStatusContext(ctx context.Context, status string) (int64, error) {
+	ctx, span := startSpan(ctx, "AppointmentCountWithStatus", "appointments")
+	defer span.End()
+	return AppointmentCountWhereContext(ctx, "status = ?", status)
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"container/list"
+	"context"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// StmtCacheSize is the max number of the prepared statements of the models kept open on DB, the least
+// recently used one is closed when it's exceeded. 0 turns the cache off, so each statement is prepared
+// for a call and closed after it.
+var StmtCacheSize = 100
+
+// cachedStmt is a prepared statement of the cache with the number of its users.
+type cachedStmt struct {
+	*sqlx.Stmt
+	query string
+	refs  int
+	// evicted tells if it's removed from the cache, it's closed when its last user releases it.
+	evicted bool
+}
+
+// stmtCache is an LRU cache of the prepared statements on DB keyed by their SQL text, safe for
+// concurrent use. A statement is acquired for a query and released after it, and it's never closed
+// while it's acquired.
+type stmtCache struct {
+	mu    sync.Mutex
+	db    *sqlx.DB
+	lru   *list.List // of *cachedStmt, the most recently used first
+	stmts map[string]*list.Element
+}
+
+// stmts is the statement cache of the package.
+var stmts = &stmtCache{}
+
+// acquire returns the prepared statement of the query from the cache, it's prepared on DB with ctx
+// and cached if it's not there. It must be released by release after it's used.
+func (c *stmtCache) acquire(ctx context.Context, query string) (*cachedStmt, error) {
+	c.mu.Lock()
+	closing := c.resetLocked()
+	db := c.db
+	if el, ok := c.stmts[query]; ok {
+		c.lru.MoveToFront(el)
+		s := el.Value.(*cachedStmt)
+		s.refs++
+		c.mu.Unlock()
+		closeStmts(closing)
+		return s, nil
+	}
+	c.mu.Unlock()
+	closeStmts(closing)
+
+	stmt, err := db.PreparexContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s := &cachedStmt{Stmt: stmt, query: query, refs: 1}
+	c.mu.Lock()
+	defer func() {
+		c.mu.Unlock()
+		closeStmts(closing)
+	}()
+	if _, ok := c.stmts[query]; ok || StmtCacheSize <= 0 || c.db != db {
+		// prepared by another call meanwhile, or not to be cached
+		s.evicted = true
+		return s, nil
+	}
+	c.stmts[query] = c.lru.PushFront(s)
+	for c.lru.Len() > StmtCacheSize {
+		if s := c.removeLocked(c.lru.Back()); s != nil {
+			closing = append(closing, s)
+		}
+	}
+	return s, nil
+}
+
+// release releases the statement s acquired by acquire, it's closed if it's evicted and unused.
+func (c *stmtCache) release(s *cachedStmt) {
+	c.mu.Lock()
+	s.refs--
+	closing := s.evicted && s.refs == 0
+	c.mu.Unlock()
+	if closing {
+		closeStmts([]*cachedStmt{s})
+	}
+}
+
+// close removes all the statements from the cache and closes them, the ones in use are closed
+// when they're released.
+func (c *stmtCache) close() {
+	c.mu.Lock()
+	closing := c.purgeLocked()
+	c.db = nil
+	c.mu.Unlock()
+	closeStmts(closing)
+}
+
+// resetLocked empties the cache if DB is changed since its statements are prepared, and returns the
+// ones to be closed. c.mu must be held.
+func (c *stmtCache) resetLocked() []*cachedStmt {
+	if c.lru != nil && c.db == DB {
+		return nil
+	}
+	closing := c.purgeLocked()
+	c.db, c.lru, c.stmts = DB, list.New(), map[string]*list.Element{}
+	return closing
+}
+
+// purgeLocked removes all the statements from the cache, and returns the ones to be closed now.
+// c.mu must be held.
+func (c *stmtCache) purgeLocked() []*cachedStmt {
+	if c.lru == nil {
+		return nil
+	}
+	var closing []*cachedStmt
+	for el := c.lru.Front(); el != nil; el = c.lru.Front() {
+		if s := c.removeLocked(el); s != nil {
+			closing = append(closing, s)
+		}
+	}
+	return closing
+}
+
+// removeLocked removes the statement of el from the cache, and returns it if it's to be closed now,
+// i.e. it's unused. c.mu must be held.
+func (c *stmtCache) removeLocked(el *list.Element) *cachedStmt {
+	s := c.lru.Remove(el).(*cachedStmt)
+	delete(c.stmts, s.query)
+	s.evicted = true
+	if s.refs > 0 {
+		return nil
+	}
+	return s
+}
+
+// closeStmts closes the statements ss.
+func closeStmts(ss []*cachedStmt) {
+	for _, s := range ss {
+		if err := s.Close(); err != nil {
+			logger().Warn("Close prepared statement error", "query", s.query, "error", err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// ClinicLocation is the time zone of the clinic, it's used for the calendar days
+// of the physicians without a TimeZone of their own.
+var ClinicLocation = time.Local
+
+// Location returns the time zone of the Physician, i.e. the IANA name in TimeZone
+// like "Europe/Berlin", or ClinicLocation if TimeZone is empty.
+func (_physician *Physician) Location() (*time.Location, error) {
+	if _physician.TimeZone == "" {
+		return ClinicLocation, nil
+	}
+	return time.LoadLocation(_physician.TimeZone)
+}
+
+// DayRange returns the start of the calendar day that t falls on in the location loc
+// and the start of the next day, both in StorageLocation. A day is not always
+// 24 hours long, e.g. it's 23 or 25 hours on the days of the DST transitions,
+// and the range covers the day as the clock on the wall in loc shows it.
+func DayRange(t time.Time, loc *time.Location) (start, end time.Time) {
+	y, m, d := t.In(loc).Date()
+	start = time.Date(y, m, d, 0, 0, 0, 0, loc)
+	end = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+	return start.In(StorageLocation), end.In(StorageLocation)
+}
+
+// FindAppointmentsBetween finds the appointments with appointment_date in the
+// half open range [start, end), ordered by appointment_date.
+func FindAppointmentsBetween(start, end time.Time) ([]Appointment, error) {
+	return FindAppointmentsBetweenContext(context.Background(), start, end)
+}
+
+// FindAppointmentsBetweenContext is same as FindAppointmentsBetween but with the context ctx.
+func FindAppointmentsBetweenContext(ctx context.Context, start, end time.Time) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsBetween", "appointments")
+	defer span.End()
+	return FindAppointmentsWhereContext(ctx, "appointment_date >= ? AND appointment_date < ? ORDER BY appointment_date ASC", start.In(StorageLocation), end.In(StorageLocation))
+}
+
+// FindAppointmentsOnDay finds the appointments on the calendar day that t falls on
+// in the location loc, see DayRange.
+func FindAppointmentsOnDay(t time.Time, loc *time.Location) ([]Appointment, error) {
+	return FindAppointmentsOnDayContext(context.Background(), t, loc)
+}
+
+// FindAppointmentsOnDayContext is same as FindAppointmentsOnDay but with the context ctx.
+func FindAppointmentsOnDayContext(ctx context.Context, t time.Time, loc *time.Location) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "FindAppointmentsOnDay", "appointments")
+	defer span.End()
+	start, end := DayRange(t, loc)
+	return FindAppointmentsBetweenContext(ctx, start, end)
+}
+
+// AppointmentsOnDay finds the appointments of the Physician on the calendar day that
+// t falls on in the time zone of the Physician, see Location.
+func (_physician *Physician) AppointmentsOnDay(t time.Time) ([]Appointment, error) {
+	return _physician.AppointmentsOnDayContext(context.Background(), t)
+}
+
+// AppointmentsOnDayContext is same as AppointmentsOnDay but with the context ctx.
+func (_physician *Physician) AppointmentsOnDayContext(ctx context.Context, t time.Time) ([]Appointment, error) {
+	ctx, span := startSpan(ctx, "Physician.AppointmentsOnDay", "physicians")
+	defer span.End()
+	loc, err := _physician.Location()
+	if err != nil {
+		return nil, err
+	}
+	start, end := DayRange(t, loc)
+	return FindAppointmentsWhereContext(ctx, "physician_id = ? AND appointment_date >= ? AND appointment_date < ? ORDER BY appointment_date ASC", _physician.Id, start, end)
+}
+
+// AppointmentDateIn returns the AppointmentDate in the location loc, e.g. the time
+// zone of the physician, or the zero time if it's not set.
+func (_appointment *Appointment) AppointmentDateIn(loc *time.Location) time.Time {
+	if _appointment.AppointmentDate == nil {
+		return time.Time{}
+	}
+	return _appointment.AppointmentDate.In(loc)
+}
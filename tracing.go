@@ -0,0 +1,84 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerProvider provides the tracer of the spans of the model calls, the global one of otel is used
+// if it's nil. Each call of a model function or method, e.g. FindPatient or AppointmentPage.Next,
+// starts a span named after it with the db.system and db.sql.table attributes, and each of its queries
+// is a child span named after the operation of the query, e.g. SELECT, with the db.statement and the
+// row count attributes and the error of the query recorded. The calls made by a call, e.g. the
+// association queries of PhysicianIncludesWhere, are the child spans of its span.
+//
+// Each function or method has a Context variant, e.g. FindPatientContext of FindPatient, whose span is
+// a child of the one in its context ctx. Use them to make the spans the children of the ones of the app,
+// the other ones start their spans as roots of new traces.
+//
+// In tests it can be a provider of the otel sdk with an in-memory exporter:
+//
+//	exporter := tracetest.NewInMemoryExporter()
+//	models.TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+var TracerProvider trace.TracerProvider
+
+// tracerName is the instrumentation name of the tracer of the package.
+const tracerName = "github.com/railstack/gor_models_sample"
+
+// startSpan starts the span of the model call name on the table as a child of the span in ctx.
+func startSpan(ctx context.Context, name, table string) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", dbSystem()), attribute.String("db.sql.table", table)))
+}
+
+// startQuerySpan starts the span of a query of the operation, e.g. "SELECT", issued at start
+// as a child of the span in ctx, i.e. the one of the model call, see traceQuery.
+func startQuerySpan(ctx context.Context, operation string, start time.Time) (context.Context, trace.Span) {
+	return tracer().Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithTimestamp(start),
+		trace.WithAttributes(attribute.String("db.system", dbSystem()), attribute.String("db.operation", operation)))
+}
+
+// tracer returns the tracer of the package from TracerProvider.
+func tracer() trace.Tracer {
+	tp := TracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// dbSystem returns the db.system attribute of the driver of DB.
+func dbSystem() string {
+	if DB == nil {
+		return "other_sql"
+	}
+	switch driverName() {
+	case "mysql":
+		return "mysql"
+	case "postgres", "pgx":
+		return "postgresql"
+	case "sqlite3":
+		return "sqlite"
+	}
+	return "other_sql"
+}
+
+// traceQuery adds the query and its number of rows to the span of the query in ctx, see startQuerySpan,
+// and records the error of the query on it.
+// The row count is the number of the rows read by a SELECT or the one of the rows affected otherwise.
+func traceQuery(ctx context.Context, query string, rows int64, err error) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(attribute.String("db.statement", query), attribute.Int64("db.row_count", rows))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newSpanRecorder sets TracerProvider to one exporting the spans to the returned in-memory exporter for the test.
+func newSpanRecorder(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	saved := TracerProvider
+	TracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { TracerProvider = saved })
+	return exporter
+}
+
+// spanNamed returns the ended span named name, it fails the test if there's none.
+func spanNamed(t *testing.T, spans tracetest.SpanStubs, name string) tracetest.SpanStub {
+	t.Helper()
+	for _, v := range spans {
+		if v.Name == name {
+			return v
+		}
+	}
+	t.Fatalf("no span named %q in %d spans", name, len(spans))
+	return tracetest.SpanStub{}
+}
+
+// hasAttribute reports whether the span has the attribute kv.
+func hasAttribute(span tracetest.SpanStub, kv attribute.KeyValue) bool {
+	for _, v := range span.Attributes {
+		if v == kv {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTracingSpans(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	exporter := newSpanRecorder(t)
+	mock.ExpectQuery("SELECT .* FROM .*patients").WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John"))
+	ctx, app := TracerProvider.Tracer("app").Start(context.Background(), "app")
+	if _, err := FindPatientContext(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	app.End()
+	spans := exporter.GetSpans()
+	root := spanNamed(t, spans, "app")
+	call := spanNamed(t, spans, "FindPatient")
+	query := spanNamed(t, spans, "SELECT")
+	if call.Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Errorf("FindPatient span isn't a child of the span in ctx")
+	}
+	if query.Parent.SpanID() != call.SpanContext.SpanID() {
+		t.Errorf("SELECT span isn't a child of the FindPatient span")
+	}
+	if !hasAttribute(call, attribute.String("db.system", "mysql")) || !hasAttribute(call, attribute.String("db.sql.table", "patients")) {
+		t.Errorf("FindPatient span attributes = %v", call.Attributes)
+	}
+	if !hasAttribute(query, attribute.Int64("db.row_count", 1)) {
+		t.Errorf("SELECT span attributes = %v", query.Attributes)
+	}
+}
+
+func TestTracingQueryError(t *testing.T) {
+	mock := newMockDB(t, "mysql")
+	exporter := newSpanRecorder(t)
+	failed := errors.New("failed")
+	mock.ExpectQuery("SELECT .* FROM .*patients").WillReturnError(failed)
+	if _, err := FindPatient(1); !errors.Is(err, failed) {
+		t.Fatalf("FindPatient error = %v, want %v", err, failed)
+	}
+	spans := exporter.GetSpans()
+	call := spanNamed(t, spans, "FindPatient")
+	query := spanNamed(t, spans, "SELECT")
+	if call.Parent.IsValid() {
+		t.Errorf("FindPatient span isn't a root span")
+	}
+	if query.Parent.SpanID() != call.SpanContext.SpanID() {
+		t.Errorf("SELECT span isn't a child of the FindPatient span")
+	}
+	if query.Status.Code != codes.Error {
+		t.Errorf("SELECT span status = %v, want the error", query.Status)
+	}
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// upsert inserts the named arg, a map or a model struct, into table of the model with the columns keys.
+// If the record conflicts with an existed one, the updateCols of the existed record are
+// updated with the new values instead. The id of the inserted or updated record is returned.
+//
+// MySQL detects the conflict on any unique key of the table by ON DUPLICATE KEY UPDATE,
+// so conflictCols is only used by the ON CONFLICT clause of PostgreSQL and SQLite. A record
+// conflicting on another unique key is updated with MySQL, and its id is returned.
+// Use upsertWithId to write a record of a known id.
+func upsert(ctx context.Context, model, table string, arg interface{}, keys, conflictCols, updateCols []string) (int64, error) {
+	if DB == nil {
+		return 0, ErrNotOpen
+	}
+	sqlStr := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, table, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
+	switch driverName() {
+	case "mysql":
+		// LAST_INSERT_ID(id) makes LastInsertId() return the id of an updated record too
+		sets := []string{"id = LAST_INSERT_ID(id)"}
+		for _, v := range updateCols {
+			sets = append(sets, fmt.Sprintf("%s = VALUES(%s)", v, v))
+		}
+		result, err := dbNamedExec(ctx, DB, model, sqlStr+" ON DUPLICATE KEY UPDATE "+strings.Join(sets, ", "), arg)
+		if err != nil {
+			return 0, err
+		}
+		lastId, err := result.LastInsertId()
+		if err != nil {
+			logger().Error("Get the last insert id error", "model", model, "error", err)
+			return 0, err
+		}
+		return lastId, nil
+	case "postgres", "pgx", "sqlite3":
+		if len(conflictCols) == 0 {
+			return 0, fmt.Errorf("No conflict columns provided for the upsert of %s", table)
+		}
+		// a DO UPDATE with no real change is still needed to return the id of the existed record
+		sets := []string{fmt.Sprintf("%s = EXCLUDED.%s", conflictCols[0], conflictCols[0])}
+		if len(updateCols) > 0 {
+			sets = sets[:0]
+		}
+		for _, v := range updateCols {
+			sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", v, v))
+		}
+		sqlStr = fmt.Sprintf("%s ON CONFLICT (%s) DO UPDATE SET %s RETURNING id", sqlStr, strings.Join(conflictCols, ","), strings.Join(sets, ", "))
+		query, args, err := sqlx.Named(sqlStr, arg)
+		if err != nil {
+			logger().Error("Bind named params error", "model", model, "error", err)
+			return 0, err
+		}
+		var id int64
+		err = dbGet(ctx, DB, model, &id, rebind(query), args...)
+		if err != nil {
+			return 0, err
+		}
+		return id, nil
+	default:
+		return 0, fmt.Errorf("Upsert is not supported by the %s driver", driverName())
+	}
+}
+
+// upsertWithId writes the named arg, a model struct with its id set to id, into table of the model with the
+// columns keys including "id" by a single statement, so it's safe against a concurrent write of the same id: the
+// record is created with the id if it doesn't exist, or the updateCols of the existed record of the id are updated.
+// It reports whether anything is written, i.e. false if the record exists and updateCols is empty.
+// Save uses it to write an object which isn't loaded.
+//
+// Unlike upsert the record conflicting on another unique key than the id is never updated, ErrDuplicateKey
+// is returned instead. With MySQL the updates are guarded by the id for it, as ON DUPLICATE KEY UPDATE
+// detects the conflict on any unique key.
+func upsertWithId(ctx context.Context, model, table string, id int64, arg interface{}, keys, updateCols []string) (bool, error) {
+	if DB == nil {
+		return false, ErrNotOpen
+	}
+	sqlStr := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, table, strings.Join(keys, ","), ":"+strings.Join(keys, ",:"))
+	switch driverName() {
+	case "mysql":
+		sets := []string{}
+		for _, v := range updateCols {
+			sets = append(sets, fmt.Sprintf("%s = IF(id = VALUES(id), VALUES(%s), %s)", v, v, v))
+		}
+		// LAST_INSERT_ID(id) makes LastInsertId() return the id of the conflicting record
+		sets = append(sets, "id = LAST_INSERT_ID(id)")
+		result, err := dbNamedExec(ctx, DB, model, sqlStr+" ON DUPLICATE KEY UPDATE "+strings.Join(sets, ", "), arg)
+		if err != nil {
+			return false, err
+		}
+		lastId, err := result.LastInsertId()
+		if err != nil {
+			return false, err
+		}
+		if lastId != 0 && lastId != id {
+			return false, fmt.Errorf("%s conflicts with the record %d: %w", model, lastId, ErrDuplicateKey)
+		}
+		cnt, err := result.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		// an update which changes nothing affects no rows
+		return cnt > 0 || len(updateCols) > 0, nil
+	case "postgres", "pgx", "sqlite3":
+		action := "DO NOTHING"
+		if len(updateCols) > 0 {
+			sets := make([]string, len(updateCols))
+			for i, v := range updateCols {
+				sets[i] = fmt.Sprintf("%s = EXCLUDED.%s", v, v)
+			}
+			action = "DO UPDATE SET " + strings.Join(sets, ", ")
+		}
+		query, args, err := sqlx.Named(sqlStr+" ON CONFLICT (id) "+action+" RETURNING id", arg)
+		if err != nil {
+			logger().Error("Bind named params error", "model", model, "error", err)
+			return false, err
+		}
+		var returned int64
+		err = dbGet(ctx, DB, model, &returned, rebind(query), args...)
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		if isUniqueViolation(err) {
+			return false, fmt.Errorf("%s: %w: %w", model, ErrDuplicateKey, err)
+		}
+		return err == nil, err
+	default:
+		return false, fmt.Errorf("Upsert is not supported by the %s driver", driverName())
+	}
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+	"math"
+	"reflect"
 )
 
 func buildIdsHolder(n int) (idsHolder string) {
@@ -21,3 +23,96 @@ func allKeys(am map[string]interface{}) []string {
 	}
 	return keys
 }
+
+// assignColumns sets the fields of the model struct pointed by dst with the values of
+// the attributes map am, matching the keys with the db tags of the fields.
+// The values are converted to the field types if needed, see convertValue, a nil value sets a zero one.
+func assignColumns(dst interface{}, am map[string]interface{}) error {
+	rv := reflect.ValueOf(dst).Elem()
+	rt := rv.Type()
+	fields := map[string]int{}
+	for i := 0; i < rt.NumField(); i++ {
+		if tag := rt.Field(i).Tag.Get("db"); tag != "" && tag != "-" {
+			fields[tag] = i
+		}
+	}
+	for k, v := range am {
+		i, ok := fields[k]
+		if !ok {
+			return fmt.Errorf("Unknown column %s of %s", k, rt.Name())
+		}
+		f := rv.Field(i)
+		if v == nil {
+			f.Set(reflect.Zero(f.Type()))
+			continue
+		}
+		val := reflect.ValueOf(v)
+		if cv, ok := convertValue(val, f.Type()); ok {
+			f.Set(cv)
+			continue
+		}
+		if f.Kind() == reflect.Ptr {
+			if cv, ok := convertValue(val, f.Type().Elem()); ok {
+				p := reflect.New(f.Type().Elem())
+				p.Elem().Set(cv)
+				f.Set(p)
+				continue
+			}
+		}
+		return fmt.Errorf("Can't assign a %T value to the column %s of %s", v, k, rt.Name())
+	}
+	return nil
+}
+
+// convertValue converts val to the type t if it's assignable, of the same kind, e.g. a string to a
+// named string type, or a number which fits t without a loss, e.g. an int to an int8 within its range
+// or an integral float64 to an int64. The other conversions, e.g. an int to a string, aren't done.
+func convertValue(val reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	switch {
+	case val.Type().AssignableTo(t):
+		return val, true
+	case val.Kind() == t.Kind() && val.Type().ConvertibleTo(t) && !isNumberKind(t.Kind()):
+		return val.Convert(t), true
+	}
+	z := reflect.New(t).Elem()
+	switch {
+	case isIntKind(val.Kind()) && isIntKind(t.Kind()) && !z.OverflowInt(val.Int()),
+		isIntKind(val.Kind()) && isUintKind(t.Kind()) && val.Int() >= 0 && !z.OverflowUint(uint64(val.Int())),
+		isUintKind(val.Kind()) && isUintKind(t.Kind()) && !z.OverflowUint(val.Uint()),
+		isUintKind(val.Kind()) && isIntKind(t.Kind()) && val.Uint() <= math.MaxInt64 && !z.OverflowInt(int64(val.Uint())),
+		(isIntKind(val.Kind()) || isUintKind(val.Kind())) && isFloatKind(t.Kind()),
+		isFloatKind(val.Kind()) && isFloatKind(t.Kind()) && !z.OverflowFloat(val.Float()):
+		return val.Convert(t), true
+	case isFloatKind(val.Kind()) && (isIntKind(t.Kind()) || isUintKind(t.Kind())):
+		f := val.Float()
+		if f != math.Trunc(f) || math.IsInf(f, 0) {
+			return val, false
+		}
+		// the range is checked on the float, as the conversion of an out of range one is undefined
+		if isIntKind(t.Kind()) && f >= math.MinInt64 && f < math.MaxInt64 && !z.OverflowInt(int64(f)) ||
+			isUintKind(t.Kind()) && f >= 0 && f < math.MaxUint64 && !z.OverflowUint(uint64(f)) {
+			return val.Convert(t), true
+		}
+	}
+	return val, false
+}
+
+// isIntKind reports whether k is a kind of the signed integers.
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+// isUintKind reports whether k is a kind of the unsigned integers.
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+// isFloatKind reports whether k is a kind of the floats.
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// isNumberKind reports whether k is a kind of the integers or the floats.
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || isFloatKind(k)
+}
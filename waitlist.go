@@ -0,0 +1,269 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The statuses of Waitlist.
+const (
+	WaitlistWaiting   = "waiting"
+	WaitlistOffered   = "offered"
+	WaitlistBooked    = "booked"
+	WaitlistWithdrawn = "withdrawn"
+)
+
+// WaitlistOffer is a freed slot offered to a waitlisted patient.
+type WaitlistOffer struct {
+	Waitlist Waitlist
+	Slot     Slot
+}
+
+// OfferNotifier delivers the offers of the freed slots to the waitlisted patients.
+type OfferNotifier interface {
+	NotifyOffer(o WaitlistOffer) error
+}
+
+// WaitlistNotifier delivers the offers made by OfferSlot, the offers are only recorded
+// in the waitlists if it's nil.
+var WaitlistNotifier OfferNotifier
+
+// WaitlistOfferTTL is how long an offer of OfferSlot may be accepted, the expired offers are
+// given to the next waitlisted patients by ExpireWaitlistOffers.
+var WaitlistOfferTTL = 24 * time.Hour
+
+// JoinWaitlist puts the patient of patientId on the waitlist of the physician of physicianId.
+// The from and until are the preferred window of the appointment, nil for an open end.
+func JoinWaitlist(patientId, physicianId int64, from, until *time.Time) (*Waitlist, error) {
+	return JoinWaitlistContext(context.Background(), patientId, physicianId, from, until)
+}
+
+// JoinWaitlistContext is same as JoinWaitlist but with the context ctx.
+func JoinWaitlistContext(ctx context.Context, patientId, physicianId int64, from, until *time.Time) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "JoinWaitlist", "waitlists")
+	defer span.End()
+	_waitlist := &Waitlist{PatientId: patientId, PhysicianId: physicianId, PreferredFrom: from, PreferredUntil: until, Status: WaitlistWaiting}
+	if _, err := _waitlist.CreateContext(ctx); err != nil {
+		return nil, err
+	}
+	return _waitlist, nil
+}
+
+// offerFreedSlot offers the slot of the cancelled or destroyed appointment to the waitlisted patients.
+// The slot is only freed once the transaction of ctx is committed, so it's offered then, see onCommit.
+// The appointment is changed already, so an error of the offer is only logged.
+func offerFreedSlot(ctx context.Context, _appointment *Appointment) {
+	if _appointment.PhysicianId == nil || _appointment.AppointmentDate == nil {
+		return
+	}
+	id, physicianId, start := _appointment.Id, *_appointment.PhysicianId, *_appointment.AppointmentDate
+	onCommit(ctx, func() error {
+		if _, err := OfferSlotContext(withoutTx(ctx), physicianId, start); err != nil {
+			logger().Error("Offer freed slot error", "model", "Appointment", "id", id, "error", err)
+		}
+		return nil
+	})
+}
+
+// offerFreedSlots offers the slots of the destroyed appointments which take up time, see offerFreedSlot.
+func offerFreedSlots(ctx context.Context, appointments []Appointment) {
+	for i := range appointments {
+		switch appointments[i].Status {
+		case AppointmentCancelled, AppointmentNoShow:
+			continue
+		}
+		offerFreedSlot(ctx, &appointments[i])
+	}
+}
+
+// OfferSlot offers the slot starting at start of the physician of physicianId to the first matching
+// waitlisted patient, i.e. the longest waiting one whose preferred window covers the slot, and sends the
+// offer through WaitlistNotifier. The waitlists of the excludeIds are skipped. The slot is only offered
+// if it's in the future, free and not offered already, an offer expires after WaitlistOfferTTL.
+// The offered waitlist is returned, or nil if none.
+func OfferSlot(physicianId int64, start time.Time, excludeIds ...int64) (*Waitlist, error) {
+	return OfferSlotContext(context.Background(), physicianId, start, excludeIds...)
+}
+
+// OfferSlotContext is same as OfferSlot but with the context ctx.
+func OfferSlotContext(ctx context.Context, physicianId int64, start time.Time, excludeIds ...int64) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "OfferSlot", "waitlists")
+	defer span.End()
+	now := time.Now()
+	if !start.After(now) {
+		return nil, nil
+	}
+	slot := Slot{Start: start, End: start.Add(DefaultAppointmentLength)}
+	appointments, err := FindConflictingAppointmentsContext(ctx, physicianId, slot.Start, slot.End)
+	if err != nil || len(appointments) > 0 {
+		return nil, err
+	}
+	offered, err := WaitlistCountWhereContext(ctx, "physician_id = ? AND status = ? AND offered_start > ? AND offered_start < ? AND offered_at > ?",
+		physicianId, WaitlistOffered, slot.Start.Add(-DefaultAppointmentLength).In(StorageLocation), slot.End.In(StorageLocation), now.Add(-WaitlistOfferTTL))
+	if err != nil || offered > 0 {
+		return nil, err
+	}
+	where := "physician_id = ? AND status = ? AND (preferred_from IS NULL OR preferred_from <= ?) AND (preferred_until IS NULL OR preferred_until >= ?)"
+	args := []interface{}{physicianId, WaitlistWaiting, slot.Start.In(StorageLocation), slot.End.In(StorageLocation)}
+	if len(excludeIds) > 0 {
+		where += fmt.Sprintf(" AND id NOT IN (?%s)", strings.Repeat(",?", len(excludeIds)-1))
+		for _, id := range excludeIds {
+			args = append(args, id)
+		}
+	}
+	waitlists, err := FindWaitlistsWhereContext(ctx, where+" ORDER BY created_at ASC, id ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range waitlists {
+		_waitlist := &waitlists[i]
+		// the waitlist is claimed only if it's still waiting, another offer may take it meanwhile
+		cnt, err := UpdateWaitlistsBySqlContext(ctx, "UPDATE waitlists SET status = ?, offered_start = ?, offered_at = ?, updated_at = ? WHERE id = ? AND status = ?",
+			WaitlistOffered, slot.Start.In(StorageLocation), now, now, _waitlist.Id, WaitlistWaiting)
+		if err != nil {
+			return nil, err
+		}
+		if cnt == 0 {
+			continue
+		}
+		if err = _waitlist.ReloadContext(ctx); err != nil {
+			return nil, err
+		}
+		if WaitlistNotifier != nil {
+			if err = WaitlistNotifier.NotifyOffer(WaitlistOffer{Waitlist: *_waitlist, Slot: slot}); err != nil {
+				return _waitlist, err
+			}
+		}
+		return _waitlist, nil
+	}
+	return nil, nil
+}
+
+// AcceptOffer books the offered slot of the Waitlist as an appointment of the patient if the slot
+// is still free, see ConflictError, and returns the appointment. An offer expired after WaitlistOfferTTL
+// can't be accepted. The booking is done in a transaction, so the slot can't be booked meanwhile.
+func (_waitlist *Waitlist) AcceptOffer() (*Appointment, error) {
+	return _waitlist.AcceptOfferContext(context.Background())
+}
+
+// AcceptOfferContext is same as AcceptOffer but with the context ctx.
+func (_waitlist *Waitlist) AcceptOfferContext(ctx context.Context) (*Appointment, error) {
+	ctx, span := startSpan(ctx, "Waitlist.AcceptOffer", "waitlists")
+	defer span.End()
+	if _waitlist.Status != WaitlistOffered || _waitlist.OfferedStart == nil {
+		return nil, fmt.Errorf("Waitlist.AcceptOffer error: %w", ErrNoOffer)
+	}
+	if _waitlist.OfferedAt != nil && !_waitlist.OfferedAt.Add(WaitlistOfferTTL).After(time.Now()) {
+		return nil, fmt.Errorf("Waitlist.AcceptOffer error: %w", ErrOfferExpired)
+	}
+	start := *_waitlist.OfferedStart
+	saved := *_waitlist
+	var _appointment *Appointment
+	err := inTransaction(ctx, func(ctx context.Context) error {
+		// the transaction may be retried, see WithTxContext
+		*_waitlist = saved
+		if err := checkConflicts(ctx, _waitlist.PhysicianId, []time.Time{start}); err != nil {
+			return err
+		}
+		physicianId, patientId := _waitlist.PhysicianId, _waitlist.PatientId
+		_appointment = &Appointment{AppointmentDate: &start, PhysicianId: &physicianId, PatientId: &patientId}
+		if _, err := _appointment.CreateContext(ctx); err != nil {
+			return err
+		}
+		_waitlist.Status = WaitlistBooked
+		_waitlist.AppointmentId = &_appointment.Id
+		return _waitlist.SaveContext(ctx)
+	})
+	if err != nil {
+		*_waitlist = saved
+		return nil, err
+	}
+	return _appointment, nil
+}
+
+// ExpireWaitlistOffers puts the waitlists whose offers are expired after WaitlistOfferTTL back to waiting,
+// keeping their places, and offers their slots to the next matching waitlists, see OfferSlot. It should be
+// run periodically, e.g. by a ticker of the app. The number of the expired offers is returned.
+func ExpireWaitlistOffers() (int, error) {
+	return ExpireWaitlistOffersContext(context.Background())
+}
+
+// ExpireWaitlistOffersContext is same as ExpireWaitlistOffers but with the context ctx.
+func ExpireWaitlistOffersContext(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, "ExpireWaitlistOffers", "waitlists")
+	defer span.End()
+	now := time.Now()
+	waitlists, err := FindWaitlistsWhereContext(ctx, "status = ? AND offered_at <= ?", WaitlistOffered, now.Add(-WaitlistOfferTTL))
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	errs := []error{}
+	for _, v := range waitlists {
+		// the offer is only expired if it's not accepted or declined meanwhile
+		cnt, err := UpdateWaitlistsBySqlContext(ctx, "UPDATE waitlists SET status = ?, offered_start = NULL, offered_at = NULL, updated_at = ? WHERE id = ? AND status = ? AND offered_at <= ?",
+			WaitlistWaiting, now, v.Id, WaitlistOffered, now.Add(-WaitlistOfferTTL))
+		if err != nil {
+			return expired, err
+		}
+		if cnt == 0 || v.OfferedStart == nil {
+			continue
+		}
+		expired++
+		if _, err = OfferSlotContext(ctx, v.PhysicianId, *v.OfferedStart, v.Id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return expired, errors.Join(errs...)
+}
+
+// DeclineOffer puts the Waitlist back to waiting, keeping its place, and offers the slot to the
+// next matching waitlist, which is returned, see OfferSlot.
+func (_waitlist *Waitlist) DeclineOffer() (*Waitlist, error) {
+	return _waitlist.DeclineOfferContext(context.Background())
+}
+
+// DeclineOfferContext is same as DeclineOffer but with the context ctx.
+func (_waitlist *Waitlist) DeclineOfferContext(ctx context.Context) (*Waitlist, error) {
+	ctx, span := startSpan(ctx, "Waitlist.DeclineOffer", "waitlists")
+	defer span.End()
+	if _waitlist.Status != WaitlistOffered || _waitlist.OfferedStart == nil {
+		return nil, fmt.Errorf("Waitlist.DeclineOffer error: %w", ErrNoOffer)
+	}
+	start := *_waitlist.OfferedStart
+	_waitlist.Status = WaitlistWaiting
+	_waitlist.OfferedStart = nil
+	_waitlist.OfferedAt = nil
+	if err := _waitlist.SaveContext(ctx); err != nil {
+		return nil, err
+	}
+	return OfferSlotContext(ctx, _waitlist.PhysicianId, start, _waitlist.Id)
+}
+
+// Withdraw takes the patient off the waitlist, a slot offered to the Waitlist is offered to the next one.
+func (_waitlist *Waitlist) Withdraw() error {
+	return _waitlist.WithdrawContext(context.Background())
+}
+
+// WithdrawContext is same as Withdraw but with the context ctx.
+func (_waitlist *Waitlist) WithdrawContext(ctx context.Context) error {
+	ctx, span := startSpan(ctx, "Waitlist.Withdraw", "waitlists")
+	defer span.End()
+	offered := _waitlist.OfferedStart
+	if _waitlist.Status != WaitlistOffered {
+		offered = nil
+	}
+	_waitlist.Status = WaitlistWithdrawn
+	if err := _waitlist.SaveContext(ctx); err != nil {
+		return err
+	}
+	if offered != nil {
+		if _, err := OfferSlotContext(ctx, _waitlist.PhysicianId, *offered, _waitlist.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}